@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/coa"
+	"fiber.com/session-api/internal/journal"
+	"fiber.com/session-api/internal/recurring"
+	"fiber.com/session-api/internal/trash"
+
+	"gorm.io/gorm"
+)
+
+// runCommand handles one-shot maintenance commands, e.g.
+//
+//	go run . verify-chain
+//	go run . chain-checkpoint 2026-01
+//	go run . coa-template sak-etap
+//	go run . coa-import chart.xlsx [upsert|replace]
+//	go run . purge-trash
+//	go run . run-recurring
+//	go run . run-reversals
+//
+// It returns the process exit code.
+func runCommand(db *gorm.DB, args []string) int {
+	repo := journal.NewRepository(db)
+	actor := audit.Actor{Name: "cli", Type: audit.ActorTypeSystem}
+
+	switch args[0] {
+	case "verify-chain":
+		res, err := journal.VerifyChain(repo)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "verify-chain: %v\n", err)
+			return 1
+		}
+		if !res.Valid {
+			b := res.FirstBreak
+			fmt.Printf("BROKEN after %d valid entries: seq %d (%s, %s): %s\n",
+				res.CheckedEntries, b.ChainSeq, b.Reference, b.JournalID, b.Reason)
+			return 2
+		}
+		fmt.Printf("OK: %d entries, head seq %d hash %s\n", res.CheckedEntries, res.HeadSeq, res.HeadHash)
+		return 0
+
+	case "chain-checkpoint":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: chain-checkpoint YYYY-MM")
+			return 1
+		}
+		cp, err := journal.BuildCheckpoint(repo, args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "chain-checkpoint: %v\n", err)
+			return 1
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(cp); err != nil {
+			fmt.Fprintf(os.Stderr, "chain-checkpoint: %v\n", err)
+			return 1
+		}
+		return 0
+
+	case "coa-template":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: coa-template NAME")
+			return 1
+		}
+		svc := coa.NewService(coa.NewRepository(db), audit.NewService(audit.NewRepository(db)))
+		return runImport("coa-template", db, func(tx *gorm.DB) (*coa.ImportResult, error) {
+			return svc.ApplyTemplate(args[1], actor, tx)
+		})
+
+	case "coa-import":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: coa-import FILE [upsert|replace]")
+			return 1
+		}
+		mode := coa.ImportModeUpsert
+		if len(args) > 2 {
+			mode = args[2]
+		}
+		f, err := os.Open(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "coa-import: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+
+		rows, err := coa.DecodeChart(f, strings.TrimPrefix(strings.ToLower(filepath.Ext(args[1])), "."))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "coa-import: %v\n", err)
+			return 1
+		}
+		svc := coa.NewService(coa.NewRepository(db), audit.NewService(audit.NewRepository(db)))
+		return runImport("coa-import", db, func(tx *gorm.DB) (*coa.ImportResult, error) {
+			return svc.Import(rows, mode, false, actor, tx)
+		})
+
+	case "purge-trash":
+		svc := trash.NewService(trash.NewRepository(db))
+		var result *trash.PurgeResult
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			result, err = svc.PurgeExpired(actor, tx)
+			return err
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "purge-trash: %v\n", err)
+			return 1
+		}
+		for _, s := range result.Skipped {
+			fmt.Printf("skipped %s %s: %s\n", s.Entity, s.ID, s.Reason)
+		}
+		fmt.Printf("OK: purged %d journal entries, %d accounts, %d users\n",
+			result.Purged[trash.EntityJournal], result.Purged[trash.EntityCOA], result.Purged[trash.EntityUser])
+		return 0
+
+	case "run-recurring":
+		journalSvc := journal.NewService(journal.NewRepository(db), audit.NewService(audit.NewRepository(db)))
+		svc := recurring.NewService(recurring.NewRepository(db), journalSvc)
+		result, err := svc.RunDue(db, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "run-recurring: %v\n", err)
+			return 1
+		}
+		for _, f := range result.Failed {
+			fmt.Printf("failed %s on %s: %s\n", f.TemplateID, f.RunDate.Format("2006-01-02"), f.Reason)
+		}
+		fmt.Printf("OK: booked %d recurring journals\n", result.Created)
+		if len(result.Failed) > 0 {
+			return 1
+		}
+		return 0
+
+	case "run-reversals":
+		svc := journal.NewService(journal.NewRepository(db), audit.NewService(audit.NewRepository(db)))
+		result, err := svc.RunReversals(db, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "run-reversals: %v\n", err)
+			return 1
+		}
+		for _, f := range result.Failed {
+			fmt.Printf("failed %s: %s\n", f.Reference, f.Reason)
+		}
+		fmt.Printf("OK: booked %d reversals\n", result.Created)
+		if len(result.Failed) > 0 {
+			return 1
+		}
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q (available: verify-chain, chain-checkpoint, coa-template, coa-import, purge-trash, run-recurring, run-reversals)\n", args[0])
+		return 1
+	}
+}
+
+// runImport runs fn in a transaction and prints its summary, rolling back when
+// the chart fails validation.
+func runImport(name string, db *gorm.DB, fn func(tx *gorm.DB) (*coa.ImportResult, error)) int {
+	var result *coa.ImportResult
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = fn(tx)
+		if err != nil {
+			return err
+		}
+		if len(result.Errors) > 0 {
+			return errors.New("chart failed validation")
+		}
+		return nil
+	})
+
+	if result != nil {
+		for _, e := range result.Errors {
+			fmt.Fprintf(os.Stderr, "line %d %s: %s\n", e.Line, e.Code, e.Message)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		return 1
+	}
+
+	fmt.Printf("OK: %d created, %d updated, %d unchanged, %d deleted\n",
+		result.Created, result.Updated, result.Unchanged, result.Deleted)
+	return 0
+}
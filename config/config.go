@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -18,6 +19,52 @@ type Config struct {
 	SessionSecret  string
 	JWTSecret      string
 	JWTExpiresHour int
+
+	ChainSigningKey string
+
+	COAMaxDepth          int
+	COAEnforceCodePrefix bool
+
+	TrashRetentionDays int
+
+	OpeningSuspenseCode string
+
+	ARControlCode string
+	APControlCode string
+
+	AgingBuckets string
+
+	SchedulerEnabled         bool
+	SchedulerIntervalMinutes int
+
+	IdempotencyTTLHours int
+
+	BulkBatchSize      int
+	BulkAsyncThreshold int
+
+	JobWorkers     int
+	JobPollSeconds int
+	JobMaxAttempts int
+
+	WebhookPollSeconds    int
+	WebhookMaxAttempts    int
+	WebhookTimeoutSeconds int
+
+	SSEHeartbeatSeconds int
+
+	TOTPIssuer        string
+	MFARequiredRoles  []string
+	MFAPendingMinutes int
+
+	OIDCIssuer            string
+	OIDCClientID          string
+	OIDCClientSecret      string
+	OIDCRedirectURL       string
+	OIDCScopes            []string
+	OIDCGroupsClaim       string
+	OIDCRoleMap           map[string]string
+	OIDCDefaultRole       string
+	OIDCPostLoginRedirect string
 }
 
 var AppConfig *Config
@@ -28,6 +75,22 @@ func Load() {
 	}
 
 	jwtExpires, _ := strconv.Atoi(getEnv("JWT_EXPIRES_HOURS", "24"))
+	mfaPending, _ := strconv.Atoi(getEnv("MFA_PENDING_MINUTES", "5"))
+	coaMaxDepth, _ := strconv.Atoi(getEnv("COA_MAX_DEPTH", "5"))
+	coaEnforcePrefix, _ := strconv.ParseBool(getEnv("COA_ENFORCE_CODE_PREFIX", "false"))
+	trashRetention, _ := strconv.Atoi(getEnv("TRASH_RETENTION_DAYS", "30"))
+	schedulerEnabled, _ := strconv.ParseBool(getEnv("SCHEDULER_ENABLED", "true"))
+	schedulerInterval, _ := strconv.Atoi(getEnv("SCHEDULER_INTERVAL_MINUTES", "60"))
+	idempotencyTTL, _ := strconv.Atoi(getEnv("IDEMPOTENCY_TTL_HOURS", "24"))
+	bulkBatchSize, _ := strconv.Atoi(getEnv("BULK_BATCH_SIZE", "100"))
+	bulkAsyncThreshold, _ := strconv.Atoi(getEnv("BULK_ASYNC_THRESHOLD", "200"))
+	jobWorkers, _ := strconv.Atoi(getEnv("JOB_WORKERS", "2"))
+	jobPoll, _ := strconv.Atoi(getEnv("JOB_POLL_SECONDS", "5"))
+	jobMaxAttempts, _ := strconv.Atoi(getEnv("JOB_MAX_ATTEMPTS", "3"))
+	webhookPoll, _ := strconv.Atoi(getEnv("WEBHOOK_POLL_SECONDS", "5"))
+	webhookMaxAttempts, _ := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "8"))
+	webhookTimeout, _ := strconv.Atoi(getEnv("WEBHOOK_TIMEOUT_SECONDS", "10"))
+	sseHeartbeat, _ := strconv.Atoi(getEnv("SSE_HEARTBEAT_SECONDS", "15"))
 
 	AppConfig = &Config{
 		Port:           getEnv("PORT", "8080"),
@@ -39,12 +102,94 @@ func Load() {
 		SessionSecret:  getEnv("SESSION_SECRET", "supersecretkey"),
 		JWTSecret:      getEnv("JWT_SECRET", "supersecretkey"),
 		JWTExpiresHour: jwtExpires,
+
+		ChainSigningKey: getEnv("CHAIN_SIGNING_KEY", "supersecretkey"),
+
+		COAMaxDepth:          coaMaxDepth,
+		COAEnforceCodePrefix: coaEnforcePrefix,
+
+		TrashRetentionDays: trashRetention,
+
+		OpeningSuspenseCode: getEnv("OPENING_SUSPENSE_CODE", "3-9999"),
+
+		ARControlCode: getEnv("AR_CONTROL_CODE", "1-1201"),
+		APControlCode: getEnv("AP_CONTROL_CODE", "2-1101"),
+
+		AgingBuckets: getEnv("AGING_BUCKETS", "30,60,90"),
+
+		SchedulerEnabled:         schedulerEnabled,
+		SchedulerIntervalMinutes: schedulerInterval,
+
+		IdempotencyTTLHours: idempotencyTTL,
+
+		BulkBatchSize:      bulkBatchSize,
+		BulkAsyncThreshold: bulkAsyncThreshold,
+
+		JobWorkers:     jobWorkers,
+		JobPollSeconds: jobPoll,
+		JobMaxAttempts: jobMaxAttempts,
+
+		WebhookPollSeconds:    webhookPoll,
+		WebhookMaxAttempts:    webhookMaxAttempts,
+		WebhookTimeoutSeconds: webhookTimeout,
+
+		SSEHeartbeatSeconds: sseHeartbeat,
+
+		TOTPIssuer:        getEnv("TOTP_ISSUER", "Accounting COA"),
+		MFARequiredRoles:  getEnvList("MFA_REQUIRED_ROLES", ""),
+		MFAPendingMinutes: mfaPending,
+
+		OIDCIssuer:            getEnv("OIDC_ISSUER", ""),
+		OIDCClientID:          getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:      getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:       getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/v1/auth/oidc/callback"),
+		OIDCScopes:            getEnvList("OIDC_SCOPES", "openid,email,profile"),
+		OIDCGroupsClaim:       getEnv("OIDC_GROUPS_CLAIM", "groups"),
+		OIDCRoleMap:           getEnvMap("OIDC_ROLE_MAP", ""),
+		OIDCDefaultRole:       getEnv("OIDC_DEFAULT_ROLE", "user"),
+		OIDCPostLoginRedirect: getEnv("OIDC_POST_LOGIN_REDIRECT", ""),
 	}
 }
 
+// OIDCEnabled reports whether single sign-on is configured.
+func (c *Config) OIDCEnabled() bool {
+	return c.OIDCIssuer != "" && c.OIDCClientID != ""
+}
+
+// MFARequiredFor reports whether users with the given role must enroll TOTP.
+func (c *Config) MFARequiredFor(role string) bool {
+	for _, r := range c.MFARequiredRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
 	}
 	return fallback
 }
+
+func getEnvList(key, fallback string) []string {
+	var list []string
+	for _, item := range strings.Split(getEnv(key, fallback), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvMap parses "key:value,key2:value2" pairs.
+func getEnvMap(key, fallback string) map[string]string {
+	m := map[string]string{}
+	for _, item := range getEnvList(key, fallback) {
+		if k, v, ok := strings.Cut(item, ":"); ok {
+			m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	return m
+}
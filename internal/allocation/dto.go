@@ -0,0 +1,115 @@
+package allocation
+
+import "time"
+
+// TargetRequest is one receiver of an allocation. CoaCode defaults to the
+// source account, which suits spreading one cost over dimension values.
+// Percent is used by the fixed basis; BasisCoaCode overrides the rule's
+// basis account for the account_balance basis.
+type TargetRequest struct {
+	CoaCode        string  `json:"coaCode"        example:"6-1001"`
+	DimensionCode  string  `json:"dimensionCode"  example:"DEPT"`
+	DimensionValue string  `json:"dimensionValue" example:"SALES"`
+	Percent        float64 `json:"percent"        example:"40"`
+	BasisCoaCode   string  `json:"basisCoaCode"   example:""`
+}
+
+// RuleRequest creates or replaces an allocation rule. The source dimension
+// pair is optional and restricts the allocated amount to tagged lines.
+type RuleRequest struct {
+	Name                 string          `json:"name"                 example:"Office rent split"`
+	SourceCoaCode        string          `json:"sourceCoaCode"        example:"6-1001"`
+	SourceDimensionCode  string          `json:"sourceDimensionCode"  example:"DEPT"`
+	SourceDimensionValue string          `json:"sourceDimensionValue" example:"SHARED"`
+	Basis                string          `json:"basis"                example:"fixed" enums:"fixed,account_balance"`
+	BasisCoaCode         string          `json:"basisCoaCode"         example:""`
+	IsActive             *bool           `json:"isActive"             example:"true"`
+	Targets              []TargetRequest `json:"targets"`
+}
+
+type TargetResponse struct {
+	CoaCode        string  `json:"coaCode"`
+	DimensionCode  string  `json:"dimensionCode,omitempty"`
+	DimensionValue string  `json:"dimensionValue,omitempty"`
+	Percent        float64 `json:"percent,omitempty"`
+	BasisCoaCode   string  `json:"basisCoaCode,omitempty"`
+}
+
+type RuleResponse struct {
+	ID                   string           `json:"id"`
+	Name                 string           `json:"name"`
+	SourceCoaCode        string           `json:"sourceCoaCode"`
+	SourceDimensionCode  string           `json:"sourceDimensionCode,omitempty"`
+	SourceDimensionValue string           `json:"sourceDimensionValue,omitempty"`
+	Basis                string           `json:"basis"`
+	BasisCoaCode         string           `json:"basisCoaCode,omitempty"`
+	IsActive             bool             `json:"isActive"`
+	Targets              []TargetResponse `json:"targets"`
+	CreatedAt            time.Time        `json:"createdAt"`
+	UpdatedAt            time.Time        `json:"updatedAt"`
+}
+
+type RunRequest struct {
+	Period  string `json:"period"  example:"2026-01"`
+	Preview bool   `json:"preview" example:"true"`
+}
+
+// RunLine is one line of the allocation journal. Weight is the target's
+// percentage or basis balance; it is zero on the source line.
+type RunLine struct {
+	CoaCode    string            `json:"coaCode"`
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+	Weight     float64           `json:"weight"`
+	Debit      float64           `json:"debit"`
+	Credit     float64           `json:"credit"`
+}
+
+// RunResponse is the computed allocation. Amount is the source's net debit
+// for the period. ExistingJournalID is set on a preview when the rule has
+// already produced a journal for the period.
+type RunResponse struct {
+	RuleID            string    `json:"ruleId"`
+	Period            string    `json:"period"`
+	Amount            float64   `json:"amount"`
+	Preview           bool      `json:"preview"`
+	Lines             []RunLine `json:"lines"`
+	JournalID         string    `json:"journalId,omitempty"`
+	Reference         string    `json:"reference,omitempty"`
+	ExistingJournalID string    `json:"existingJournalId,omitempty"`
+}
+
+type RunListItem struct {
+	ID             string    `json:"id"             gorm:"column:id"`
+	Period         string    `json:"period"         gorm:"column:period"`
+	JournalEntryID string    `json:"journalEntryId" gorm:"column:journal_entry_id"`
+	Reference      string    `json:"reference"      gorm:"column:reference"`
+	JournalStatus  string    `json:"journalStatus"  gorm:"column:journal_status"`
+	Amount         float64   `json:"amount"         gorm:"column:amount"`
+	CreatedAt      time.Time `json:"createdAt"      gorm:"column:created_at"`
+}
+
+// Swagger Responses
+
+type SwaggerRuleResponse struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Data    RuleResponse `json:"data"`
+}
+
+type SwaggerRuleListResponse struct {
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Data    []RuleResponse `json:"data"`
+}
+
+type SwaggerRunResponse struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    RunResponse `json:"data"`
+}
+
+type SwaggerRunListResponse struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    []RunListItem `json:"data"`
+}
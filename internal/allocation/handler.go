@@ -0,0 +1,239 @@
+package allocation
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func ruleID(c *fiber.Ctx) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "Invalid allocation rule ID")
+	}
+	return id, nil
+}
+
+// GetAll godoc
+// @Summary      List allocation rules
+// @Description  Returns every allocation rule with its targets
+// @Tags         Allocation
+// @Produce      json
+// @Success      200  {object}  SwaggerRuleListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /allocations/rules [get]
+func (h *Handler) GetAll(c *fiber.Ctx) error {
+	rules, err := h.service.GetAll()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get all allocation rules", rules)
+}
+
+// GetByID godoc
+// @Summary      Get allocation rule by ID
+// @Description  Returns a single allocation rule with its targets
+// @Tags         Allocation
+// @Produce      json
+// @Param        id   path  string  true  "Allocation Rule ID (UUID)"
+// @Success      200  {object}  SwaggerRuleResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /allocations/rules/{id} [get]
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	id, err := ruleID(c)
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.service.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get allocation rule", rule)
+}
+
+// GetRuns godoc
+// @Summary      List allocation runs
+// @Description  Returns the periods an allocation rule has been run for and the journals it produced (newest first)
+// @Tags         Allocation
+// @Produce      json
+// @Param        id   path  string  true  "Allocation Rule ID (UUID)"
+// @Success      200  {object}  SwaggerRunListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /allocations/rules/{id}/runs [get]
+func (h *Handler) GetRuns(c *fiber.Ctx) error {
+	id, err := ruleID(c)
+	if err != nil {
+		return err
+	}
+
+	runs, err := h.service.GetRuns(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get allocation runs", runs)
+}
+
+// Create godoc
+// @Summary      Create an allocation rule
+// @Description  Creates a rule that spreads a source account's period balance over target accounts or dimension values, by fixed percentages (adding up to 100) or by the targets' basis account balances
+// @Tags         Allocation
+// @Accept       json
+// @Produce      json
+// @Param        body body RuleRequest true "Allocation rule payload"
+// @Success      201  {object}  SwaggerRuleResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /allocations/rules [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req RuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.service.Create(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Allocation rule created successfully", rule)
+}
+
+// Update godoc
+// @Summary      Update an allocation rule
+// @Description  Replaces an allocation rule and all of its targets. Journals from earlier runs are not changed.
+// @Tags         Allocation
+// @Accept       json
+// @Produce      json
+// @Param        id    path  string       true  "Allocation Rule ID (UUID)"
+// @Param        body  body  RuleRequest  true  "Allocation rule payload"
+// @Success      200  {object}  SwaggerRuleResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /allocations/rules/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := ruleID(c)
+	if err != nil {
+		return err
+	}
+
+	var req RuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	rule, err := h.service.Update(id, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Allocation rule updated successfully", rule)
+}
+
+// Delete godoc
+// @Summary      Delete an allocation rule
+// @Description  Soft-deletes an allocation rule. Journals from earlier runs are kept.
+// @Tags         Allocation
+// @Produce      json
+// @Param        id   path  string  true  "Allocation Rule ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /allocations/rules/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := ruleID(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Delete(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Allocation rule deleted successfully", nil)
+}
+
+// Run godoc
+// @Summary      Run an allocation
+// @Description  Allocates the source's posted balance for a period (YYYY-MM) and creates a draft journal dated on the last day of the period; the last target takes the rounding remainder. With preview=true nothing is written. A rule runs at most once per period unless its draft is deleted.
+// @Tags         Allocation
+// @Accept       json
+// @Produce      json
+// @Param        id    path  string      true  "Allocation Rule ID (UUID)"
+// @Param        body  body  RunRequest  true  "Run payload"
+// @Success      200  {object}  SwaggerRunResponse
+// @Success      201  {object}  SwaggerRunResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /allocations/rules/{id}/run [post]
+func (h *Handler) Run(c *fiber.Ctx) error {
+	id, err := ruleID(c)
+	if err != nil {
+		return err
+	}
+
+	var req RunRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.service.Run(id, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	if res.Preview {
+		return utils.SuccessResponse(c, fiber.StatusOK, "Allocation preview", res)
+	}
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Allocation journal created as draft", res)
+}
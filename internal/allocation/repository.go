@@ -0,0 +1,217 @@
+package allocation
+
+import (
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAll() ([]domain.AllocationRule, error)
+	FindByID(id uuid.UUID) (*domain.AllocationRule, error)
+	FindTargets(ruleIDs []uuid.UUID) ([]domain.AllocationTarget, error)
+	Create(rule *domain.AllocationRule) error
+	Update(rule *domain.AllocationRule) error
+	ReplaceTargets(ruleID uuid.UUID, targets []domain.AllocationTarget) error
+	Delete(id uuid.UUID) error
+	LockRule(id uuid.UUID) error
+	FindActiveRun(ruleID uuid.UUID, period string) (*RunListItem, error)
+	FindRuns(ruleID uuid.UUID) ([]RunListItem, error)
+	CreateRun(run *domain.AllocationRun) error
+	SumLines(coaCode, startDate, endDate string, dimensionCode, dimensionValue *string) (float64, float64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+const ruleColumns = `id, name, source_coa_code, source_dimension_code, source_dimension_value, basis, basis_coa_code, is_active, created_at, updated_at`
+
+func (r *repository) FindAll() ([]domain.AllocationRule, error) {
+	var rules []domain.AllocationRule
+	err := r.db.Raw(
+		`SELECT ` + ruleColumns + `
+		 FROM allocation_rules
+		 WHERE deleted_at IS NULL
+		 ORDER BY name ASC`,
+	).Scan(&rules).Error
+	return rules, err
+}
+
+func (r *repository) FindByID(id uuid.UUID) (*domain.AllocationRule, error) {
+	var rule domain.AllocationRule
+	result := r.db.Raw(
+		`SELECT `+ruleColumns+`
+		 FROM allocation_rules
+		 WHERE id = ? AND deleted_at IS NULL
+		 LIMIT 1`,
+		id,
+	).Scan(&rule)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &rule, nil
+}
+
+func (r *repository) FindTargets(ruleIDs []uuid.UUID) ([]domain.AllocationTarget, error) {
+	var targets []domain.AllocationTarget
+	if len(ruleIDs) == 0 {
+		return targets, nil
+	}
+	err := r.db.Raw(
+		`SELECT id, rule_id, seq, coa_code, dimension_code, dimension_value, percent, basis_coa_code
+		 FROM allocation_targets
+		 WHERE rule_id IN ?
+		 ORDER BY rule_id, seq ASC`,
+		ruleIDs,
+	).Scan(&targets).Error
+	return targets, err
+}
+
+func (r *repository) Create(rule *domain.AllocationRule) error {
+	return r.db.Exec(
+		`INSERT INTO allocation_rules (id, name, source_coa_code, source_dimension_code, source_dimension_value, basis, basis_coa_code, is_active, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		rule.ID, rule.Name, rule.SourceCoaCode, rule.SourceDimensionCode, rule.SourceDimensionValue, rule.Basis, rule.BasisCoaCode, rule.IsActive,
+	).Error
+}
+
+func (r *repository) Update(rule *domain.AllocationRule) error {
+	return r.db.Exec(
+		`UPDATE allocation_rules
+		 SET name = ?, source_coa_code = ?, source_dimension_code = ?, source_dimension_value = ?,
+			basis = ?, basis_coa_code = ?, is_active = ?, updated_at = NOW()
+		 WHERE id = ? AND deleted_at IS NULL`,
+		rule.Name, rule.SourceCoaCode, rule.SourceDimensionCode, rule.SourceDimensionValue,
+		rule.Basis, rule.BasisCoaCode, rule.IsActive, rule.ID,
+	).Error
+}
+
+func (r *repository) ReplaceTargets(ruleID uuid.UUID, targets []domain.AllocationTarget) error {
+	if err := r.db.Exec(`DELETE FROM allocation_targets WHERE rule_id = ?`, ruleID).Error; err != nil {
+		return err
+	}
+	for _, t := range targets {
+		if err := r.db.Exec(
+			`INSERT INTO allocation_targets (id, rule_id, seq, coa_code, dimension_code, dimension_value, percent, basis_coa_code)
+			 VALUES (gen_random_uuid(), ?, ?, ?, ?, ?, ?, ?)`,
+			ruleID, t.Seq, t.CoaCode, t.DimensionCode, t.DimensionValue, t.Percent, t.BasisCoaCode,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) Delete(id uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE allocation_rules SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`,
+		id,
+	).Error
+}
+
+// LockRule serialises runs of one rule until the surrounding transaction
+// ends, so two requests cannot both allocate the same period.
+func (r *repository) LockRule(id uuid.UUID) error {
+	return r.db.Exec(`SELECT id FROM allocation_rules WHERE id = ? FOR UPDATE`, id).Error
+}
+
+const runColumns = `
+	ar.id,
+	ar.period,
+	ar.journal_entry_id,
+	je.reference,
+	je.status AS journal_status,
+	ar.amount,
+	ar.created_at`
+
+// FindActiveRun returns the run of the rule for period whose journal still
+// exists. A run whose draft journal was deleted does not count.
+func (r *repository) FindActiveRun(ruleID uuid.UUID, period string) (*RunListItem, error) {
+	var run RunListItem
+	result := r.db.Raw(
+		`SELECT `+runColumns+`
+		 FROM allocation_runs ar
+		 JOIN journal_entries je ON je.id = ar.journal_entry_id
+		 WHERE ar.rule_id = ? AND ar.period = ?
+		 AND je.deleted_at IS NULL
+		 LIMIT 1`,
+		ruleID, period,
+	).Scan(&run)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &run, nil
+}
+
+func (r *repository) FindRuns(ruleID uuid.UUID) ([]RunListItem, error) {
+	var runs []RunListItem
+	err := r.db.Raw(
+		`SELECT `+runColumns+`
+		 FROM allocation_runs ar
+		 JOIN journal_entries je ON je.id = ar.journal_entry_id
+		 WHERE ar.rule_id = ?
+		 ORDER BY ar.period DESC, ar.created_at DESC`,
+		ruleID,
+	).Scan(&runs).Error
+	return runs, err
+}
+
+func (r *repository) CreateRun(run *domain.AllocationRun) error {
+	return r.db.Exec(
+		`INSERT INTO allocation_runs (id, rule_id, period, journal_entry_id, amount, created_by, created_at)
+		 VALUES (gen_random_uuid(), ?, ?, ?, ?, ?, NOW())`,
+		run.RuleID, run.Period, run.JournalEntryID, run.Amount, run.CreatedBy,
+	).Error
+}
+
+// SumLines totals the posted lines of coaCode dated within the period,
+// optionally only those tagged with one dimension value.
+func (r *repository) SumLines(coaCode, startDate, endDate string, dimensionCode, dimensionValue *string) (float64, float64, error) {
+	var res struct {
+		Debit  float64
+		Credit float64
+	}
+
+	query := `
+		SELECT
+			COALESCE(SUM(jd.debit), 0) AS debit,
+			COALESCE(SUM(jd.credit), 0) AS credit
+		FROM journal_entry_details jd
+		JOIN journal_entries je ON je.id = jd.journal_entry_id
+		WHERE jd.coa_code = ?
+		AND jd.deleted_at IS NULL
+		AND je.status = 'posted'
+		AND je.deleted_at IS NULL
+		AND je.date >= ?
+		AND je.date <= ?`
+	args := []any{coaCode, startDate, endDate}
+
+	if dimensionCode != nil && dimensionValue != nil {
+		query += `
+		AND EXISTS (
+			SELECT 1 FROM journal_line_dimensions ld
+			WHERE ld.detail_id = jd.id
+			AND ld.dimension_code = ?
+			AND ld.value_code = ?)`
+		args = append(args, *dimensionCode, *dimensionValue)
+	}
+
+	if err := r.db.Raw(query, args...).Scan(&res).Error; err != nil {
+		return 0, 0, err
+	}
+	return res.Debit, res.Credit, nil
+}
@@ -0,0 +1,25 @@
+package allocation
+
+import (
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	allocationRoutes := router.Group("/allocations")
+	allocationRoutes.Use(middleware.AuthMiddleware(), middleware.Idempotency(db))
+
+	read := middleware.RequireScope(apikey.ScopeJournalRead)
+	write := middleware.RequireScope(apikey.ScopeJournalWrite)
+
+	allocationRoutes.Get("/rules", read, handler.GetAll)
+	allocationRoutes.Get("/rules/:id", read, handler.GetByID)
+	allocationRoutes.Get("/rules/:id/runs", read, handler.GetRuns)
+	allocationRoutes.Post("/rules", write, middleware.DBTransaction(db), handler.Create)
+	allocationRoutes.Put("/rules/:id", write, middleware.DBTransaction(db), handler.Update)
+	allocationRoutes.Delete("/rules/:id", write, middleware.DBTransaction(db), handler.Delete)
+	allocationRoutes.Post("/rules/:id/run", write, middleware.DBTransaction(db), handler.Run)
+}
@@ -0,0 +1,539 @@
+package allocation
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/coa"
+	"fiber.com/session-api/internal/dimension"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/journal"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetAll() ([]RuleResponse, error)
+	GetByID(id uuid.UUID) (*RuleResponse, error)
+	GetRuns(id uuid.UUID) ([]RunListItem, error)
+	Create(req *RuleRequest, actor audit.Actor, tx *gorm.DB) (*RuleResponse, error)
+	Update(id uuid.UUID, req *RuleRequest, actor audit.Actor, tx *gorm.DB) (*RuleResponse, error)
+	Delete(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	Run(id uuid.UUID, req *RunRequest, actor audit.Actor, tx *gorm.DB) (*RunResponse, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func toResponse(rule *domain.AllocationRule, targets []domain.AllocationTarget) *RuleResponse {
+	res := &RuleResponse{
+		ID:                   rule.ID.String(),
+		Name:                 rule.Name,
+		SourceCoaCode:        rule.SourceCoaCode,
+		SourceDimensionCode:  deref(rule.SourceDimensionCode),
+		SourceDimensionValue: deref(rule.SourceDimensionValue),
+		Basis:                string(rule.Basis),
+		BasisCoaCode:         deref(rule.BasisCoaCode),
+		IsActive:             rule.IsActive,
+		Targets:              []TargetResponse{},
+		CreatedAt:            rule.CreatedAt,
+		UpdatedAt:            rule.UpdatedAt,
+	}
+	for _, t := range targets {
+		res.Targets = append(res.Targets, TargetResponse{
+			CoaCode:        t.CoaCode,
+			DimensionCode:  deref(t.DimensionCode),
+			DimensionValue: deref(t.DimensionValue),
+			Percent:        t.Percent,
+			BasisCoaCode:   deref(t.BasisCoaCode),
+		})
+	}
+	return res
+}
+
+func findRule(repo Repository, id uuid.UUID) (*domain.AllocationRule, []domain.AllocationTarget, error) {
+	rule, err := repo.FindByID(id)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rule == nil {
+		return nil, nil, fiber.NewError(fiber.StatusNotFound, "Allocation rule not found")
+	}
+
+	targets, err := repo.FindTargets([]uuid.UUID{id})
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return rule, targets, nil
+}
+
+func findResponse(repo Repository, id uuid.UUID) (*RuleResponse, error) {
+	rule, targets, err := findRule(repo, id)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(rule, targets), nil
+}
+
+func (s *service) GetAll() ([]RuleResponse, error) {
+	rules, err := s.repo.FindAll()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	ids := make([]uuid.UUID, len(rules))
+	for i, r := range rules {
+		ids[i] = r.ID
+	}
+	targets, err := s.repo.FindTargets(ids)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	byRule := make(map[uuid.UUID][]domain.AllocationTarget)
+	for _, t := range targets {
+		byRule[t.RuleID] = append(byRule[t.RuleID], t)
+	}
+
+	res := make([]RuleResponse, len(rules))
+	for i := range rules {
+		res[i] = *toResponse(&rules[i], byRule[rules[i].ID])
+	}
+	return res, nil
+}
+
+func (s *service) GetByID(id uuid.UUID) (*RuleResponse, error) {
+	return findResponse(s.repo, id)
+}
+
+func (s *service) GetRuns(id uuid.UUID) ([]RunListItem, error) {
+	if _, _, err := findRule(s.repo, id); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.repo.FindRuns(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if runs == nil {
+		runs = []RunListItem{}
+	}
+	return runs, nil
+}
+
+// checkAccount requires code to be an active postable account.
+func checkAccount(coaRepo coa.Repository, field, code string) error {
+	account, err := coaRepo.FindByCode(code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if account == nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s: COA %s does not exist", field, code))
+	}
+	if account.Kind != domain.AccountKindPostable || !account.IsActive {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s: COA %s must be an active postable account", field, code))
+	}
+	return nil
+}
+
+// checkDimensionValue requires the dimension pair to be both set or both
+// empty, and a set pair to name an existing value.
+func checkDimensionValue(dimRepo dimension.Repository, field, dim, value string) error {
+	if dim == "" && value == "" {
+		return nil
+	}
+	if dim == "" || value == "" {
+		return fiber.NewError(fiber.StatusBadRequest, field+": dimension code and value must be given together")
+	}
+	v, err := dimRepo.FindValue(dim, value)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if v == nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s: %s is not a value of dimension %s", field, value, dim))
+	}
+	return nil
+}
+
+// buildRule validates req and turns it into a rule and its ordered targets.
+func buildRule(tx *gorm.DB, req *RuleRequest) (*domain.AllocationRule, []domain.AllocationTarget, error) {
+	coaRepo := coa.NewRepository(tx)
+	dimRepo := dimension.NewRepository(tx)
+
+	if req.Name == "" {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "name is required")
+	}
+	if req.SourceCoaCode == "" {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "sourceCoaCode is required")
+	}
+	if err := checkAccount(coaRepo, "sourceCoaCode", req.SourceCoaCode); err != nil {
+		return nil, nil, err
+	}
+	if err := checkDimensionValue(dimRepo, "source", req.SourceDimensionCode, req.SourceDimensionValue); err != nil {
+		return nil, nil, err
+	}
+
+	basis := domain.AllocationBasis(req.Basis)
+	if basis != domain.AllocationBasisFixed && basis != domain.AllocationBasisBalance {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "basis must be fixed or account_balance")
+	}
+	if req.BasisCoaCode != "" {
+		if err := checkAccount(coaRepo, "basisCoaCode", req.BasisCoaCode); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(req.Targets) == 0 {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "at least one target is required")
+	}
+
+	seen := map[string]bool{}
+	totalPercent := 0.0
+	targets := make([]domain.AllocationTarget, len(req.Targets))
+	for i, t := range req.Targets {
+		field := fmt.Sprintf("targets[%d]", i)
+
+		code := t.CoaCode
+		if code == "" {
+			code = req.SourceCoaCode
+		}
+		if err := checkAccount(coaRepo, field, code); err != nil {
+			return nil, nil, err
+		}
+		if err := checkDimensionValue(dimRepo, field, t.DimensionCode, t.DimensionValue); err != nil {
+			return nil, nil, err
+		}
+
+		key := code + "|" + t.DimensionCode + "|" + t.DimensionValue
+		if key == req.SourceCoaCode+"|"+req.SourceDimensionCode+"|"+req.SourceDimensionValue {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, field+": a target cannot be the source itself")
+		}
+		if seen[key] {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, field+": duplicate target")
+		}
+		seen[key] = true
+
+		switch basis {
+		case domain.AllocationBasisFixed:
+			if t.Percent <= 0 {
+				return nil, nil, fiber.NewError(fiber.StatusBadRequest, field+": percent must be greater than zero")
+			}
+			totalPercent += t.Percent
+		case domain.AllocationBasisBalance:
+			basisCode := t.BasisCoaCode
+			if basisCode == "" {
+				basisCode = req.BasisCoaCode
+			}
+			if basisCode == "" {
+				return nil, nil, fiber.NewError(fiber.StatusBadRequest, field+": basisCoaCode is required on the rule or the target")
+			}
+			if t.BasisCoaCode != "" {
+				if err := checkAccount(coaRepo, field, t.BasisCoaCode); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		targets[i] = domain.AllocationTarget{
+			Seq:            i + 1,
+			CoaCode:        code,
+			DimensionCode:  optional(t.DimensionCode),
+			DimensionValue: optional(t.DimensionValue),
+			BasisCoaCode:   optional(t.BasisCoaCode),
+		}
+		if basis == domain.AllocationBasisFixed {
+			targets[i].Percent = t.Percent
+		}
+	}
+
+	if basis == domain.AllocationBasisFixed && math.Abs(totalPercent-100) > 0.0001 {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("target percentages must add up to 100, got %g", totalPercent))
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	rule := &domain.AllocationRule{
+		Name:                 req.Name,
+		SourceCoaCode:        req.SourceCoaCode,
+		SourceDimensionCode:  optional(req.SourceDimensionCode),
+		SourceDimensionValue: optional(req.SourceDimensionValue),
+		Basis:                basis,
+		BasisCoaCode:         optional(req.BasisCoaCode),
+		IsActive:             isActive,
+	}
+	return rule, targets, nil
+}
+
+func (s *service) Create(req *RuleRequest, actor audit.Actor, tx *gorm.DB) (*RuleResponse, error) {
+	txRepo := NewRepository(tx)
+
+	rule, targets, err := buildRule(tx, req)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = uuid.New()
+
+	if err := txRepo.Create(rule); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.ReplaceTargets(rule.ID, targets); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findResponse(txRepo, rule.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityAllocation, after.ID, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) Update(id uuid.UUID, req *RuleRequest, actor audit.Actor, tx *gorm.DB) (*RuleResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := findResponse(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, targets, err := buildRule(tx, req)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = id
+
+	if err := txRepo.Update(rule); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.ReplaceTargets(id, targets); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findResponse(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityAllocation, after.ID, audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) Delete(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	before, err := findResponse(txRepo, id)
+	if err != nil {
+		return err
+	}
+	if err := txRepo.Delete(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return audit.Record(tx, actor, audit.EntityAllocation, before.ID, audit.ActionDelete, before, nil)
+}
+
+func lineDimensions(code, value *string) map[string]string {
+	if code == nil || value == nil {
+		return nil
+	}
+	return map[string]string{*code: *value}
+}
+
+// computeRun allocates the source's net balance for the period over the
+// targets. Each share is rounded to cents and the last target takes the
+// rounding remainder, so the journal always balances.
+func computeRun(repo Repository, rule *domain.AllocationRule, targets []domain.AllocationTarget, startDate, endDate string) (float64, []RunLine, error) {
+	debit, credit, err := repo.SumLines(rule.SourceCoaCode, startDate, endDate, rule.SourceDimensionCode, rule.SourceDimensionValue)
+	if err != nil {
+		return 0, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	amount := utils.Round2(debit - credit)
+	if amount == 0 {
+		return 0, nil, fiber.NewError(fiber.StatusBadRequest, "The source has no balance to allocate in this period")
+	}
+
+	weights := make([]float64, len(targets))
+	totalWeight := 0.0
+	for i, t := range targets {
+		if rule.Basis == domain.AllocationBasisFixed {
+			weights[i] = t.Percent
+		} else {
+			basisCode := deref(t.BasisCoaCode)
+			if basisCode == "" {
+				basisCode = deref(rule.BasisCoaCode)
+			}
+			d, c, err := repo.SumLines(basisCode, startDate, endDate, t.DimensionCode, t.DimensionValue)
+			if err != nil {
+				return 0, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+			weights[i] = math.Abs(d - c)
+		}
+		totalWeight += weights[i]
+	}
+	if totalWeight == 0 {
+		return 0, nil, fiber.NewError(fiber.StatusBadRequest, "The basis accounts have no balance in this period")
+	}
+
+	abs := math.Abs(amount)
+	lines := make([]RunLine, 0, len(targets)+1)
+	allocated := 0.0
+	for i, t := range targets {
+		share := utils.Round2(abs * weights[i] / totalWeight)
+		if i == len(targets)-1 {
+			share = utils.Round2(abs - allocated)
+		}
+		allocated += share
+		if share == 0 {
+			continue
+		}
+
+		line := RunLine{
+			CoaCode:    t.CoaCode,
+			Dimensions: lineDimensions(t.DimensionCode, t.DimensionValue),
+			Weight:     weights[i],
+		}
+		if amount > 0 {
+			line.Debit = share
+		} else {
+			line.Credit = share
+		}
+		lines = append(lines, line)
+	}
+
+	source := RunLine{
+		CoaCode:    rule.SourceCoaCode,
+		Dimensions: lineDimensions(rule.SourceDimensionCode, rule.SourceDimensionValue),
+	}
+	if amount > 0 {
+		source.Credit = abs
+	} else {
+		source.Debit = abs
+	}
+	lines = append(lines, source)
+
+	return amount, lines, nil
+}
+
+// Run computes the allocation for a period and, unless previewing, books it
+// as a draft journal dated on the last day of the period. A rule runs at
+// most once per period; deleting the draft allows it to run again.
+func (s *service) Run(id uuid.UUID, req *RunRequest, actor audit.Actor, tx *gorm.DB) (*RunResponse, error) {
+	txRepo := NewRepository(tx)
+
+	start, err := time.Parse("2006-01", req.Period)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "period must use the YYYY-MM format")
+	}
+	end := start.AddDate(0, 1, -1)
+
+	if err := txRepo.LockRule(id); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	rule, targets, err := findRule(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if !rule.IsActive && !req.Preview {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Allocation rule is inactive")
+	}
+
+	existing, err := txRepo.FindActiveRun(id, req.Period)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	amount, lines, err := computeRun(txRepo, rule, targets, start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &RunResponse{
+		RuleID:  id.String(),
+		Period:  req.Period,
+		Amount:  amount,
+		Preview: req.Preview,
+		Lines:   lines,
+	}
+	if req.Preview {
+		if existing != nil {
+			res.ExistingJournalID = existing.JournalEntryID
+		}
+		return res, nil
+	}
+	if existing != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Allocation rule already ran for %s (journal %s); delete that draft to run it again", req.Period, existing.Reference,
+		))
+	}
+
+	details := make([]journal.JournalDetailRequest, len(lines))
+	for i, l := range lines {
+		details[i] = journal.JournalDetailRequest{
+			CoaCode:     l.CoaCode,
+			Debit:       l.Debit,
+			Credit:      l.Credit,
+			Description: fmt.Sprintf("Allocation %s %s", rule.Name, req.Period),
+			Dimensions:  l.Dimensions,
+		}
+	}
+
+	entry, err := journal.CreateDraft(tx, journal.DraftInput{
+		Date:        end,
+		Prefix:      "ALC",
+		Description: fmt.Sprintf("Allocation %s for %s", rule.Name, req.Period),
+		Type:        domain.JournalTypeAllocation,
+		Details:     details,
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	createdBy, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+	if err := txRepo.CreateRun(&domain.AllocationRun{
+		RuleID:         id,
+		Period:         req.Period,
+		JournalEntryID: uuid.MustParse(entry.ID),
+		Amount:         amount,
+		CreatedBy:      createdBy,
+	}); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	res.JournalID = entry.ID
+	res.Reference = entry.Reference
+
+	if err := audit.Record(tx, actor, audit.EntityAllocation, id.String(), audit.ActionRun, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
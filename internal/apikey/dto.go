@@ -0,0 +1,53 @@
+package apikey
+
+import "time"
+
+const (
+	ScopeCOARead      = "coa:read"
+	ScopeCOAWrite     = "coa:write"
+	ScopeJournalRead  = "journal:read"
+	ScopeJournalWrite = "journal:write"
+	ScopeReportRead   = "report:read"
+	ScopeARRead       = "ar:read"
+	ScopeARWrite      = "ar:write"
+	ScopeAPRead       = "ap:read"
+	ScopeAPWrite      = "ap:write"
+)
+
+var knownScopes = []string{
+	ScopeCOARead,
+	ScopeCOAWrite,
+	ScopeJournalRead,
+	ScopeJournalWrite,
+	ScopeReportRead,
+	ScopeARRead,
+	ScopeARWrite,
+	ScopeAPRead,
+	ScopeAPWrite,
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name"        validate:"required,max=100"  example:"Payroll integration"`
+	ServiceName string     `json:"serviceName" validate:"required,max=100"  example:"payroll"`
+	Scopes      []string   `json:"scopes"      validate:"required,min=1"    example:"journal:read,journal:write"`
+	ExpiresAt   *time.Time `json:"expiresAt"   validate:"omitempty"         example:"2027-01-01T00:00:00Z"`
+}
+
+type APIKeyResponse struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	ServiceName string     `json:"serviceName"`
+	Prefix      string     `json:"prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	CreatedBy   string     `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// CreatedAPIKeyResponse carries the full key. It is only returned once.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
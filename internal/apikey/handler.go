@@ -0,0 +1,100 @@
+package apikey
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetAll godoc
+// @Summary      List API keys
+// @Description  Returns all API keys (without secrets). Admin only.
+// @Tags         API Key
+// @Produce      json
+// @Success      200  {object}  model.SwaggerAuthResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /api-keys [get]
+func (h *Handler) GetAll(c *fiber.Ctx) error {
+	keys, err := h.service.GetAll()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get all API keys", keys)
+}
+
+// Create godoc
+// @Summary      Create an API key
+// @Description  Creates a scoped API key for a service identity. The full key is only returned in this response; send it in the X-API-Key header. Admin only.
+// @Tags         API Key
+// @Accept       json
+// @Produce      json
+// @Param        body body CreateAPIKeyRequest true "API key payload"
+// @Success      201  {object}  model.SwaggerAuthResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /api-keys [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	key, err := h.service.Create(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "API key created. Store the key now, it will not be shown again", key)
+}
+
+// Revoke godoc
+// @Summary      Revoke an API key
+// @Description  Revokes an API key immediately. Admin only.
+// @Tags         API Key
+// @Produce      json
+// @Param        id   path  string  true  "API Key ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /api-keys/{id} [delete]
+func (h *Handler) Revoke(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid API key ID")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Revoke(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "API key revoked successfully", nil)
+}
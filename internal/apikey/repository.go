@@ -0,0 +1,102 @@
+package apikey
+
+import (
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAll() ([]domain.APIKey, error)
+	FindByID(id uuid.UUID) (*domain.APIKey, error)
+	FindActiveByPrefix(prefix string) (*domain.APIKey, error)
+	Create(key *domain.APIKey) error
+	Revoke(id uuid.UUID) (bool, error)
+	TouchLastUsed(id uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) FindAll() ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	err := r.db.Raw(
+		`SELECT id, name, service_name, prefix, scopes, expires_at, last_used_at, revoked_at, created_by, created_at, updated_at
+		 FROM api_keys
+		 ORDER BY created_at DESC`,
+	).Scan(&keys).Error
+	return keys, err
+}
+
+func (r *repository) FindByID(id uuid.UUID) (*domain.APIKey, error) {
+	var key domain.APIKey
+	result := r.db.Raw(
+		`SELECT id, name, service_name, prefix, scopes, expires_at, last_used_at, revoked_at, created_by, created_at, updated_at
+		 FROM api_keys WHERE id = ? LIMIT 1`,
+		id,
+	).Scan(&key)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &key, nil
+}
+
+func (r *repository) FindActiveByPrefix(prefix string) (*domain.APIKey, error) {
+	var key domain.APIKey
+	result := r.db.Raw(
+		`SELECT id, name, service_name, prefix, secret_hash, scopes, expires_at, last_used_at, revoked_at, created_by, created_at, updated_at
+		 FROM api_keys
+		 WHERE prefix = ?
+		 AND revoked_at IS NULL
+		 AND (expires_at IS NULL OR expires_at > NOW())
+		 LIMIT 1`,
+		prefix,
+	).Scan(&key)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &key, nil
+}
+
+func (r *repository) Create(key *domain.APIKey) error {
+	return r.db.Exec(
+		`INSERT INTO api_keys (id, name, service_name, prefix, secret_hash, scopes, expires_at, created_by, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		key.ID, key.Name, key.ServiceName, key.Prefix, key.SecretHash, key.Scopes, key.ExpiresAt, key.CreatedBy,
+	).Error
+}
+
+func (r *repository) Revoke(id uuid.UUID) (bool, error) {
+	result := r.db.Exec(
+		`UPDATE api_keys SET revoked_at = NOW(), updated_at = NOW() WHERE id = ? AND revoked_at IS NULL`,
+		id,
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// TouchLastUsed updates last_used_at at most once a minute per key so busy
+// integrations do not turn every request into a write.
+func (r *repository) TouchLastUsed(id uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE api_keys SET last_used_at = NOW()
+		 WHERE id = ? AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`,
+		id,
+	).Error
+}
@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	keyRoutes := router.Group("/api-keys")
+	keyRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole("admin"), middleware.Idempotency(db))
+
+	keyRoutes.Get("/", handler.GetAll)
+	keyRoutes.Post("/", middleware.DBTransaction(db), handler.Create)
+	keyRoutes.Delete("/:id", middleware.DBTransaction(db), handler.Revoke)
+}
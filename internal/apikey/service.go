@@ -0,0 +1,202 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Keys look like "ak_1a2b3c4d.<secret>". The part before the dot is the
+// visible prefix used for lookup and display.
+const keyPrefix = "ak_"
+
+type Service interface {
+	GetAll() ([]APIKeyResponse, error)
+	Create(req *CreateAPIKeyRequest, actor audit.Actor, tx *gorm.DB) (*CreatedAPIKeyResponse, error)
+	Revoke(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	Verify(rawKey string) (*middleware.APIKeyIdentity, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func toResponse(k *domain.APIKey) *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:          k.ID.String(),
+		Name:        k.Name,
+		ServiceName: k.ServiceName,
+		Prefix:      k.Prefix,
+		Scopes:      splitScopes(k.Scopes),
+		ExpiresAt:   k.ExpiresAt,
+		LastUsedAt:  k.LastUsedAt,
+		RevokedAt:   k.RevokedAt,
+		CreatedBy:   k.CreatedBy.String(),
+		CreatedAt:   k.CreatedAt,
+	}
+}
+
+func (s *service) GetAll() ([]APIKeyResponse, error) {
+	keys, err := s.repo.FindAll()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	responses := make([]APIKeyResponse, len(keys))
+	for i, k := range keys {
+		responses[i] = *toResponse(&k)
+	}
+	return responses, nil
+}
+
+func (s *service) Create(req *CreateAPIKeyRequest, actor audit.Actor, tx *gorm.DB) (*CreatedAPIKeyResponse, error) {
+	txRepo := NewRepository(tx)
+
+	createdBy, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+
+	if strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.ServiceName) == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "name and serviceName are required")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "At least one scope is required")
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(knownScopes, scope) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Unknown scope: "+scope)
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "expiresAt must be in the future")
+	}
+
+	prefixPart, err := randomHex(4)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate API key")
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate API key")
+	}
+
+	key := &domain.APIKey{
+		ID:          uuid.New(),
+		Name:        req.Name,
+		ServiceName: req.ServiceName,
+		Prefix:      keyPrefix + prefixPart,
+		SecretHash:  hashSecret(secret),
+		Scopes:      strings.Join(req.Scopes, ","),
+		ExpiresAt:   req.ExpiresAt,
+		CreatedBy:   createdBy,
+	}
+
+	if err := txRepo.Create(key); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	created, err := txRepo.FindByID(key.ID)
+	if err != nil || created == nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch created API key")
+	}
+
+	resp := toResponse(created)
+	if err := audit.Record(tx, actor, audit.EntityAPIKey, resp.ID, audit.ActionCreate, nil, resp); err != nil {
+		return nil, err
+	}
+
+	return &CreatedAPIKeyResponse{
+		APIKeyResponse: *resp,
+		Key:            key.Prefix + "." + secret,
+	}, nil
+}
+
+func (s *service) Revoke(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	existing, err := txRepo.FindByID(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	revoked, err := txRepo.Revoke(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if !revoked {
+		return fiber.NewError(fiber.StatusNotFound, "API key not found or already revoked")
+	}
+
+	after, err := txRepo.FindByID(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return audit.Record(tx, actor, audit.EntityAPIKey, id.String(), audit.ActionRevoke, toResponse(existing), toResponse(after))
+}
+
+func (s *service) Verify(rawKey string) (*middleware.APIKeyIdentity, error) {
+	prefix, secret, ok := strings.Cut(rawKey, ".")
+	if !ok || !strings.HasPrefix(prefix, keyPrefix) || secret == "" {
+		return nil, nil
+	}
+
+	key, err := s.repo.FindActiveByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.SecretHash), []byte(hashSecret(secret))) != 1 {
+		return nil, nil
+	}
+
+	if err := s.repo.TouchLastUsed(key.ID); err != nil {
+		return nil, err
+	}
+
+	return &middleware.APIKeyIdentity{
+		KeyID:       key.ID.String(),
+		ServiceName: key.ServiceName,
+		Scopes:      splitScopes(key.Scopes),
+	}, nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func splitScopes(scopes string) []string {
+	if scopes == "" {
+		return []string{}
+	}
+	return strings.Split(scopes, ",")
+}
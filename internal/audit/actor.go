@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	ActorTypeUser      = "user"
+	ActorTypeAPIKey    = "api_key"
+	ActorTypeSystem    = "system"
+	ActorTypeAnonymous = "anonymous"
+)
+
+// Actor identifies who performed a mutation and from where.
+type Actor struct {
+	ID   string
+	Name string
+	Type string
+	IP   string
+}
+
+// ActorFromCtx builds the actor from the locals set by AuthMiddleware. Routes
+// without authentication yield an anonymous actor carrying only the IP.
+func ActorFromCtx(c *fiber.Ctx) Actor {
+	userID, _ := c.Locals("userId").(string)
+	if userID == "" {
+		return Actor{Name: ActorTypeAnonymous, Type: ActorTypeAnonymous, IP: c.IP()}
+	}
+
+	actorType := ActorTypeUser
+	if c.Locals("authType") == middleware.AuthTypeAPIKey {
+		actorType = ActorTypeAPIKey
+	}
+
+	userName, _ := c.Locals("userName").(string)
+	return Actor{ID: userID, Name: userName, Type: actorType, IP: c.IP()}
+}
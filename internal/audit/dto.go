@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+const (
+	EntityCOA             = "coa"
+	EntityJournal         = "journal"
+	EntityUser            = "user"
+	EntityAPIKey          = "api_key"
+	EntityDimension       = "dimension"
+	EntityAllocation      = "allocation"
+	EntityJournalTemplate = "journal_template"
+	EntityWebhook         = "webhook"
+	EntityCustomer        = "customer"
+	EntitySalesInvoice    = "sales_invoice"
+	EntityReceipt         = "receipt"
+	EntityVendor          = "vendor"
+	EntityPurchaseBill    = "purchase_bill"
+	EntityPayment         = "payment"
+)
+
+const (
+	ActionCreate                  = "create"
+	ActionUpdate                  = "update"
+	ActionDelete                  = "delete"
+	ActionPost                    = "post"
+	ActionRenumber                = "renumber"
+	ActionMerge                   = "merge"
+	ActionRestore                 = "restore"
+	ActionPurge                   = "purge"
+	ActionLock                    = "lock"
+	ActionRun                     = "run"
+	ActionPause                   = "pause"
+	ActionResume                  = "resume"
+	ActionEnableMFA               = "enable_mfa"
+	ActionDisableMFA              = "disable_mfa"
+	ActionRegenerateRecoveryCodes = "regenerate_recovery_codes"
+	ActionLinkIdentity            = "link_identity"
+	ActionRevoke                  = "revoke"
+	ActionRedeliver               = "redeliver"
+)
+
+// AuditQuery is the request DTO for GET /audit. All filters are optional.
+type AuditQuery struct {
+	Page       int    `query:"page"`
+	Limit      int    `query:"limit"`
+	EntityType string `query:"entityType"`
+	EntityID   string `query:"entityId"`
+	ActorID    string `query:"actorId"`
+	Action     string `query:"action"`
+	StartDate  string `query:"startDate"`
+	EndDate    string `query:"endDate"`
+}
+
+type AuditLogResponse struct {
+	ID         string         `json:"id"`
+	ActorID    string         `json:"actorId"`
+	ActorName  string         `json:"actorName"`
+	ActorType  string         `json:"actorType"`
+	IPAddress  string         `json:"ipAddress"`
+	EntityType string         `json:"entityType"`
+	EntityID   string         `json:"entityId"`
+	Action     string         `json:"action"`
+	Before     datatypes.JSON `json:"before,omitempty" swaggertype:"object"`
+	After      datatypes.JSON `json:"after,omitempty"  swaggertype:"object"`
+	Changes    datatypes.JSON `json:"changes,omitempty" swaggertype:"object"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+// FieldChange is one entry of the "changes" diff.
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetAll godoc
+// @Summary      List audit log entries
+// @Description  Returns a paginated, filterable list of audit entries (newest first). Admin only.
+// @Tags         Audit
+// @Produce      json
+// @Param        page       query  int     false "Page number"    minimum(1)
+// @Param        limit      query  int     false "Items per page" minimum(1) maximum(100)
+// @Param        entityType query  string  false "Entity type (coa, journal, user, api_key, dimension, allocation, journal_template, webhook, customer, sales_invoice, receipt, vendor, purchase_bill, payment)"
+// @Param        entityId   query  string  false "Entity ID (COA code, journal ID, ...)"
+// @Param        actorId    query  string  false "Actor ID (user or API key ID)"
+// @Param        action     query  string  false "Action (create, update, delete, post, ...)"
+// @Param        startDate  query  string  false "Start Date (YYYY-MM-DD)"
+// @Param        endDate    query  string  false "End Date (YYYY-MM-DD)"
+// @Success      200  {object}  model.SwaggerCOAListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /audit [get]
+func (h *Handler) GetAll(c *fiber.Ctx) error {
+	var q AuditQuery
+	if err := c.QueryParser(&q); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	logs, meta, err := h.service.GetAll(&q)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get audit logs", logs, meta)
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"fiber.com/session-api/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(log *domain.AuditLog) error
+	FindAll(q *AuditQuery) ([]domain.AuditLog, int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Migrate installs the trigger that makes audit_logs append-only. It is
+// idempotent and must run after AutoMigrate has created the table.
+func Migrate(db *gorm.DB) error {
+	return db.Exec(`
+		CREATE OR REPLACE FUNCTION audit_logs_immutable() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit_logs is append-only';
+		END;
+		$$ LANGUAGE plpgsql;
+
+		DROP TRIGGER IF EXISTS audit_logs_no_modify ON audit_logs;
+		CREATE TRIGGER audit_logs_no_modify
+			BEFORE UPDATE OR DELETE ON audit_logs
+			FOR EACH ROW EXECUTE FUNCTION audit_logs_immutable();
+
+		DROP TRIGGER IF EXISTS audit_logs_no_truncate ON audit_logs;
+		CREATE TRIGGER audit_logs_no_truncate
+			BEFORE TRUNCATE ON audit_logs
+			FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_immutable();
+	`).Error
+}
+
+func (r *repository) Create(log *domain.AuditLog) error {
+	return r.db.Exec(
+		`INSERT INTO audit_logs (id, actor_id, actor_name, actor_type, ip_address, entity_type, entity_id, action, before, after, changes, created_at)
+		 VALUES (gen_random_uuid(), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NOW())`,
+		log.ActorID, log.ActorName, log.ActorType, log.IPAddress, log.EntityType, log.EntityID, log.Action, log.Before, log.After, log.Changes,
+	).Error
+}
+
+func (r *repository) FindAll(q *AuditQuery) ([]domain.AuditLog, int64, error) {
+	var total int64
+	offset := (q.Page - 1) * q.Limit
+
+	where := "1 = 1"
+	var args []any
+
+	if q.EntityType != "" {
+		where += " AND entity_type = ?"
+		args = append(args, q.EntityType)
+	}
+	if q.EntityID != "" {
+		where += " AND entity_id = ?"
+		args = append(args, q.EntityID)
+	}
+	if q.ActorID != "" {
+		where += " AND actor_id = ?"
+		args = append(args, q.ActorID)
+	}
+	if q.Action != "" {
+		where += " AND action = ?"
+		args = append(args, q.Action)
+	}
+	if q.StartDate != "" {
+		where += " AND created_at >= ?"
+		args = append(args, q.StartDate)
+	}
+	if q.EndDate != "" {
+		where += " AND created_at < (?::date + INTERVAL '1 day')"
+		args = append(args, q.EndDate)
+	}
+
+	if err := r.db.Raw(`SELECT COUNT(*) FROM audit_logs WHERE `+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	dataQuery := `
+		SELECT id, actor_id, actor_name, actor_type, ip_address, entity_type, entity_id, action, before, after, changes, created_at
+		FROM audit_logs
+		WHERE ` + where + `
+		ORDER BY created_at DESC
+		LIMIT ? OFFSET ?`
+
+	var logs []domain.AuditLog
+	if err := r.db.Raw(dataQuery, append(args, q.Limit, offset)...).Scan(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -0,0 +1,14 @@
+package audit
+
+import (
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler) {
+	auditRoutes := router.Group("/audit")
+	auditRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole("admin"))
+
+	auditRoutes.Get("/", handler.GetAll)
+}
@@ -0,0 +1,169 @@
+package audit
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/model"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetAll(q *AuditQuery) ([]AuditLogResponse, *model.MetaPagination, error)
+	GetHistory(entityType, entityID string, req *model.PaginationRequest) ([]AuditLogResponse, *model.MetaPagination, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// Record writes an audit entry through tx, so it commits or rolls back
+// together with the change it describes. before is nil for creations and
+// after is nil for deletions; both are snapshotted as JSON and diffed.
+func Record(tx *gorm.DB, actor Actor, entityType, entityID, action string, before, after any) error {
+	beforeJSON, err := snapshot(before)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to record audit log: "+err.Error())
+	}
+	afterJSON, err := snapshot(after)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to record audit log: "+err.Error())
+	}
+
+	changes, err := diff(beforeJSON, afterJSON)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to record audit log: "+err.Error())
+	}
+
+	log := &domain.AuditLog{
+		ActorID:    actor.ID,
+		ActorName:  actor.Name,
+		ActorType:  actor.Type,
+		IPAddress:  actor.IP,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		Changes:    changes,
+	}
+
+	if err := NewRepository(tx).Create(log); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to record audit log: "+err.Error())
+	}
+	return nil
+}
+
+func (s *service) GetAll(q *AuditQuery) ([]AuditLogResponse, *model.MetaPagination, error) {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 || q.Limit > 100 {
+		q.Limit = 10
+	}
+
+	logs, total, err := s.repo.FindAll(q)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	responses := make([]AuditLogResponse, len(logs))
+	for i, l := range logs {
+		responses[i] = *toResponse(&l)
+	}
+
+	meta := &model.MetaPagination{
+		Page:      q.Page,
+		Limit:     q.Limit,
+		TotalPage: int(math.Ceil(float64(total) / float64(q.Limit))),
+		TotalData: int(total),
+	}
+
+	return responses, meta, nil
+}
+
+func (s *service) GetHistory(entityType, entityID string, req *model.PaginationRequest) ([]AuditLogResponse, *model.MetaPagination, error) {
+	return s.GetAll(&AuditQuery{
+		Page:       req.Page,
+		Limit:      req.Limit,
+		EntityType: entityType,
+		EntityID:   entityID,
+	})
+}
+
+func toResponse(l *domain.AuditLog) *AuditLogResponse {
+	return &AuditLogResponse{
+		ID:         l.ID.String(),
+		ActorID:    l.ActorID,
+		ActorName:  l.ActorName,
+		ActorType:  l.ActorType,
+		IPAddress:  l.IPAddress,
+		EntityType: l.EntityType,
+		EntityID:   l.EntityID,
+		Action:     l.Action,
+		Before:     l.Before,
+		After:      l.After,
+		Changes:    l.Changes,
+		CreatedAt:  l.CreatedAt,
+	}
+}
+
+func snapshot(v any) (datatypes.JSON, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(raw), nil
+}
+
+// diff returns the top-level fields whose value differs between before and
+// after as {"field": {"from": ..., "to": ...}}.
+func diff(before, after datatypes.JSON) (datatypes.JSON, error) {
+	oldFields := map[string]any{}
+	newFields := map[string]any{}
+
+	if before != nil {
+		if err := json.Unmarshal(before, &oldFields); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if err := json.Unmarshal(after, &newFields); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := map[string]FieldChange{}
+	for key, oldValue := range oldFields {
+		if newValue, ok := newFields[key]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changes[key] = FieldChange{From: oldValue, To: newFields[key]}
+		}
+	}
+	for key, newValue := range newFields {
+		if _, ok := oldFields[key]; !ok {
+			changes[key] = FieldChange{From: nil, To: newValue}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	return datatypes.JSON(raw), nil
+}
@@ -17,4 +17,38 @@ type AuthResponse struct {
 	UserName string `json:"userName"`
 	Email    string `json:"email"`
 	Role     string `json:"eRole"`
+	// MFAEnrollmentRequired is set when the role enforces TOTP and the user
+	// has not enrolled yet; only the /auth endpoints are reachable until then.
+	MFAEnrollmentRequired bool `json:"mfaEnrollmentRequired,omitempty"`
+}
+
+type VerifyMFARequest struct {
+	MFAToken     string `json:"mfaToken"     validate:"required"`
+	Code         string `json:"code"         validate:"omitempty,len=6" example:"123456"`
+	RecoveryCode string `json:"recoveryCode" validate:"omitempty"       example:"a1b2c-3d4e5"`
+}
+
+type TOTPCodeRequest struct {
+	Code string `json:"code" validate:"required,len=6" example:"123456"`
+}
+
+type DisableTOTPRequest struct {
+	Password string `json:"password" validate:"required"`
+	Code     string `json:"code"     validate:"required,len=6" example:"123456"`
+}
+
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfaRequired"`
+	MFAToken    string `json:"mfaToken"`
+	ExpiresIn   int    `json:"expiresIn"`
+}
+
+type TOTPEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauthUri"`
+	QRCode     string `json:"qrCode"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
 }
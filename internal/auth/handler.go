@@ -4,17 +4,21 @@ import (
 	"time"
 
 	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/middleware"
 	"fiber.com/session-api/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type Handler struct {
-	service Service
+	service     Service
+	oidcService OIDCService
 }
 
-func NewHandler(service Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service Service, oidcService OIDCService) *Handler {
+	return &Handler{service: service, oidcService: oidcService}
 }
 
 // Register godoc
@@ -35,7 +39,12 @@ func (h *Handler) Register(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	user, err := h.service.Register(&req)
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.service.Register(&req, audit.ActorFromCtx(c), tx)
 	if err != nil {
 		return err
 	}
@@ -52,7 +61,7 @@ func (h *Handler) Register(c *fiber.Ctx) error {
 
 // Login godoc
 // @Summary      Login user
-// @Description  Authenticates a user and sets an HttpOnly JWT cookie
+// @Description  Authenticates a user and sets an HttpOnly JWT cookie. When the user has TOTP enabled no cookie is set; the response carries a short-lived mfaToken to be exchanged at /auth/login/verify.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
@@ -68,20 +77,137 @@ func (h *Handler) Login(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	tokenStr, authResp, err := h.service.Login(&req)
+	tokenStr, authResp, challenge, err := h.service.Login(&req)
 	if err != nil {
 		return err
 	}
 
+	if challenge != nil {
+		return utils.SuccessResponse(c, fiber.StatusOK, "Two-factor authentication required", challenge)
+	}
+
+	setAuthCookie(c, tokenStr)
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Login successful", authResp)
+}
+
+// VerifyMFA godoc
+// @Summary      Complete login with a second factor
+// @Description  Exchanges the mfaToken returned by /auth/login and a TOTP or recovery code for the session cookie
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        body body VerifyMFARequest true "MFA verification payload"
+// @Success      200  {object}  model.SwaggerAuthResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Router       /auth/login/verify [post]
+func (h *Handler) VerifyMFA(c *fiber.Ctx) error {
+	var req VerifyMFARequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tokenStr, authResp, err := h.service.VerifyMFA(&req)
+	if err != nil {
+		return err
+	}
+
+	setAuthCookie(c, tokenStr)
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Login successful", authResp)
+}
+
+// OIDCLogin godoc
+// @Summary      Start single sign-on login
+// @Description  Redirects to the configured OpenID Connect provider (authorization code flow with PKCE)
+// @Tags         Auth
+// @Success      302
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      502  {object}  model.SwaggerErrorResponse
+// @Router       /auth/oidc/login [get]
+func (h *Handler) OIDCLogin(c *fiber.Ctx) error {
+	if !h.oidcService.Enabled() {
+		return fiber.NewError(fiber.StatusNotFound, "Single sign-on is not configured")
+	}
+
+	authURL, state, err := h.oidcService.Begin(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	sealed, err := h.oidcService.SealState(state)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to store login state")
+	}
+
 	c.Cookie(&fiber.Cookie{
-		Name:     "auth_token",
-		Value:    tokenStr,
+		Name:     oidcStateCookie,
+		Value:    sealed,
 		HTTPOnly: true,
 		Secure:   false,
 		SameSite: "Lax",
-		Expires:  time.Now().Add(time.Duration(config.AppConfig.JWTExpiresHour) * time.Hour),
+		Expires:  time.Now().Add(oidcStateTTL),
+	})
+
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// OIDCCallback godoc
+// @Summary      Single sign-on callback
+// @Description  Completes the OpenID Connect login, provisions or links the user by email and sets the same auth_token cookie as /auth/login
+// @Tags         Auth
+// @Produce      json
+// @Param        code   query  string  true  "Authorization code"
+// @Param        state  query  string  true  "Login state"
+// @Success      200  {object}  model.SwaggerAuthResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Router       /auth/oidc/callback [get]
+func (h *Handler) OIDCCallback(c *fiber.Ctx) error {
+	if !h.oidcService.Enabled() {
+		return fiber.NewError(fiber.StatusNotFound, "Single sign-on is not configured")
+	}
+
+	if errCode := c.Query("error"); errCode != "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "Identity provider error: "+errCode)
+	}
+
+	state, err := h.oidcService.OpenState(c.Cookies(oidcStateCookie))
+	if err != nil || state.State != c.Query("state") {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid or expired login state")
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    "",
+		HTTPOnly: true,
+		Expires:  time.Now().Add(-time.Hour),
 	})
 
+	code := c.Query("code")
+	if code == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Authorization code is required")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	tokenStr, authResp, err := h.oidcService.Complete(c.UserContext(), code, state, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	setAuthCookie(c, tokenStr)
+
+	if redirect := config.AppConfig.OIDCPostLoginRedirect; redirect != "" {
+		return c.Redirect(redirect, fiber.StatusFound)
+	}
+
 	return utils.SuccessResponse(c, fiber.StatusOK, "Login successful", authResp)
 }
 
@@ -123,3 +249,156 @@ func (h *Handler) Me(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Success get current user", resp)
 }
+
+// EnrollTOTP godoc
+// @Summary      Start TOTP enrollment
+// @Description  Generates a new TOTP secret and returns its otpauth URI and a QR code PNG (data URI). Enrollment takes effect after /auth/2fa/confirm.
+// @Tags         Auth
+// @Produce      json
+// @Success      200  {object}  model.SwaggerAuthResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /auth/2fa/enroll [post]
+func (h *Handler) EnrollTOTP(c *fiber.Ctx) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.service.EnrollTOTP(userID)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Scan the QR code and confirm with a code", resp)
+}
+
+// ConfirmTOTP godoc
+// @Summary      Confirm TOTP enrollment
+// @Description  Verifies the first code from the authenticator app, enables 2FA and returns one-time recovery codes
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        body body TOTPCodeRequest true "TOTP code"
+// @Success      200  {object}  model.SwaggerAuthResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /auth/2fa/confirm [post]
+func (h *Handler) ConfirmTOTP(c *fiber.Ctx) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req TOTPCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.service.ConfirmTOTP(userID, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Two-factor authentication enabled. Store the recovery codes safely", resp)
+}
+
+// DisableTOTP godoc
+// @Summary      Disable TOTP
+// @Description  Turns off 2FA after re-checking password and a current code. Not allowed for roles that enforce 2FA.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        body body DisableTOTPRequest true "Password and TOTP code"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /auth/2fa/disable [post]
+func (h *Handler) DisableTOTP(c *fiber.Ctx) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req DisableTOTPRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DisableTOTP(userID, &req, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Two-factor authentication disabled", nil)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Regenerate recovery codes
+// @Description  Invalidates all previous recovery codes and issues a new set
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        body body TOTPCodeRequest true "TOTP code"
+// @Success      200  {object}  model.SwaggerAuthResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /auth/2fa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req TOTPCodeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.service.RegenerateRecoveryCodes(userID, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Recovery codes regenerated", resp)
+}
+
+const oidcStateCookie = "oidc_state"
+
+func setAuthCookie(c *fiber.Ctx, tokenStr string) {
+	c.Cookie(&fiber.Cookie{
+		Name:     "auth_token",
+		Value:    tokenStr,
+		HTTPOnly: true,
+		Secure:   false,
+		SameSite: "Lax",
+		Expires:  time.Now().Add(time.Duration(config.AppConfig.JWTExpiresHour) * time.Hour),
+	})
+}
+
+func currentUserID(c *fiber.Ctx) (uuid.UUID, error) {
+	userID, err := uuid.Parse(c.Locals("userId").(string))
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+	return userID, nil
+}
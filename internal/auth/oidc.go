@@ -0,0 +1,351 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+const oidcStateTTL = 10 * time.Minute
+
+// rolePriority decides which role wins when a user's groups map to several.
+var rolePriority = map[string]int{"user": 1, "admin": 2}
+
+// OIDCState is the per-login secret material kept in a signed cookie between
+// the redirect to the identity provider and the callback.
+type OIDCState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+type oidcStateClaims struct {
+	OIDCState
+	jwt.RegisteredClaims
+}
+
+type OIDCService interface {
+	Enabled() bool
+	// Begin returns the provider authorization URL (authorization code flow
+	// with PKCE) and the state that must be echoed back on the callback.
+	Begin(ctx context.Context) (string, *OIDCState, error)
+	// Complete exchanges the code, validates the ID token against the
+	// provider JWKS and provisions or links the local user inside tx.
+	Complete(ctx context.Context, code string, state *OIDCState, actor audit.Actor, tx *gorm.DB) (string, *AuthResponse, error)
+	SealState(state *OIDCState) (string, error)
+	OpenState(sealed string) (*OIDCState, error)
+}
+
+type oidcService struct {
+	mu       sync.Mutex
+	provider *oidc.Provider
+	// repository opens the user repository on the login transaction.
+	repository func(tx *gorm.DB) Repository
+}
+
+func NewOIDCService() OIDCService {
+	return &oidcService{repository: NewRepository}
+}
+
+func (s *oidcService) Enabled() bool {
+	return config.AppConfig.OIDCEnabled()
+}
+
+// discover resolves the issuer metadata lazily so the API can start while
+// the identity provider is unreachable; a failed discovery is retried on the
+// next login.
+func (s *oidcService) discover(ctx context.Context) (*oidc.Provider, *oauth2.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider == nil {
+		provider, err := oidc.NewProvider(ctx, config.AppConfig.OIDCIssuer)
+		if err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadGateway, "Failed to discover identity provider: "+err.Error())
+		}
+		s.provider = provider
+	}
+
+	oauthCfg := &oauth2.Config{
+		ClientID:     config.AppConfig.OIDCClientID,
+		ClientSecret: config.AppConfig.OIDCClientSecret,
+		RedirectURL:  config.AppConfig.OIDCRedirectURL,
+		Endpoint:     s.provider.Endpoint(),
+		Scopes:       config.AppConfig.OIDCScopes,
+	}
+
+	return s.provider, oauthCfg, nil
+}
+
+func (s *oidcService) Begin(ctx context.Context) (string, *OIDCState, error) {
+	_, oauthCfg, err := s.discover(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return "", nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate login state")
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return "", nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate login state")
+	}
+
+	st := &OIDCState{
+		State:    state,
+		Nonce:    nonce,
+		Verifier: oauth2.GenerateVerifier(),
+	}
+
+	authURL := oauthCfg.AuthCodeURL(st.State, oidc.Nonce(st.Nonce), oauth2.S256ChallengeOption(st.Verifier))
+	return authURL, st, nil
+}
+
+func (s *oidcService) Complete(ctx context.Context, code string, state *OIDCState, actor audit.Actor, tx *gorm.DB) (string, *AuthResponse, error) {
+	provider, oauthCfg, err := s.discover(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, err := oauthCfg.Exchange(ctx, code, oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Failed to exchange authorization code")
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Identity provider did not return an ID token")
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: oauthCfg.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid ID token: "+err.Error())
+	}
+	if idToken.Nonce != state.Nonce {
+		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid ID token nonce")
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid ID token claims")
+	}
+
+	user, err := provisionUser(s.repository(tx), idToken.Subject, claims, actor, tx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Second-factor policy is owned by the identity provider for SSO logins.
+	return issueSession(user, true)
+}
+
+// provisionUser finds the user linked to the subject, links an existing user
+// with the same email, or creates a new one. Linking requires the provider to
+// assert email_verified, otherwise anyone able to register that address at
+// the provider could take over the local account. New users get the role
+// their groups map to, or OIDC_DEFAULT_ROLE; existing users are only re-synced
+// when a mapped group is present, so a local admin signing in without one
+// keeps their role.
+func provisionUser(repo Repository, subject string, claims map[string]any, actor audit.Actor, tx *gorm.DB) (*domain.User, error) {
+	email, _ := claims["email"].(string)
+	verified, hasVerified := claims["email_verified"].(bool)
+	if hasVerified && !verified {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Email address is not verified by the identity provider")
+	}
+
+	role, mapped := mapGroupsToRole(claims[config.AppConfig.OIDCGroupsClaim])
+
+	user, err := repo.FindUserByOIDCSubject(subject)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if user == nil {
+		if email == "" {
+			return nil, fiber.NewError(fiber.StatusForbidden, "Identity provider did not return an email claim")
+		}
+
+		user, err = repo.FindUserByEmail(email)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		if user != nil {
+			if user.OIDCSubject != nil && *user.OIDCSubject != subject {
+				return nil, fiber.NewError(fiber.StatusConflict, "Email is already linked to another identity")
+			}
+			if !verified {
+				return nil, fiber.NewError(fiber.StatusForbidden, "The identity provider must verify the email address before it can be linked to an existing account")
+			}
+			if err := repo.LinkOIDCSubject(user.ID, subject); err != nil {
+				return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+
+			before := userSnapshot(user)
+			user.OIDCSubject = &subject
+			if err := audit.Record(tx, actorFor(actor, user), audit.EntityUser, user.ID.String(), audit.ActionLinkIdentity, before, userSnapshot(user)); err != nil {
+				return nil, err
+			}
+		} else {
+			user, err = createOIDCUser(repo, subject, email, claims, role)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := audit.Record(tx, actorFor(actor, user), audit.EntityUser, user.ID.String(), audit.ActionCreate, nil, userSnapshot(user)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if mapped && user.Role != role {
+		if err := repo.UpdateRole(user.ID, role); err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		before := userSnapshot(user)
+		user.Role = role
+		if err := audit.Record(tx, actorFor(actor, user), audit.EntityUser, user.ID.String(), audit.ActionUpdate, before, userSnapshot(user)); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+// actorFor attributes SSO provisioning to the user signing in.
+func actorFor(actor audit.Actor, user *domain.User) audit.Actor {
+	actor.ID, actor.Name, actor.Type = user.ID.String(), user.UserName, audit.ActorTypeUser
+	return actor
+}
+
+func createOIDCUser(repo Repository, subject, email string, claims map[string]any, role string) (*domain.User, error) {
+	userName, err := uniqueUserName(repo, claims, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// SSO users get an unguessable password so the password login stays closed.
+	secret, err := randomToken()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to provision user")
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to provision user")
+	}
+
+	user := &domain.User{
+		ID:          uuid.New(),
+		UserName:    userName,
+		Email:       email,
+		Password:    string(hashedPassword),
+		Role:        role,
+		OIDCSubject: &subject,
+	}
+
+	if err := repo.CreateUser(user); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return user, nil
+}
+
+func uniqueUserName(repo Repository, claims map[string]any, email string) (string, error) {
+	base, _ := claims["preferred_username"].(string)
+	if base == "" {
+		base, _, _ = strings.Cut(email, "@")
+	}
+	if len(base) > 90 {
+		base = base[:90]
+	}
+
+	candidate := base
+	for range 5 {
+		exists, err := repo.UserNameExists(candidate)
+		if err != nil {
+			return "", fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = base + "-" + uuid.New().String()[0:4]
+	}
+	return "", fiber.NewError(fiber.StatusConflict, "Could not allocate a unique username")
+}
+
+func (s *oidcService) SealState(state *OIDCState) (string, error) {
+	claims := oidcStateClaims{
+		OIDCState: *state,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(oidcStateTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.AppConfig.SessionSecret))
+}
+
+func (s *oidcService) OpenState(sealed string) (*OIDCState, error) {
+	claims := &oidcStateClaims{}
+	_, err := jwt.ParseWithClaims(sealed, claims, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(config.AppConfig.SessionSecret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &claims.OIDCState, nil
+}
+
+// mapGroupsToRole returns the highest-priority role the groups map to, and
+// whether any group was mapped at all. Without a mapped group it returns
+// OIDC_DEFAULT_ROLE and false.
+func mapGroupsToRole(raw any) (string, bool) {
+	var groups []string
+	switch v := raw.(type) {
+	case string:
+		groups = []string{v}
+	case []any:
+		for _, g := range v {
+			if name, ok := g.(string); ok {
+				groups = append(groups, name)
+			}
+		}
+	}
+
+	role := config.AppConfig.OIDCDefaultRole
+	best := 0
+	for _, group := range groups {
+		if mapped, ok := config.AppConfig.OIDCRoleMap[group]; ok && rolePriority[mapped] > best {
+			role = mapped
+			best = rolePriority[mapped]
+		}
+	}
+	return role, best > 0
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,527 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testClientID = "coa-api"
+
+// mockIssuer is a minimal OpenID provider serving discovery, JWKS and the
+// token endpoint. Codes are registered by authorize with the PKCE challenge
+// and nonce taken from the authorization URL, as a real provider would.
+type mockIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+
+	// key is published in the JWKS; signKey signs ID tokens. They differ
+	// only when a test wants a bad signature.
+	key     *rsa.PrivateKey
+	signKey *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+type authorization struct {
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	m := &mockIssuer{t: t, key: key, signKey: key, codes: map[string]authorization{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", m.discovery)
+	mux.HandleFunc("/keys", m.jwks)
+	mux.HandleFunc("/token", m.token)
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+func (m *mockIssuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                m.server.URL,
+		"authorization_endpoint":                m.server.URL + "/authorize",
+		"token_endpoint":                        m.server.URL + "/token",
+		"jwks_uri":                              m.server.URL + "/keys",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (m *mockIssuer) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := m.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "test",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	m.mu.Lock()
+	auth, ok := m.codes[r.PostForm.Get("code")]
+	delete(m.codes, r.PostForm.Get("code"))
+	m.mu.Unlock()
+
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != auth.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   testClientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": auth.nonce,
+	}
+	for k, v := range auth.claims {
+		claims[k] = v
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = "test"
+	signed, err := idToken.SignedString(m.signKey)
+	if err != nil {
+		m.t.Errorf("sign id token: %v", err)
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+// authorize plays the user approving the login at the provider: it checks
+// the authorization URL and registers a code bound to its PKCE challenge.
+// nonce overrides the nonce from the URL when not empty.
+func (m *mockIssuer) authorize(authURL string, claims jwt.MapClaims, nonce string) string {
+	m.t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatalf("parse authorization URL: %v", err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		m.t.Fatalf("authorization URL is missing a S256 PKCE challenge: %s", authURL)
+	}
+	if nonce == "" {
+		nonce = q.Get("nonce")
+	}
+
+	code := uuid.NewString()
+	m.mu.Lock()
+	m.codes[code] = authorization{challenge: q.Get("code_challenge"), nonce: nonce, claims: claims}
+	m.mu.Unlock()
+	return code
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// fakeRepository keeps users in memory; only the calls made by SSO login are
+// implemented.
+type fakeRepository struct {
+	Repository
+	users map[uuid.UUID]*domain.User
+}
+
+func newFakeRepository(users ...*domain.User) *fakeRepository {
+	r := &fakeRepository{users: map[uuid.UUID]*domain.User{}}
+	for _, u := range users {
+		r.users[u.ID] = u
+	}
+	return r
+}
+
+func (r *fakeRepository) FindUserByEmail(email string) (*domain.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepository) FindUserByOIDCSubject(subject string) (*domain.User, error) {
+	for _, u := range r.users {
+		if u.OIDCSubject != nil && *u.OIDCSubject == subject {
+			copied := *u
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRepository) CreateUser(user *domain.User) error {
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func (r *fakeRepository) UserNameExists(userName string) (bool, error) {
+	for _, u := range r.users {
+		if u.UserName == userName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeRepository) LinkOIDCSubject(userID uuid.UUID, subject string) error {
+	r.users[userID].OIDCSubject = &subject
+	return nil
+}
+
+func (r *fakeRepository) UpdateRole(userID uuid.UUID, role string) error {
+	r.users[userID].Role = role
+	return nil
+}
+
+// dryRunDB accepts the audit writes made during login without a database.
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost user=test dbname=test"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open dry-run db: %v", err)
+	}
+	return db
+}
+
+type oidcFixture struct {
+	issuer  *mockIssuer
+	repo    *fakeRepository
+	service *oidcService
+	db      *gorm.DB
+}
+
+func newOIDCFixture(t *testing.T, users ...*domain.User) *oidcFixture {
+	t.Helper()
+
+	issuer := newMockIssuer(t)
+	config.AppConfig = &config.Config{
+		JWTSecret:        "test-secret",
+		JWTExpiresHour:   1,
+		SessionSecret:    "test-secret",
+		OIDCIssuer:       issuer.server.URL,
+		OIDCClientID:     testClientID,
+		OIDCClientSecret: "client-secret",
+		OIDCRedirectURL:  "http://localhost:8080/api/v1/auth/oidc/callback",
+		OIDCScopes:       []string{"openid", "email", "profile", "groups"},
+		OIDCGroupsClaim:  "groups",
+		OIDCRoleMap:      map[string]string{"finance-admins": "admin", "finance": "user"},
+		OIDCDefaultRole:  "user",
+	}
+
+	repo := newFakeRepository(users...)
+	return &oidcFixture{
+		issuer:  issuer,
+		repo:    repo,
+		service: &oidcService{repository: func(*gorm.DB) Repository { return repo }},
+		db:      dryRunDB(t),
+	}
+}
+
+// login runs a full authorization code flow with claims in the ID token.
+func (f *oidcFixture) login(t *testing.T, claims jwt.MapClaims) (*AuthResponse, error) {
+	t.Helper()
+
+	authURL, state, err := f.service.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	code := f.issuer.authorize(authURL, claims, "")
+
+	_, resp, err := f.service.Complete(context.Background(), code, state, audit.Actor{}, f.db)
+	return resp, err
+}
+
+func wantStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	var fe *fiber.Error
+	if !errors.As(err, &fe) || fe.Code != status {
+		t.Fatalf("want fiber error %d, got %v", status, err)
+	}
+}
+
+func TestOIDCLoginProvisionsNewUser(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	resp, err := f.login(t, jwt.MapClaims{
+		"sub":                "sub-new",
+		"email":              "rina@example.com",
+		"email_verified":     true,
+		"preferred_username": "rina",
+		"groups":             []string{"finance"},
+	})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if resp.Email != "rina@example.com" || resp.UserName != "rina" || resp.Role != "user" {
+		t.Fatalf("unexpected session: %+v", resp)
+	}
+
+	user, _ := f.repo.FindUserByOIDCSubject("sub-new")
+	if user == nil || user.ID.String() != resp.UserID {
+		t.Fatalf("user was not provisioned with the subject: %+v", user)
+	}
+}
+
+func TestOIDCLoginReusesLinkedUser(t *testing.T) {
+	subject := "sub-linked"
+	existing := &domain.User{ID: uuid.New(), UserName: "budi", Email: "budi@example.com", Role: "user", OIDCSubject: &subject}
+	f := newOIDCFixture(t, existing)
+
+	// The email claim changed at the provider; the subject still wins.
+	resp, err := f.login(t, jwt.MapClaims{"sub": subject, "email": "budi.new@example.com"})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if resp.UserID != existing.ID.String() || len(f.repo.users) != 1 {
+		t.Fatalf("expected the linked user to sign in, got %+v", resp)
+	}
+}
+
+func TestOIDCLoginLinksVerifiedEmail(t *testing.T) {
+	existing := &domain.User{ID: uuid.New(), UserName: "admin", Email: "admin@example.com", Role: "admin"}
+	f := newOIDCFixture(t, existing)
+
+	resp, err := f.login(t, jwt.MapClaims{"sub": "sub-admin", "email": "admin@example.com", "email_verified": true})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if resp.UserID != existing.ID.String() {
+		t.Fatalf("expected the existing user to be linked, got %+v", resp)
+	}
+	if got := f.repo.users[existing.ID].OIDCSubject; got == nil || *got != "sub-admin" {
+		t.Fatalf("subject was not linked: %v", got)
+	}
+	if len(f.repo.users) != 1 {
+		t.Fatalf("a new user was provisioned instead of linking")
+	}
+}
+
+func TestOIDCLoginRefusesToLinkUnverifiedEmail(t *testing.T) {
+	existing := &domain.User{ID: uuid.New(), UserName: "admin", Email: "admin@example.com", Role: "admin"}
+
+	for name, claims := range map[string]jwt.MapClaims{
+		"claim missing": {"sub": "sub-attacker", "email": "admin@example.com"},
+		"claim false":   {"sub": "sub-attacker", "email": "admin@example.com", "email_verified": false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			user := *existing
+			f := newOIDCFixture(t, &user)
+
+			_, err := f.login(t, claims)
+			wantStatus(t, err, fiber.StatusForbidden)
+			if f.repo.users[existing.ID].OIDCSubject != nil {
+				t.Fatalf("unverified email was linked to the existing account")
+			}
+		})
+	}
+}
+
+func TestOIDCLoginProvisionsWithoutEmailVerifiedClaim(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	resp, err := f.login(t, jwt.MapClaims{"sub": "sub-plain", "email": "sari@example.com"})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if resp.UserName != "sari" || len(f.repo.users) != 1 {
+		t.Fatalf("expected a new user, got %+v", resp)
+	}
+}
+
+func TestOIDCLoginRejectsBadNonce(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	authURL, state, err := f.service.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	code := f.issuer.authorize(authURL, jwt.MapClaims{"sub": "sub-1", "email": "a@example.com"}, "replayed-nonce")
+
+	_, _, err = f.service.Complete(context.Background(), code, state, audit.Actor{}, f.db)
+	wantStatus(t, err, fiber.StatusUnauthorized)
+	if len(f.repo.users) != 0 {
+		t.Fatalf("user was provisioned from a token with a bad nonce")
+	}
+}
+
+func TestOIDCLoginRejectsBadSignature(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	f.issuer.signKey = other
+
+	_, err = f.login(t, jwt.MapClaims{"sub": "sub-1", "email": "a@example.com"})
+	wantStatus(t, err, fiber.StatusUnauthorized)
+	if len(f.repo.users) != 0 {
+		t.Fatalf("user was provisioned from a forged token")
+	}
+}
+
+func TestOIDCLoginRequiresPKCEVerifier(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	authURL, state, err := f.service.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	code := f.issuer.authorize(authURL, jwt.MapClaims{"sub": "sub-1", "email": "a@example.com"}, "")
+
+	// A stolen code is useless without the verifier kept in the state cookie.
+	forged := *state
+	forged.Verifier = "not-the-verifier-that-made-the-challenge-0123456789"
+
+	_, _, err = f.service.Complete(context.Background(), code, &forged, audit.Actor{}, f.db)
+	wantStatus(t, err, fiber.StatusUnauthorized)
+}
+
+func TestOIDCLoginMapsGroupsToRole(t *testing.T) {
+	f := newOIDCFixture(t)
+
+	resp, err := f.login(t, jwt.MapClaims{
+		"sub":    "sub-1",
+		"email":  "dewi@example.com",
+		"groups": []string{"finance", "finance-admins", "unmapped"},
+	})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if resp.Role != "admin" {
+		t.Fatalf("want the highest mapped role admin, got %s", resp.Role)
+	}
+}
+
+func TestOIDCLoginRoleSync(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups any
+		want   string
+	}{
+		{"no groups keeps local admin", nil, "admin"},
+		{"unmapped groups keep local admin", []string{"sales"}, "admin"},
+		{"mapped group re-syncs role", []string{"finance"}, "user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject := "sub-admin"
+			existing := &domain.User{ID: uuid.New(), UserName: "admin", Email: "admin@example.com", Role: "admin", OIDCSubject: &subject}
+			f := newOIDCFixture(t, existing)
+
+			claims := jwt.MapClaims{"sub": subject, "email": "admin@example.com"}
+			if tt.groups != nil {
+				claims["groups"] = tt.groups
+			}
+
+			resp, err := f.login(t, claims)
+			if err != nil {
+				t.Fatalf("login: %v", err)
+			}
+			if resp.Role != tt.want || f.repo.users[existing.ID].Role != tt.want {
+				t.Fatalf("want role %s, got session %s and stored %s", tt.want, resp.Role, f.repo.users[existing.ID].Role)
+			}
+		})
+	}
+}
+
+func TestMapGroupsToRole(t *testing.T) {
+	config.AppConfig = &config.Config{
+		OIDCRoleMap:     map[string]string{"finance-admins": "admin", "finance": "user"},
+		OIDCDefaultRole: "user",
+	}
+
+	tests := []struct {
+		name       string
+		raw        any
+		wantRole   string
+		wantMapped bool
+	}{
+		{"missing claim", nil, "user", false},
+		{"single string", "finance-admins", "admin", true},
+		{"list picks highest priority", []any{"finance", "finance-admins"}, "admin", true},
+		{"unmapped only", []any{"sales", 42}, "user", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, mapped := mapGroupsToRole(tt.raw)
+			if role != tt.wantRole || mapped != tt.wantMapped {
+				t.Fatalf("got (%s, %v), want (%s, %v)", role, mapped, tt.wantRole, tt.wantMapped)
+			}
+		})
+	}
+}
@@ -10,9 +10,18 @@ import (
 type Repository interface {
 	FindUserByEmail(email string) (*domain.User, error)
 	FindUserByID(id uuid.UUID) (*domain.User, error)
+	FindUserByOIDCSubject(subject string) (*domain.User, error)
 	CreateUser(user *domain.User) error
 	EmailExists(email string) (bool, error)
 	UserNameExists(userName string) (bool, error)
+	LinkOIDCSubject(userID uuid.UUID, subject string) error
+	UpdateRole(userID uuid.UUID, role string) error
+	SetTOTPSecret(userID uuid.UUID, secret string) error
+	EnableTOTP(userID uuid.UUID, step int64, codeHashes []string) error
+	DisableTOTP(userID uuid.UUID) error
+	ConsumeTOTPStep(userID uuid.UUID, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID uuid.UUID, codeHashes []string) error
+	ConsumeRecoveryCode(userID uuid.UUID, codeHash string) (bool, error)
 }
 
 type repository struct {
@@ -26,7 +35,7 @@ func NewRepository(db *gorm.DB) Repository {
 func (r *repository) FindUserByEmail(email string) (*domain.User, error) {
 	var user domain.User
 	result := r.db.Raw(
-		`SELECT id, user_name, email, password, role, created_at, updated_at
+		`SELECT id, user_name, email, password, role, COALESCE(totp_secret, '') AS totp_secret, totp_enabled, totp_last_step, oidc_subject, created_at, updated_at
 		 FROM users WHERE email = ? AND deleted_at IS NULL LIMIT 1`,
 		email,
 	).Scan(&user)
@@ -45,7 +54,7 @@ func (r *repository) FindUserByEmail(email string) (*domain.User, error) {
 func (r *repository) FindUserByID(id uuid.UUID) (*domain.User, error) {
 	var user domain.User
 	result := r.db.Raw(
-		`SELECT id, user_name, email, role, created_at, updated_at
+		`SELECT id, user_name, email, password, role, COALESCE(totp_secret, '') AS totp_secret, totp_enabled, totp_last_step, oidc_subject, created_at, updated_at
 		 FROM users WHERE id = ? AND deleted_at IS NULL LIMIT 1`,
 		id,
 	).Scan(&user)
@@ -59,11 +68,28 @@ func (r *repository) FindUserByID(id uuid.UUID) (*domain.User, error) {
 	return &user, nil
 }
 
+func (r *repository) FindUserByOIDCSubject(subject string) (*domain.User, error) {
+	var user domain.User
+	result := r.db.Raw(
+		`SELECT id, user_name, email, password, role, COALESCE(totp_secret, '') AS totp_secret, totp_enabled, totp_last_step, oidc_subject, created_at, updated_at
+		 FROM users WHERE oidc_subject = ? AND deleted_at IS NULL LIMIT 1`,
+		subject,
+	).Scan(&user)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &user, nil
+}
+
 func (r *repository) CreateUser(user *domain.User) error {
 	return r.db.Exec(
-		`INSERT INTO users (id, user_name, email, password, role, created_at, updated_at)
-		 VALUES (gen_random_uuid(), ?, ?, ?, ?, NOW(), NOW())`,
-		user.UserName, user.Email, user.Password, user.Role,
+		`INSERT INTO users (id, user_name, email, password, role, oidc_subject, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		user.ID, user.UserName, user.Email, user.Password, user.Role, user.OIDCSubject,
 	).Error
 }
 
@@ -82,3 +108,93 @@ func (r *repository) UserNameExists(userName string) (bool, error) {
 	).Scan(&count).Error
 	return count > 0, err
 }
+
+func (r *repository) SetTOTPSecret(userID uuid.UUID, secret string) error {
+	return r.db.Exec(
+		`UPDATE users SET totp_secret = ?, updated_at = NOW()
+		 WHERE id = ? AND totp_enabled = false AND deleted_at IS NULL`,
+		secret, userID,
+	).Error
+}
+
+func (r *repository) EnableTOTP(userID uuid.UUID, step int64, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			`UPDATE users SET totp_enabled = true, totp_last_step = ?, updated_at = NOW()
+			 WHERE id = ? AND deleted_at IS NULL`,
+			step, userID,
+		).Error; err != nil {
+			return err
+		}
+		return (&repository{db: tx}).ReplaceRecoveryCodes(userID, codeHashes)
+	})
+}
+
+func (r *repository) DisableTOTP(userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(
+			`UPDATE users SET totp_enabled = false, totp_secret = NULL, totp_last_step = 0, updated_at = NOW()
+			 WHERE id = ? AND deleted_at IS NULL`,
+			userID,
+		).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`DELETE FROM recovery_codes WHERE user_id = ?`, userID).Error
+	})
+}
+
+// ConsumeTOTPStep records the time step of an accepted code. It reports false
+// when the step (or a later one) was already used, which blocks code replay.
+func (r *repository) ConsumeTOTPStep(userID uuid.UUID, step int64) (bool, error) {
+	result := r.db.Exec(
+		`UPDATE users SET totp_last_step = ? WHERE id = ? AND totp_last_step < ?`,
+		step, userID, step,
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) ReplaceRecoveryCodes(userID uuid.UUID, codeHashes []string) error {
+	if err := r.db.Exec(`DELETE FROM recovery_codes WHERE user_id = ?`, userID).Error; err != nil {
+		return err
+	}
+
+	for _, hash := range codeHashes {
+		if err := r.db.Exec(
+			`INSERT INTO recovery_codes (id, user_id, code_hash, created_at)
+			 VALUES (gen_random_uuid(), ?, ?, NOW())`,
+			userID, hash,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) ConsumeRecoveryCode(userID uuid.UUID, codeHash string) (bool, error) {
+	result := r.db.Exec(
+		`UPDATE recovery_codes SET used_at = NOW()
+		 WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`,
+		userID, codeHash,
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) LinkOIDCSubject(userID uuid.UUID, subject string) error {
+	return r.db.Exec(
+		`UPDATE users SET oidc_subject = ?, updated_at = NOW() WHERE id = ? AND deleted_at IS NULL`,
+		subject, userID,
+	).Error
+}
+
+func (r *repository) UpdateRole(userID uuid.UUID, role string) error {
+	return r.db.Exec(
+		`UPDATE users SET role = ?, updated_at = NOW() WHERE id = ? AND deleted_at IS NULL`,
+		role, userID,
+	).Error
+}
@@ -4,15 +4,24 @@ import (
 	"fiber.com/session-api/pkg/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
-func RegisterRoutes(router fiber.Router, handler *Handler) {
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
 	auth := router.Group("/auth")
 
-	auth.Post("/register", handler.Register)
+	auth.Post("/register", middleware.DBTransaction(db), handler.Register)
 	auth.Post("/login", handler.Login)
+	auth.Post("/login/verify", handler.VerifyMFA)
+	auth.Get("/oidc/login", handler.OIDCLogin)
+	auth.Get("/oidc/callback", middleware.DBTransaction(db), handler.OIDCCallback)
 
-	auth.Use(middleware.AuthMiddleware())
+	auth.Use(middleware.AuthMiddleware(middleware.AuthConfig{AllowMFAEnrollment: true}))
 	auth.Post("/logout", handler.Logout)
 	auth.Get("/me", handler.Me)
+
+	auth.Post("/2fa/enroll", handler.EnrollTOTP)
+	auth.Post("/2fa/confirm", middleware.DBTransaction(db), handler.ConfirmTOTP)
+	auth.Post("/2fa/disable", middleware.DBTransaction(db), handler.DisableTOTP)
+	auth.Post("/2fa/recovery-codes", middleware.DBTransaction(db), handler.RegenerateRecoveryCodes)
 }
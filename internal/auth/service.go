@@ -1,17 +1,33 @@
 package auth
 
 import (
+	"encoding/base64"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
 	"fiber.com/session-api/internal/domain"
 	"fiber.com/session-api/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	qrcode "github.com/skip2/go-qrcode"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
+const recoveryCodeCount = 10
+
 type Service interface {
-	Register(req *RegisterRequest) (*domain.User, error)
-	Login(req *LoginRequest) (string, *AuthResponse, error)
+	Register(req *RegisterRequest, actor audit.Actor, tx *gorm.DB) (*domain.User, error)
+	// Login verifies the password. Users with TOTP enabled receive an MFA
+	// challenge instead of a session token and must call VerifyMFA next.
+	Login(req *LoginRequest) (string, *AuthResponse, *MFAChallengeResponse, error)
+	VerifyMFA(req *VerifyMFARequest) (string, *AuthResponse, error)
+	EnrollTOTP(userID uuid.UUID) (*TOTPEnrollResponse, error)
+	ConfirmTOTP(userID uuid.UUID, req *TOTPCodeRequest, actor audit.Actor, tx *gorm.DB) (*RecoveryCodesResponse, error)
+	DisableTOTP(userID uuid.UUID, req *DisableTOTPRequest, actor audit.Actor, tx *gorm.DB) error
+	RegenerateRecoveryCodes(userID uuid.UUID, req *TOTPCodeRequest, actor audit.Actor, tx *gorm.DB) (*RecoveryCodesResponse, error)
 }
 
 type service struct {
@@ -22,8 +38,10 @@ func NewService(repo Repository) Service {
 	return &service{repo: repo}
 }
 
-func (s *service) Register(req *RegisterRequest) (*domain.User, error) {
-	emailExists, err := s.repo.EmailExists(req.Email)
+func (s *service) Register(req *RegisterRequest, actor audit.Actor, tx *gorm.DB) (*domain.User, error) {
+	txRepo := NewRepository(tx)
+
+	emailExists, err := txRepo.EmailExists(req.Email)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -31,7 +49,7 @@ func (s *service) Register(req *RegisterRequest) (*domain.User, error) {
 		return nil, fiber.NewError(fiber.StatusConflict, "Email already registered")
 	}
 
-	userNameExists, err := s.repo.UserNameExists(req.UserName)
+	userNameExists, err := txRepo.UserNameExists(req.UserName)
 
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -59,40 +77,290 @@ func (s *service) Register(req *RegisterRequest) (*domain.User, error) {
 		Role:     role,
 	}
 
-	if err := s.repo.CreateUser(user); err != nil {
+	if err := txRepo.CreateUser(user); err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	// Self-registration has no authenticated actor; attribute it to the new user.
+	if actor.ID == "" {
+		actor.ID, actor.Name, actor.Type = user.ID.String(), user.UserName, audit.ActorTypeUser
+	}
+	if err := audit.Record(tx, actor, audit.EntityUser, user.ID.String(), audit.ActionCreate, nil, userSnapshot(user)); err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
-func (s *service) Login(req *LoginRequest) (string, *AuthResponse, error) {
+func (s *service) Login(req *LoginRequest) (string, *AuthResponse, *MFAChallengeResponse, error) {
 	user, err := s.repo.FindUserByEmail(req.Email)
 
 	if err != nil {
-		return "", nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return "", nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	if user == nil {
-		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid email or password")
+		return "", nil, nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid email or password")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid email or password")
+		return "", nil, nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid email or password")
+	}
+
+	if user.TOTPEnabled {
+		mfaToken, err := utils.GenerateMFAPendingToken(user.ID.String())
+		if err != nil {
+			return "", nil, nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate token")
+		}
+
+		return "", nil, &MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			ExpiresIn:   config.AppConfig.MFAPendingMinutes * 60,
+		}, nil
+	}
+
+	tokenStr, authResp, err := issueSession(user, false)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return tokenStr, authResp, nil, nil
+}
+
+func (s *service) VerifyMFA(req *VerifyMFARequest) (string, *AuthResponse, error) {
+	claims, err := utils.ValidateMFAPendingToken(req.MFAToken)
+	if err != nil {
+		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired MFA token")
 	}
 
-	tokenStr, err := utils.GenerateToken(user.ID.String(), user.UserName, user.Email, user.Role)
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired MFA token")
+	}
+
+	user, err := s.repo.FindUserByID(userID)
+	if err != nil {
+		return "", nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if user == nil || !user.TOTPEnabled {
+		return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired MFA token")
+	}
+
+	switch {
+	case req.Code != "":
+		if err := checkTOTP(s.repo, user, req.Code); err != nil {
+			return "", nil, err
+		}
+	case req.RecoveryCode != "":
+		ok, err := s.repo.ConsumeRecoveryCode(user.ID, utils.HashRecoveryCode(req.RecoveryCode))
+		if err != nil {
+			return "", nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if !ok {
+			return "", nil, fiber.NewError(fiber.StatusUnauthorized, "Invalid recovery code")
+		}
+	default:
+		return "", nil, fiber.NewError(fiber.StatusBadRequest, "Either code or recoveryCode is required")
+	}
+
+	return issueSession(user, true)
+}
+
+func (s *service) EnrollTOTP(userID uuid.UUID) (*TOTPEnrollResponse, error) {
+	user, err := findUser(s.repo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, fiber.NewError(fiber.StatusConflict, "Two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate TOTP secret")
+	}
+
+	if err := s.repo.SetTOTPSecret(user.ID, secret); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	uri := utils.TOTPURI(config.AppConfig.TOTPIssuer, user.Email, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, 256)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate QR code")
+	}
+
+	return &TOTPEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: uri,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+func (s *service) ConfirmTOTP(userID uuid.UUID, req *TOTPCodeRequest, actor audit.Actor, tx *gorm.DB) (*RecoveryCodesResponse, error) {
+	txRepo := NewRepository(tx)
+
+	user, err := findUser(txRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabled {
+		return nil, fiber.NewError(fiber.StatusConflict, "Two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Start enrollment before confirming")
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, req.Code, time.Now())
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid TOTP code")
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txRepo.EnableTOTP(user.ID, step, hashes); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	before := userSnapshot(user)
+	user.TOTPEnabled = true
+	if err := audit.Record(tx, actor, audit.EntityUser, user.ID.String(), audit.ActionEnableMFA, before, userSnapshot(user)); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (s *service) DisableTOTP(userID uuid.UUID, req *DisableTOTPRequest, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	user, err := findUser(txRepo, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TOTPEnabled {
+		return fiber.NewError(fiber.StatusBadRequest, "Two-factor authentication is not enabled")
+	}
+	if config.AppConfig.MFARequiredFor(user.Role) {
+		return fiber.NewError(fiber.StatusForbidden, "Two-factor authentication is mandatory for this role")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return fiber.NewError(fiber.StatusUnauthorized, "Invalid password")
+	}
+	if err := checkTOTP(txRepo, user, req.Code); err != nil {
+		return err
+	}
+
+	if err := txRepo.DisableTOTP(user.ID); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	before := userSnapshot(user)
+	user.TOTPEnabled = false
+	return audit.Record(tx, actor, audit.EntityUser, user.ID.String(), audit.ActionDisableMFA, before, userSnapshot(user))
+}
+
+func (s *service) RegenerateRecoveryCodes(userID uuid.UUID, req *TOTPCodeRequest, actor audit.Actor, tx *gorm.DB) (*RecoveryCodesResponse, error) {
+	txRepo := NewRepository(tx)
+
+	user, err := findUser(txRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TOTPEnabled {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Two-factor authentication is not enabled")
+	}
+	if err := checkTOTP(txRepo, user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txRepo.ReplaceRecoveryCodes(user.ID, hashes); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := audit.Record(tx, actor, audit.EntityUser, user.ID.String(), audit.ActionRegenerateRecoveryCodes, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return &RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func findUser(repo Repository, userID uuid.UUID) (*domain.User, error) {
+	user, err := repo.FindUserByID(userID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if user == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "User not found")
+	}
+	return user, nil
+}
+
+func checkTOTP(repo Repository, user *domain.User, code string) error {
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return fiber.NewError(fiber.StatusUnauthorized, "Invalid TOTP code")
+	}
+
+	fresh, err := repo.ConsumeTOTPStep(user.ID, step)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if !fresh {
+		return fiber.NewError(fiber.StatusUnauthorized, "TOTP code already used")
+	}
+	return nil
+}
+
+func issueSession(user *domain.User, mfaVerified bool) (string, *AuthResponse, error) {
+	tokenStr, err := utils.GenerateToken(user.ID.String(), user.UserName, user.Email, user.Role, mfaVerified)
 
 	if err != nil {
 		return "", nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate token")
 	}
 
 	authResp := &AuthResponse{
-		UserID:   user.ID.String(),
-		UserName: user.UserName,
-		Email:    user.Email,
-		Role:     user.Role,
+		UserID:                user.ID.String(),
+		UserName:              user.UserName,
+		Email:                 user.Email,
+		Role:                  user.Role,
+		MFAEnrollmentRequired: !mfaVerified && config.AppConfig.MFARequiredFor(user.Role),
 	}
 
 	return tokenStr, authResp, nil
 }
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate recovery codes")
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
+
+// userSnapshot is the audited view of a user; secrets are never included.
+func userSnapshot(user *domain.User) map[string]any {
+	return map[string]any{
+		"id":          user.ID.String(),
+		"userName":    user.UserName,
+		"email":       user.Email,
+		"role":        user.Role,
+		"totpEnabled": user.TOTPEnabled,
+		"ssoLinked":   user.OIDCSubject != nil,
+	}
+}
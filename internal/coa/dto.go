@@ -10,13 +10,17 @@ type CreateCOARequest struct {
 	Name       string             `json:"name"       validate:"required"                                          example:"Kas dan Setara Kas"`
 	Type       domain.AccountType `json:"type"       validate:"required,oneof=asset liability equity revenue expense" example:"asset"`
 	ParentCode *string            `json:"parentCode" validate:"omitempty"                                         example:"1-1000"`
+	Kind       domain.AccountKind `json:"kind"       validate:"omitempty,oneof=header postable"                   example:"postable"`
 	IsActive   *bool              `json:"isActive"                                                                example:"true"`
+	// Revive reuses the code of a soft-deleted account instead of failing.
+	Revive bool `json:"revive" example:"false"`
 }
 
 type UpdateCOARequest struct {
 	Name       string             `json:"name"       validate:"omitempty" example:"Kas dan Setara Kas"`
 	Type       domain.AccountType `json:"type"       validate:"omitempty,oneof=asset liability equity revenue expense" example:"asset"`
 	ParentCode *string            `json:"parentCode" validate:"omitempty" example:"1-1000"`
+	Kind       domain.AccountKind `json:"kind"       validate:"omitempty,oneof=header postable" example:"postable"`
 	IsActive   *bool              `json:"isActive"                        example:"true"`
 }
 
@@ -25,12 +29,147 @@ type COAResponse struct {
 	Name       string             `json:"name"`
 	Type       domain.AccountType `json:"type"`
 	ParentCode *string            `json:"parentCode"`
+	Kind       domain.AccountKind `json:"kind"`
 	IsActive   bool               `json:"isActive"`
 }
 
+// COAUsage summarises how much live data still references an account.
+type COAUsage struct {
+	DraftLines       int64   `gorm:"column:draft_lines"`
+	PostedLines      int64   `gorm:"column:posted_lines"`
+	Balance          float64 `gorm:"column:balance"`
+	AllocationRules  int64   `gorm:"column:allocation_rules"`
+	JournalTemplates int64   `gorm:"column:journal_templates"`
+	Documents        int64   `gorm:"column:documents"`
+}
+
+// CodeReference is an account code held by configuration outside the chart.
+type CodeReference struct {
+	Code   string `gorm:"column:code"`
+	UsedBy string `gorm:"column:used_by"`
+}
+
+type RenumberRequest struct {
+	NewCode string `json:"newCode" validate:"required" example:"1-1105"`
+	Preview bool   `json:"preview"                     example:"true"`
+}
+
+type MergeRequest struct {
+	TargetCode string `json:"targetCode" validate:"required" example:"1-1101"`
+	Preview    bool   `json:"preview"                        example:"true"`
+}
+
+// CodeChangeResponse describes the rows a renumber or merge touches. With
+// Preview set nothing has been written yet.
+type CodeChangeResponse struct {
+	Operation   string   `json:"operation"`
+	SourceCode  string   `json:"sourceCode"`
+	TargetCode  string   `json:"targetCode"`
+	Children    []string `json:"children"`
+	DraftLines  int64    `json:"draftLines"`
+	PostedLines int64    `json:"postedLines"`
+	Balance     float64  `json:"balance"`
+	// AllocationRules counts the rules whose source, basis or target
+	// accounts follow the code change; JournalTemplates the recurring
+	// templates whose lines do; Documents the subledger document lines.
+	AllocationRules  int64 `json:"allocationRules"`
+	JournalTemplates int64 `json:"journalTemplates"`
+	Documents        int64 `json:"documents"`
+	Preview          bool  `json:"preview"`
+}
+
 type CoaReqursiveResponse struct {
 	Code   string         `json:"code"`
 	Name   string         `json:"name"`
 	Type   string         `json:"type"`
 	Childs datatypes.JSON `json:"childs"`
 }
+
+// COATreeQuery filters the recursive COA tree. Root limits the result to the
+// subtree under that code; Search keeps matching nodes plus their ancestors.
+type COATreeQuery struct {
+	Root     string `query:"root"     example:"1-0000"`
+	Type     string `query:"type"     validate:"omitempty,oneof=asset liability equity revenue expense" example:"asset"`
+	Kind     string `query:"kind"     validate:"omitempty,oneof=header postable" example:"header"`
+	IsActive *bool  `query:"isActive" example:"true"`
+	Search   string `query:"search"`
+}
+
+// COATreeRow is one flattened node produced by the recursive tree query.
+type COATreeRow struct {
+	Code       string  `gorm:"column:code"`
+	Name       string  `gorm:"column:name"`
+	Type       string  `gorm:"column:type"`
+	ParentCode *string `gorm:"column:parent_code"`
+	Kind       string  `gorm:"column:kind"`
+	IsActive   bool    `gorm:"column:is_active"`
+	Depth      int     `gorm:"column:depth"`
+	Path       string  `gorm:"column:path"`
+	IsLeaf     bool    `gorm:"column:is_leaf"`
+}
+
+type COATreeNode struct {
+	Code       string         `json:"code"`
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	ParentCode *string        `json:"parentCode"`
+	Kind       string         `json:"kind"`
+	IsActive   bool           `json:"isActive"`
+	Depth      int            `json:"depth"`
+	Path       string         `json:"path"`
+	IsLeaf     bool           `json:"isLeaf"`
+	Children   []*COATreeNode `json:"children"`
+}
+
+type SwaggerCOATreeResponse struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    []COATreeNode `json:"data"`
+}
+
+// ImportRow is one data row of an import file, kept as raw text so every
+// problem can be reported against its line number.
+type ImportRow struct {
+	Line   int
+	Code   string
+	Name   string
+	Type   string
+	Parent string
+	Active string
+	Kind   string
+}
+
+type ImportQuery struct {
+	Mode   string `query:"mode"   validate:"omitempty,oneof=upsert replace" example:"upsert"`
+	Format string `query:"format" validate:"omitempty,oneof=csv xlsx"       example:"csv"`
+	DryRun bool   `query:"dryRun"                                           example:"false"`
+}
+
+type ImportError struct {
+	Line    int    `json:"line,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	Mode      string        `json:"mode"`
+	DryRun    bool          `json:"dryRun"`
+	Total     int           `json:"total"`
+	Created   int           `json:"created"`
+	Updated   int           `json:"updated"`
+	Unchanged int           `json:"unchanged"`
+	Deleted   int           `json:"deleted"`
+	Errors    []ImportError `json:"errors,omitempty"`
+}
+
+type TemplateResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Accounts    int    `json:"accounts"`
+}
+
+type SwaggerImportResponse struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Data    ImportResult `json:"data"`
+}
@@ -2,7 +2,12 @@ package coa
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/middleware"
 	"fiber.com/session-api/pkg/model"
 	"fiber.com/session-api/pkg/utils"
 
@@ -25,7 +30,9 @@ func NewHandler(service Service) *Handler {
 // @Param        page   query  int     true  "Page number"    minimum(1)
 // @Param        limit  query  int     true  "Items per page" minimum(1) maximum(100)
 // @Param        search query  string  false "Search by name or code"
+// @Param        kind   query  string  false "Filter by account kind" Enums(header, postable)
 // @Success      200  {object}  model.SwaggerCOAListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
 // @Failure      401  {object}  model.SwaggerErrorResponse
 // @Failure      500  {object}  model.SwaggerErrorResponse
 // @Security     CookieAuth
@@ -42,7 +49,12 @@ func (h *Handler) GetAll(c *fiber.Ctx) error {
 		req.Limit = 10
 	}
 
-	accounts, meta, err := h.service.GetAll(&req)
+	kind, err := kindQuery(c)
+	if err != nil {
+		return err
+	}
+
+	accounts, meta, err := h.service.GetAll(&req, kind)
 	if err != nil {
 		return err
 	}
@@ -55,13 +67,20 @@ func (h *Handler) GetAll(c *fiber.Ctx) error {
 // @Description  Returns a list of COAs
 // @Tags         COA
 // @Produce      json
+// @Param        kind   query  string  false "Filter by account kind" Enums(header, postable)
 // @Success      200  {object}  model.SwaggerCOAListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
 // @Failure      401  {object}  model.SwaggerErrorResponse
 // @Failure      500  {object}  model.SwaggerErrorResponse
 // @Security     CookieAuth
 // @Router       /coa/no-paginate [get]
 func (h *Handler) GetAllNoPaginate(c *fiber.Ctx) error {
-	accounts, err := h.service.GetAllNoPagination()
+	kind, err := kindQuery(c)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := h.service.GetAllNoPagination(kind)
 	if err != nil {
 		return err
 	}
@@ -77,7 +96,9 @@ func (h *Handler) GetAllNoPaginate(c *fiber.Ctx) error {
 // @Param        page   query  int     true  "Page number"    minimum(1)
 // @Param        limit  query  int     true  "Items per page" minimum(1) maximum(100)
 // @Param        search query  string  false "Search by name or code"
+// @Param        kind   query  string  false "Filter by account kind" Enums(header, postable)
 // @Success      200  {object}  model.SwaggerCOAListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
 // @Failure      401  {object}  model.SwaggerErrorResponse
 // @Failure      500  {object}  model.SwaggerErrorResponse
 // @Security     CookieAuth
@@ -94,7 +115,12 @@ func (h *Handler) GetAllWithChildren(c *fiber.Ctx) error {
 		req.Limit = 10
 	}
 
-	accounts, meta, err := h.service.GetAllWithChildren(&req)
+	kind, err := kindQuery(c)
+	if err != nil {
+		return err
+	}
+
+	accounts, meta, err := h.service.GetAllWithChildren(&req, kind)
 	if err != nil {
 		return err
 	}
@@ -102,6 +128,186 @@ func (h *Handler) GetAllWithChildren(c *fiber.Ctx) error {
 	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get all COA", accounts, meta)
 }
 
+// GetTree godoc
+// @Summary      Get the full COA tree
+// @Description  Returns the chart of accounts as fully nested nodes with depth, path and leaf flag. Search keeps the ancestors of matching nodes.
+// @Tags         COA
+// @Produce      json
+// @Param        root      query  string  false "Return only the subtree rooted at this code"
+// @Param        type      query  string  false "Filter by account type" Enums(asset, liability, equity, revenue, expense)
+// @Param        kind      query  string  false "Filter by account kind" Enums(header, postable)
+// @Param        isActive  query  bool    false "Filter by active state"
+// @Param        search    query  string  false "Search by code or name"
+// @Success      200  {object}  SwaggerCOATreeResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /coa/tree [get]
+func (h *Handler) GetTree(c *fiber.Ctx) error {
+	var q COATreeQuery
+	if err := c.QueryParser(&q); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	switch domain.AccountType(q.Type) {
+	case "", domain.AccountTypeAsset, domain.AccountTypeLiability, domain.AccountTypeEquity,
+		domain.AccountTypeRevenue, domain.AccountTypeExpense:
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account type")
+	}
+	if _, err := kindQuery(c); err != nil {
+		return err
+	}
+
+	tree, err := h.service.GetTree(&q)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get COA tree", tree)
+}
+
+// Export godoc
+// @Summary      Export the chart of accounts
+// @Description  Downloads the whole chart as CSV or XLSX with the columns code, name, type, parent, active and kind
+// @Tags         COA
+// @Produce      octet-stream
+// @Param        format  query  string  false "File format" Enums(csv, xlsx) default(csv)
+// @Success      200  {file}    file
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /coa/export [get]
+func (h *Handler) Export(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", FormatCSV))
+
+	data, err := h.service.Export(format)
+	if err != nil {
+		return err
+	}
+
+	contentType := "text/csv"
+	if format == FormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="chart-of-accounts.%s"`, format))
+
+	return c.Status(fiber.StatusOK).Send(data)
+}
+
+// Import godoc
+// @Summary      Import a chart of accounts
+// @Description  Uploads a CSV or XLSX chart (code, name, type, parent, active, kind). The file is validated as a whole tree; parents may appear after their children. Upsert adds and overwrites accounts; replace (admin only) also deletes accounts missing from the file.
+// @Tags         COA
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file    formData  file    true  "CSV or XLSX file"
+// @Param        mode    query     string  false "Import mode" Enums(upsert, replace) default(upsert)
+// @Param        format  query     string  false "File format, inferred from the file name when omitted" Enums(csv, xlsx)
+// @Param        dryRun  query     bool    false "Validate and report without writing"
+// @Success      200  {object}  SwaggerImportResponse
+// @Failure      400  {object}  SwaggerImportResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /coa/import [post]
+func (h *Handler) Import(c *fiber.Ctx) error {
+	var q ImportQuery
+	if err := c.QueryParser(&q); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if role, _ := c.Locals("role").(string); q.Mode == ImportModeReplace && role != "admin" {
+		return fiber.NewError(fiber.StatusForbidden, "Only admins can import in replace mode")
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "file is required")
+	}
+
+	format := strings.ToLower(q.Format)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Failed to read uploaded file")
+	}
+	defer src.Close()
+
+	rows, err := DecodeChart(src, format)
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.Import(rows, q.Mode, q.DryRun, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return utils.SuccessResponse(c, fiber.StatusBadRequest, "COA import validation failed", result)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "COA imported successfully", result)
+}
+
+// GetTemplates godoc
+// @Summary      List chart templates
+// @Description  Returns the bundled seed charts that can be applied to an empty chart of accounts
+// @Tags         COA
+// @Produce      json
+// @Success      200  {object}  model.SwaggerCOAListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /coa/templates [get]
+func (h *Handler) GetTemplates(c *fiber.Ctx) error {
+	templates, err := h.service.GetTemplates()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get COA templates", templates)
+}
+
+// ApplyTemplate godoc
+// @Summary      Apply a chart template
+// @Description  Seeds an empty chart of accounts from a bundled template (admin only)
+// @Tags         COA
+// @Produce      json
+// @Param        name  path  string  true  "Template name (e.g. sak-etap)"
+// @Success      201  {object}  SwaggerImportResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /coa/templates/{name}/apply [post]
+func (h *Handler) ApplyTemplate(c *fiber.Ctx) error {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.ApplyTemplate(c.Params("name"), audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return utils.SuccessResponse(c, fiber.StatusBadRequest, "COA template does not satisfy the hierarchy rules", result)
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, fmt.Sprintf("COA template %s applied successfully", c.Params("name")), result)
+}
+
 // GetByCode godoc
 // @Summary      Get COA by code
 // @Description  Returns a single Chart of Account by its code (e.g. "1-1001")
@@ -127,6 +333,38 @@ func (h *Handler) GetByCode(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, fmt.Sprintf("Success get COA %s", coa.Code), coa)
 }
 
+// GetHistory godoc
+// @Summary      Get COA change history
+// @Description  Returns the audit trail of a Chart of Account (newest first), including deleted accounts
+// @Tags         COA
+// @Produce      json
+// @Param        code   path   string  true  "COA Code (e.g. 1-1001)"
+// @Param        page   query  int     false "Page number"    minimum(1)
+// @Param        limit  query  int     false "Items per page" minimum(1) maximum(100)
+// @Success      200  {object}  model.SwaggerCOAListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /coa/{code}/history [get]
+func (h *Handler) GetHistory(c *fiber.Ctx) error {
+	var req model.PaginationRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 10
+	}
+
+	logs, meta, err := h.service.GetHistory(c.Params("code"), &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get COA history", logs, meta)
+}
+
 // Create godoc
 // @Summary      Create a new COA
 // @Description  Creates a new Chart of Account. The code becomes the unique identifier (primary key).
@@ -147,7 +385,12 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	coa, err := h.service.Create(&req)
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	coa, err := h.service.Create(&req, audit.ActorFromCtx(c), tx)
 	if err != nil {
 		return err
 	}
@@ -157,7 +400,7 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 
 // Update godoc
 // @Summary      Update a COA
-// @Description  Updates name, type, parentCode, or isActive of an existing COA by code
+// @Description  Updates name, type, parentCode, kind, or isActive of an existing COA by code
 // @Tags         COA
 // @Accept       json
 // @Produce      json
@@ -181,7 +424,12 @@ func (h *Handler) Update(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	coa, err := h.service.Update(code, &req)
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	coa, err := h.service.Update(code, &req, audit.ActorFromCtx(c), tx)
 	if err != nil {
 		return err
 	}
@@ -189,15 +437,94 @@ func (h *Handler) Update(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, fmt.Sprintf("COA %s updated successfully", coa.Code), coa)
 }
 
+// Renumber godoc
+// @Summary      Renumber a COA
+// @Description  Changes an account's code and cascades it to its children and journal lines in one transaction (admin only). Set preview to see the affected rows without writing.
+// @Tags         COA
+// @Accept       json
+// @Produce      json
+// @Param        code  path  string           true  "COA Code (e.g. 1-1001)"
+// @Param        body  body  RenumberRequest  true  "Renumber payload"
+// @Success      200  {object}  model.SwaggerCOAResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /coa/{code}/renumber [post]
+func (h *Handler) Renumber(c *fiber.Ctx) error {
+	var req RenumberRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.service.Renumber(c.Params("code"), &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("COA %s renumbered to %s", res.SourceCode, res.TargetCode)
+	if res.Preview {
+		message = "Renumber preview"
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, message, res)
+}
+
+// Merge godoc
+// @Summary      Merge a COA into another
+// @Description  Moves all journal lines and children of an account into the target account, then retires the source (admin only). Refused when the source has draft lines, templates, allocation rules or subledger documents and its dimension rules differ from the target's. Set preview to see the affected rows without writing.
+// @Tags         COA
+// @Accept       json
+// @Produce      json
+// @Param        code  path  string        true  "Source COA Code (e.g. 1-1001)"
+// @Param        body  body  MergeRequest  true  "Merge payload"
+// @Success      200  {object}  model.SwaggerCOAResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /coa/{code}/merge [post]
+func (h *Handler) Merge(c *fiber.Ctx) error {
+	var req MergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.service.Merge(c.Params("code"), &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("COA %s merged into %s", res.SourceCode, res.TargetCode)
+	if res.Preview {
+		message = "Merge preview"
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, message, res)
+}
+
 // Delete godoc
 // @Summary      Delete a COA
-// @Description  Soft-deletes a Chart of Account by code
+// @Description  Soft-deletes a Chart of Account by code. Accounts with journal lines or a balance cannot be deleted (deactivate them instead); accounts with children need force, which re-parents the children (admin only).
 // @Tags         COA
 // @Produce      json
-// @Param        code  path  string  true  "COA Code (e.g. 1-1001)"
+// @Param        code   path   string  true  "COA Code (e.g. 1-1001)"
+// @Param        force  query  bool    false "Re-parent children to this account's parent (admin only)"
 // @Success      200  {object}  model.SwaggerEmptyResponse
 // @Failure      400  {object}  model.SwaggerErrorResponse
 // @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
 // @Failure      404  {object}  model.SwaggerErrorResponse
 // @Failure      500  {object}  model.SwaggerErrorResponse
 // @Security     CookieAuth
@@ -208,9 +535,30 @@ func (h *Handler) Delete(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "COA code is required")
 	}
 
-	if err := h.service.Delete(code); err != nil {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	force := c.QueryBool("force")
+	if role, _ := c.Locals("role").(string); force && role != "admin" {
+		return fiber.NewError(fiber.StatusForbidden, "Only admins can force-delete a COA")
+	}
+
+	if err := h.service.Delete(code, force, audit.ActorFromCtx(c), tx); err != nil {
 		return err
 	}
 
 	return utils.SuccessResponse[any](c, fiber.StatusOK, "COA deleted successfully", nil)
 }
+
+// kindQuery reads the optional ?kind= filter shared by the list endpoints.
+func kindQuery(c *fiber.Ctx) (string, error) {
+	kind := c.Query("kind")
+	switch domain.AccountKind(kind) {
+	case "", domain.AccountKindHeader, domain.AccountKindPostable:
+		return kind, nil
+	default:
+		return "", fiber.NewError(fiber.StatusBadRequest, "Invalid account kind")
+	}
+}
@@ -0,0 +1,148 @@
+package coa
+
+import (
+	"fmt"
+	"strings"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// validateHierarchy checks that coa, placed under its ParentCode, keeps the
+// chart a well-formed forest: no cycles, only header parents, one account
+// type per tree, a bounded depth and, when COA_ENFORCE_CODE_PREFIX is set,
+// codes nested under their parent's prefix. coa may not exist yet (create)
+// or already carry a subtree (update), in which case the whole subtree is
+// checked at its new position.
+func validateHierarchy(repo Repository, coa *domain.ChartOfAccount) error {
+	rootCode, rootType := coa.Code, coa.Type
+	depth := 1
+
+	if coa.ParentCode != nil {
+		ancestors, err := repo.FindAncestors(*coa.ParentCode)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if len(ancestors) == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Parent COA code does not exist")
+		}
+
+		for _, a := range ancestors {
+			if a.Code == coa.Code {
+				return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+					"Cannot move COA %s under %s: %s is one of its descendants, which would create a cycle",
+					coa.Code, *coa.ParentCode, *coa.ParentCode,
+				))
+			}
+		}
+
+		if parent := ancestors[0]; parent.Kind != domain.AccountKindHeader {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+				"Parent COA %s is a postable account and cannot have children; convert it to a header first",
+				parent.Code,
+			))
+		}
+
+		root := ancestors[len(ancestors)-1]
+		rootCode, rootType = root.Code, root.Type
+		depth = len(ancestors) + 1
+
+		if config.AppConfig.COAEnforceCodePrefix {
+			prefix := codePrefix(*coa.ParentCode)
+			if !strings.HasPrefix(coa.Code, prefix) || coa.Code == *coa.ParentCode {
+				return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+					"COA code %s must start with %s to be placed under %s",
+					coa.Code, prefix, *coa.ParentCode,
+				))
+			}
+		}
+	}
+
+	if coa.Type != rootType {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA type %s does not match type %s of root account %s",
+			coa.Type, rootType, rootCode,
+		))
+	}
+
+	subtree, err := repo.FindTree(&COATreeQuery{Root: coa.Code})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	height := 0
+	for _, node := range subtree {
+		if node.Depth == 0 {
+			continue
+		}
+		if node.Depth > height {
+			height = node.Depth
+		}
+		if domain.AccountType(node.Type) != rootType {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+				"Descendant COA %s has type %s, which conflicts with type %s of root account %s",
+				node.Code, node.Type, rootType, rootCode,
+			))
+		}
+	}
+
+	if maxDepth := config.AppConfig.COAMaxDepth; maxDepth > 0 && depth+height > maxDepth {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA hierarchy cannot be deeper than %d levels (this change would reach %d)",
+			maxDepth, depth+height,
+		))
+	}
+
+	return nil
+}
+
+// codePrefix derives the prefix required of a parent's children by dropping
+// its trailing zeros, e.g. 1-1000 -> 1-1 and 1-0000 -> 1-.
+func codePrefix(parentCode string) string {
+	return strings.TrimRight(parentCode, "0")
+}
+
+func sameParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func validKind(kind domain.AccountKind) bool {
+	return kind == domain.AccountKindHeader || kind == domain.AccountKindPostable
+}
+
+// checkKindChange blocks conversions that would contradict existing data: a
+// header with children cannot become postable, and a postable account that
+// already carries journal lines cannot become a header.
+func checkKindChange(repo Repository, code string, kind domain.AccountKind) error {
+	switch kind {
+	case domain.AccountKindPostable:
+		children, err := repo.FindChildren(code)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if len(children) > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+				"COA %s cannot become postable: it has %d child accounts", code, len(children),
+			))
+		}
+	case domain.AccountKindHeader:
+		usage, err := repo.GetUsage(code)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if usage.DraftLines > 0 || usage.PostedLines > 0 {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+				"COA %s cannot become a header: it has %d posted and %d draft journal lines",
+				code, usage.PostedLines, usage.DraftLines,
+			))
+		}
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account kind")
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+package coa
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// ImportModeUpsert creates new codes and overwrites existing ones, leaving
+	// accounts absent from the file untouched.
+	ImportModeUpsert = "upsert"
+	// ImportModeReplace makes the file the whole chart: accounts absent from
+	// it are deleted, which is refused for accounts with journal lines.
+	ImportModeReplace = "replace"
+)
+
+type importPlan struct {
+	writes  []domain.ChartOfAccount // parents before children
+	deletes []domain.ChartOfAccount
+}
+
+// planImport validates rows as one complete tree, together with whatever
+// remains of the current chart in upsert mode. Rows may list children before
+// their parents. Problems are collected rather than returned one by one.
+func planImport(rows []ImportRow, existing []domain.ChartOfAccount, usedCodes map[string]bool, referenced map[string]string, mode string) (*importPlan, []ImportError) {
+	var errs []ImportError
+	fail := func(line int, code, format string, args ...any) {
+		errs = append(errs, ImportError{Line: line, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	final := map[string]*domain.ChartOfAccount{}
+	lines := map[string]int{}
+	if mode == ImportModeUpsert {
+		for _, a := range existing {
+			a := a
+			final[a.Code] = &a
+		}
+	}
+
+	blankKind := map[string]bool{}
+	for _, row := range rows {
+		if row.Code == "" {
+			fail(row.Line, "", "code is required")
+			continue
+		}
+		if prev, dup := lines[row.Code]; dup {
+			fail(row.Line, row.Code, "duplicate code, already defined on line %d", prev)
+			continue
+		}
+		lines[row.Code] = row.Line
+
+		if row.Name == "" {
+			fail(row.Line, row.Code, "name is required")
+		}
+		accountType := domain.AccountType(row.Type)
+		switch accountType {
+		case domain.AccountTypeAsset, domain.AccountTypeLiability, domain.AccountTypeEquity,
+			domain.AccountTypeRevenue, domain.AccountTypeExpense:
+		default:
+			fail(row.Line, row.Code, "invalid type %q", row.Type)
+		}
+
+		active := true
+		if row.Active != "" {
+			parsed, err := strconv.ParseBool(row.Active)
+			if err != nil {
+				fail(row.Line, row.Code, "invalid active value %q", row.Active)
+			}
+			active = parsed
+		}
+
+		kind := domain.AccountKind(row.Kind)
+		if kind == "" {
+			blankKind[row.Code] = true
+		} else if !validKind(kind) {
+			fail(row.Line, row.Code, "invalid kind %q", row.Kind)
+		}
+
+		var parent *string
+		if row.Parent != "" {
+			p := row.Parent
+			parent = &p
+		}
+
+		final[row.Code] = &domain.ChartOfAccount{
+			Code:       row.Code,
+			Name:       row.Name,
+			Type:       accountType,
+			ParentCode: parent,
+			Kind:       kind,
+			IsActive:   active,
+		}
+	}
+
+	hasChildren := map[string]bool{}
+	for _, a := range final {
+		if a.ParentCode != nil {
+			hasChildren[*a.ParentCode] = true
+		}
+	}
+	for code := range blankKind {
+		final[code].Kind = domain.AccountKindPostable
+		if hasChildren[code] {
+			final[code].Kind = domain.AccountKindHeader
+		}
+	}
+
+	codes := make([]string, 0, len(final))
+	for code := range final {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	current := make(map[string]domain.ChartOfAccount, len(existing))
+	for _, a := range existing {
+		current[a.Code] = a
+	}
+	controls := controlAccounts()
+
+	depths := make(map[string]int, len(final))
+	for _, code := range codes {
+		a := final[code]
+		line := lines[code]
+
+		depth, root, broken := 1, a, false
+		seen := map[string]bool{code: true}
+		for node := a; node.ParentCode != nil; depth++ {
+			parent, ok := final[*node.ParentCode]
+			if !ok {
+				fail(line, code, "parent %s does not exist", *node.ParentCode)
+				broken = true
+				break
+			}
+			if seen[parent.Code] {
+				fail(line, code, "parent chain loops back to %s", parent.Code)
+				broken = true
+				break
+			}
+			seen[parent.Code] = true
+			node, root = parent, parent
+		}
+		if broken {
+			continue
+		}
+		depths[code] = depth
+
+		if a.ParentCode != nil {
+			if parent := final[*a.ParentCode]; parent.Kind != domain.AccountKindHeader {
+				fail(line, code, "parent %s is a postable account and cannot have children", parent.Code)
+			}
+			if config.AppConfig.COAEnforceCodePrefix {
+				prefix := codePrefix(*a.ParentCode)
+				if !strings.HasPrefix(code, prefix) || code == *a.ParentCode {
+					fail(line, code, "code must start with %s to be placed under %s", prefix, *a.ParentCode)
+				}
+			}
+		}
+		if a.Type != root.Type {
+			fail(line, code, "type %s does not match type %s of root account %s", a.Type, root.Type, root.Code)
+		}
+		if maxDepth := config.AppConfig.COAMaxDepth; maxDepth > 0 && depth > maxDepth {
+			fail(line, code, "hierarchy cannot be deeper than %d levels (account is at level %d)", maxDepth, depth)
+		}
+		if a.Kind == domain.AccountKindHeader && usedCodes[code] {
+			fail(line, code, "account has journal lines and cannot be a header")
+		}
+		if setting, ok := controls[code]; ok {
+			if prev, ok := current[code]; ok {
+				switch {
+				case prev.IsActive && !a.IsActive:
+					fail(line, code, "%s", controlAccountMessage(code, setting, "deactivated"))
+				case a.Type != prev.Type:
+					fail(line, code, "%s", controlAccountMessage(code, setting, "given another type"))
+				case a.Kind != prev.Kind:
+					fail(line, code, "%s", controlAccountMessage(code, setting, "made a "+string(a.Kind)+" account"))
+				}
+			}
+		}
+	}
+
+	plan := &importPlan{}
+	if mode == ImportModeReplace {
+		for _, a := range existing {
+			if _, kept := final[a.Code]; kept {
+				continue
+			}
+			if usedCodes[a.Code] {
+				fail(0, a.Code, "account is not in the file but has journal lines, so replace cannot delete it")
+				continue
+			}
+			if usedBy, ok := referenced[a.Code]; ok {
+				fail(0, a.Code, "account is not in the file but is used by %s, so replace cannot delete it", usedBy)
+				continue
+			}
+			plan.deletes = append(plan.deletes, a)
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+		return nil, errs
+	}
+
+	for code := range lines {
+		plan.writes = append(plan.writes, *final[code])
+	}
+	sort.Slice(plan.writes, func(i, j int) bool {
+		a, b := plan.writes[i], plan.writes[j]
+		if depths[a.Code] != depths[b.Code] {
+			return depths[a.Code] < depths[b.Code]
+		}
+		return a.Code < b.Code
+	})
+
+	return plan, nil
+}
+
+// importChart validates rows and, unless dryRun, writes them through tx with
+// one audit entry per changed account.
+func importChart(tx *gorm.DB, rows []ImportRow, mode string, dryRun bool, actor audit.Actor) (*ImportResult, error) {
+	if mode == "" {
+		mode = ImportModeUpsert
+	}
+	if mode != ImportModeUpsert && mode != ImportModeReplace {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "mode must be upsert or replace")
+	}
+
+	txRepo := NewRepository(tx)
+
+	existing, err := txRepo.FindAllNoPagination("")
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	used, err := txRepo.FindUsedCodes()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	usedCodes := make(map[string]bool, len(used))
+	for _, code := range used {
+		usedCodes[code] = true
+	}
+	refs, err := txRepo.FindReferencedCodes()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	referenced := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		if _, seen := referenced[ref.Code]; !seen {
+			referenced[ref.Code] = ref.UsedBy
+		}
+	}
+	for code, setting := range controlAccounts() {
+		referenced[code] = setting
+	}
+
+	result := &ImportResult{Mode: mode, DryRun: dryRun, Total: len(rows)}
+
+	plan, errs := planImport(rows, existing, usedCodes, referenced, mode)
+	if len(errs) > 0 {
+		result.Errors = errs
+		return result, nil
+	}
+
+	current := make(map[string]*COAResponse, len(existing))
+	for i := range existing {
+		current[existing[i].Code] = toResponse(&existing[i])
+	}
+
+	for _, a := range plan.writes {
+		before, exists := current[a.Code]
+		after := toResponse(&a)
+
+		switch {
+		case !exists:
+			result.Created++
+		case sameAccount(before, after):
+			result.Unchanged++
+			continue
+		default:
+			result.Updated++
+		}
+		if dryRun {
+			continue
+		}
+
+		if err := txRepo.Upsert(&a); err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if exists {
+			err = record(tx, actor, a.Code, audit.ActionUpdate, before, after)
+		} else {
+			err = record(tx, actor, a.Code, audit.ActionCreate, nil, after)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Deleted = len(plan.deletes)
+	if dryRun {
+		return result, nil
+	}
+	for _, a := range plan.deletes {
+		if err := txRepo.Delete(a.Code); err != nil {
+			return nil, err
+		}
+		if err := record(tx, actor, a.Code, audit.ActionDelete, current[a.Code], nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func sameAccount(a, b *COAResponse) bool {
+	return a.Name == b.Name && a.Type == b.Type && a.Kind == b.Kind &&
+		a.IsActive == b.IsActive && sameParent(a.ParentCode, b.ParentCode)
+}
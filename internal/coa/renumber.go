@@ -0,0 +1,322 @@
+package coa
+
+import (
+	"fmt"
+	"strings"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// previewCodeChange collects the children, journal lines, allocation rules,
+// journal templates and subledger documents that follow an account when its
+// code is renumbered or merged away.
+func previewCodeChange(repo Repository, operation string, source *domain.ChartOfAccount, targetCode string) (*CodeChangeResponse, []domain.ChartOfAccount, error) {
+	children, err := repo.FindChildren(source.Code)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	usage, err := repo.GetUsage(source.Code)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	preview := &CodeChangeResponse{
+		Operation:   operation,
+		SourceCode:  source.Code,
+		TargetCode:  targetCode,
+		Children:    make([]string, len(children)),
+		DraftLines:  usage.DraftLines,
+		PostedLines: usage.PostedLines,
+		Balance:     usage.Balance,
+
+		AllocationRules:  usage.AllocationRules,
+		JournalTemplates: usage.JournalTemplates,
+		Documents:        usage.Documents,
+	}
+	for i, c := range children {
+		preview.Children[i] = c.Code
+	}
+	return preview, children, nil
+}
+
+// Renumber changes an account's code and cascades it to its children, every
+// journal line booked to it, and the allocation rules, journal templates and
+// subledger documents that use it. Subledger control accounts cannot be
+// renumbered while configured.
+func (s *service) Renumber(code string, req *RenumberRequest, actor audit.Actor, tx *gorm.DB) (*CodeChangeResponse, error) {
+	txRepo := NewRepository(tx)
+
+	newCode := strings.TrimSpace(req.NewCode)
+	if newCode == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "newCode is required")
+	}
+	if newCode == code {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "newCode must differ from the current code")
+	}
+
+	existing, err := txRepo.FindByCode(code)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if existing == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "COA not found")
+	}
+	if err := checkControlAccount(code, "renumbered"); err != nil {
+		return nil, err
+	}
+
+	taken, err := txRepo.CodeTaken(newCode)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if taken {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("COA code %s is already in use (possibly by a deleted account)", newCode))
+	}
+
+	if config.AppConfig.COAEnforceCodePrefix && existing.ParentCode != nil {
+		if prefix := codePrefix(*existing.ParentCode); !strings.HasPrefix(newCode, prefix) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+				"COA code %s must start with %s to stay under %s", newCode, prefix, *existing.ParentCode,
+			))
+		}
+	}
+
+	preview, children, err := previewCodeChange(txRepo, "renumber", existing, newCode)
+	if err != nil {
+		return nil, err
+	}
+	if req.Preview {
+		preview.Preview = true
+		return preview, nil
+	}
+
+	if err := txRepo.ChangeCode(code, newCode); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveChildren(code, newCode); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveDetailLines(code, newCode); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveDimensionRules(code, newCode); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveAllocationRules(code, newCode); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveTemplateLines(code, newCode); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveDocumentCodes(code, newCode); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	before := toResponse(existing)
+	existing.Code = newCode
+	after := toResponse(existing)
+
+	for _, entityID := range []string{code, newCode} {
+		if err := record(tx, actor, entityID, audit.ActionRenumber, before, after); err != nil {
+			return nil, err
+		}
+	}
+	if err := recordReparent(tx, actor, children, newCode); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// Merge moves every posting, child, allocation rule, template line and
+// subledger document of the account at code into the target account, then
+// retires (soft-deletes) the source. Subledger control accounts can be
+// neither side of a merge, and both sides must share their dimension rules
+// while anything still to be posted uses the source.
+func (s *service) Merge(code string, req *MergeRequest, actor audit.Actor, tx *gorm.DB) (*CodeChangeResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if req.TargetCode == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "targetCode is required")
+	}
+	if req.TargetCode == code {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "An account cannot be merged into itself")
+	}
+
+	source, err := txRepo.FindByCode(code)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if source == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "COA not found")
+	}
+	target, err := txRepo.FindByCode(req.TargetCode)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if target == nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Target COA code does not exist")
+	}
+	if err := checkControlAccount(source.Code, "merged away"); err != nil {
+		return nil, err
+	}
+	if err := checkControlAccount(target.Code, "a merge target"); err != nil {
+		return nil, err
+	}
+
+	if target.Type != source.Type {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"Cannot merge %s account %s into %s account %s", source.Type, source.Code, target.Type, target.Code,
+		))
+	}
+	if !target.IsActive {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Target COA is inactive")
+	}
+
+	preview, children, err := previewCodeChange(txRepo, "merge", source, target.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if (preview.DraftLines > 0 || preview.PostedLines > 0 || preview.AllocationRules > 0 || preview.JournalTemplates > 0 || preview.Documents > 0) && target.Kind != domain.AccountKindPostable {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA %s has journal lines, allocation rules, journal templates or subledger documents, so its target must be a postable account", source.Code,
+		))
+	}
+	if len(children) > 0 {
+		if err := checkMergeChildren(txRepo, source, target); err != nil {
+			return nil, err
+		}
+	}
+	if preview.DraftLines > 0 || preview.AllocationRules > 0 || preview.JournalTemplates > 0 || preview.Documents > 0 {
+		if err := checkMergeDimensions(txRepo, source, target); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Preview {
+		preview.Preview = true
+		return preview, nil
+	}
+
+	if err := txRepo.MoveDetailLines(source.Code, target.Code); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveChildren(source.Code, target.Code); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveAllocationRules(source.Code, target.Code); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveTemplateLines(source.Code, target.Code); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.MoveDocumentCodes(source.Code, target.Code); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := recordReparent(tx, actor, children, target.Code); err != nil {
+		return nil, err
+	}
+
+	before := toResponse(source)
+	source.IsActive = false
+	if err := txRepo.Update(source); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.Delete(source.Code); err != nil {
+		return nil, err
+	}
+
+	if err := record(tx, actor, source.Code, audit.ActionMerge, before, preview); err != nil {
+		return nil, err
+	}
+	if err := record(tx, actor, target.Code, audit.ActionMerge, nil, preview); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// checkMergeChildren applies the hierarchy rules to source's subtree as it
+// would sit once re-hung under target.
+func checkMergeChildren(repo Repository, source, target *domain.ChartOfAccount) error {
+	if target.Kind != domain.AccountKindHeader {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA %s has child accounts, so its target must be a header account", source.Code,
+		))
+	}
+
+	ancestors, err := repo.FindAncestors(target.Code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	for _, a := range ancestors {
+		if a.Code == source.Code {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+				"Cannot merge %s into its own descendant %s", source.Code, target.Code,
+			))
+		}
+	}
+
+	subtree, err := repo.FindTree(&COATreeQuery{Root: source.Code})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	height := 0
+	for _, node := range subtree {
+		if node.Depth > height {
+			height = node.Depth
+		}
+	}
+
+	if maxDepth := config.AppConfig.COAMaxDepth; maxDepth > 0 && len(ancestors)+height > maxDepth {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA hierarchy cannot be deeper than %d levels (this merge would reach %d)",
+			maxDepth, len(ancestors)+height,
+		))
+	}
+	return nil
+}
+
+// checkMergeDimensions requires source and target to share their dimension
+// rules. Draft lines, and the journals that templates, allocation rules and
+// subledger documents will generate, are tagged for the source's rules and
+// would be rejected at posting under different ones.
+func checkMergeDimensions(repo Repository, source, target *domain.ChartOfAccount) error {
+	sourceRules, err := repo.FindDimensionRules(source.Code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	targetRules, err := repo.FindDimensionRules(target.Code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	same := len(sourceRules) == len(targetRules)
+	for i := 0; same && i < len(sourceRules); i++ {
+		same = sourceRules[i].DimensionCode == targetRules[i].DimensionCode && sourceRules[i].Required == targetRules[i].Required
+	}
+	if !same {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA %s and %s have different dimension rules; give them the same rules before merging so the source's draft lines stay postable",
+			source.Code, target.Code,
+		))
+	}
+	return nil
+}
+
+func recordReparent(tx *gorm.DB, actor audit.Actor, children []domain.ChartOfAccount, parentCode string) error {
+	for _, child := range children {
+		before := toResponse(&child)
+		child.ParentCode = &parentCode
+		if err := record(tx, actor, child.Code, audit.ActionUpdate, before, toResponse(&child)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package coa
 
 import (
+	"database/sql"
+
 	"fiber.com/session-api/internal/domain"
 	"fiber.com/session-api/pkg/model"
 
@@ -9,11 +11,27 @@ import (
 )
 
 type Repository interface {
-	FindAll(req *model.PaginationRequest) ([]domain.ChartOfAccount, int64, error)
-	FindAllNoPagination() ([]domain.ChartOfAccount, error)
-	FindAllWithChildren(req *model.PaginationRequest) ([]CoaReqursiveResponse, int64, error)
+	FindAll(req *model.PaginationRequest, kind string) ([]domain.ChartOfAccount, int64, error)
+	FindAllNoPagination(kind string) ([]domain.ChartOfAccount, error)
+	FindAllWithChildren(req *model.PaginationRequest, kind string) ([]CoaReqursiveResponse, int64, error)
+	FindTree(q *COATreeQuery) ([]COATreeRow, error)
 	FindByCode(code string) (*domain.ChartOfAccount, error)
+	FindAncestors(code string) ([]domain.ChartOfAccount, error)
+	FindChildren(code string) ([]domain.ChartOfAccount, error)
+	GetUsage(code string) (*COAUsage, error)
+	FindUsedCodes() ([]string, error)
+	FindReferencedCodes() ([]CodeReference, error)
 	Create(coa *domain.ChartOfAccount) error
+	Upsert(coa *domain.ChartOfAccount) error
+	CodeTaken(code string) (bool, error)
+	ChangeCode(oldCode, newCode string) error
+	MoveChildren(fromCode, toCode string) error
+	MoveDetailLines(fromCode, toCode string) error
+	MoveDimensionRules(fromCode, toCode string) error
+	FindDimensionRules(code string) ([]domain.AccountDimension, error)
+	MoveAllocationRules(fromCode, toCode string) error
+	MoveTemplateLines(fromCode, toCode string) error
+	MoveDocumentCodes(fromCode, toCode string) error
 	Update(coa *domain.ChartOfAccount) error
 	Delete(code string) error
 }
@@ -26,32 +44,51 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) FindAll(req *model.PaginationRequest) ([]domain.ChartOfAccount, int64, error) {
+// Migrate backfills the account kind for charts created before kinds existed:
+// accounts that already have children and no journal lines become headers.
+// It is idempotent and must run after AutoMigrate has added the column.
+func Migrate(db *gorm.DB) error {
+	return db.Exec(`
+		UPDATE chart_of_accounts p SET kind = 'header'
+		WHERE p.kind = 'postable'
+		AND p.deleted_at IS NULL
+		AND EXISTS (
+			SELECT 1 FROM chart_of_accounts c
+			WHERE c.parent_code = p.code AND c.deleted_at IS NULL
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM journal_entry_details jd
+			WHERE jd.coa_code = p.code AND jd.deleted_at IS NULL
+		)
+	`).Error
+}
+
+func (r *repository) FindAll(req *model.PaginationRequest, kind string) ([]domain.ChartOfAccount, int64, error) {
 	var accounts []domain.ChartOfAccount
 	var total int64
 	offset := (req.Page - 1) * req.Limit
 	search := "%" + req.Search + "%"
 
-	countQuery := `SELECT COUNT(*) FROM chart_of_accounts WHERE deleted_at IS NULL AND (name ILIKE ? OR code ILIKE ?)`
-	if err := r.db.Raw(countQuery, search, search).Scan(&total).Error; err != nil {
+	countQuery := `SELECT COUNT(*) FROM chart_of_accounts WHERE deleted_at IS NULL AND (name ILIKE ? OR code ILIKE ?) AND (? = '' OR kind = ?)`
+	if err := r.db.Raw(countQuery, search, search, kind, kind).Scan(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	dataQuery := `
-		SELECT code, name, type, parent_code, is_active, created_at, updated_at
+		SELECT code, name, type, parent_code, kind, is_active, created_at, updated_at
 		FROM chart_of_accounts
-		WHERE deleted_at IS NULL AND (name ILIKE ? OR code ILIKE ?)
+		WHERE deleted_at IS NULL AND (name ILIKE ? OR code ILIKE ?) AND (? = '' OR kind = ?)
 		ORDER BY code ASC
 		LIMIT ? OFFSET ?`
 
-	if err := r.db.Raw(dataQuery, search, search, req.Limit, offset).Scan(&accounts).Error; err != nil {
+	if err := r.db.Raw(dataQuery, search, search, kind, kind, req.Limit, offset).Scan(&accounts).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return accounts, total, nil
 }
 
-func (r *repository) FindAllNoPagination() ([]domain.ChartOfAccount, error) {
+func (r *repository) FindAllNoPagination(kind string) ([]domain.ChartOfAccount, error) {
 	var accounts []domain.ChartOfAccount
 
 	dataQuery := `
@@ -60,22 +97,24 @@ func (r *repository) FindAllNoPagination() ([]domain.ChartOfAccount, error) {
 			name,
 			type,
 			parent_code,
+			kind,
 			is_active,
 			created_at,
 			updated_at
 		FROM chart_of_accounts
 		WHERE deleted_at IS NULL
+		AND (? = '' OR kind = ?)
 		ORDER BY code ASC
 	`
 
-	if err := r.db.Raw(dataQuery).Scan(&accounts).Error; err != nil {
+	if err := r.db.Raw(dataQuery, kind, kind).Scan(&accounts).Error; err != nil {
 		return nil, err
 	}
 
 	return accounts, nil
 }
 
-func (r *repository) FindAllWithChildren(req *model.PaginationRequest) ([]CoaReqursiveResponse, int64, error) {
+func (r *repository) FindAllWithChildren(req *model.PaginationRequest, kind string) ([]CoaReqursiveResponse, int64, error) {
 	var accounts []CoaReqursiveResponse
 	var total int64
 	offset := (req.Page - 1) * req.Limit
@@ -93,6 +132,18 @@ func (r *repository) FindAllWithChildren(req *model.PaginationRequest) ([]CoaReq
 		args = append(args, search, search)
 	}
 
+	var childFilter string
+	var childArgs []any
+	if kind != "" {
+		query += `
+            and coa.kind = ?
+        `
+		args = append(args, kind)
+		childFilter = `
+            and coa2.kind = ?`
+		childArgs = append(childArgs, kind)
+	}
+
 	countQuery := `
         SELECT COUNT(*)
         from chart_of_accounts coa
@@ -116,6 +167,7 @@ func (r *repository) FindAllWithChildren(req *model.PaginationRequest) ([]CoaReq
                         'code', coa2.code,
                         'name', coa2."name",
                         'type', coa2."type",
+                        'kind', coa2.kind,
                         'parentCode', coa2.parent_code
                     )
                 ) FILTER (WHERE coa2.code is not null),
@@ -124,7 +176,7 @@ func (r *repository) FindAllWithChildren(req *model.PaginationRequest) ([]CoaReq
         FROM chart_of_accounts coa
         LEFT JOIN chart_of_accounts coa2
             on coa2.parent_code = coa.code
-            and coa2.is_active = true
+            and coa2.is_active = true` + childFilter + `
         WHERE coa.parent_code is null
         AND coa.is_active = true
         ` + query + `
@@ -136,19 +188,104 @@ func (r *repository) FindAllWithChildren(req *model.PaginationRequest) ([]CoaReq
         LIMIT ?
         OFFSET ?
 	`
-	args = append(args, req.Limit, offset)
+	dataArgs := append(append(childArgs, args...), req.Limit, offset)
 
-	if err := r.db.Raw(dataQuery, args...).Scan(&accounts).Error; err != nil {
+	if err := r.db.Raw(dataQuery, dataArgs...).Scan(&accounts).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return accounts, total, nil
 }
 
+// FindTree walks the hierarchy with a recursive CTE and returns every reachable
+// node flattened in path order. Depth is relative to the starting nodes (the
+// roots, or q.Root when given); the path guard stops the walk on cycles.
+func (r *repository) FindTree(q *COATreeQuery) ([]COATreeRow, error) {
+	var rows []COATreeRow
+	var filter string
+	var args []any
+
+	if q.Type != "" {
+		filter += ` AND coa."type" = ?`
+		args = append(args, q.Type)
+	}
+	if q.Kind != "" {
+		filter += ` AND coa.kind = ?`
+		args = append(args, q.Kind)
+	}
+	if q.IsActive != nil {
+		filter += ` AND coa.is_active = ?`
+		args = append(args, *q.IsActive)
+	}
+
+	start := `coa.parent_code IS NULL`
+	var startArgs []any
+	if q.Root != "" {
+		start = `coa.code = ?`
+		startArgs = append(startArgs, q.Root)
+	}
+
+	dataQuery := `
+		WITH RECURSIVE tree AS (
+			SELECT
+				coa.code,
+				coa."name",
+				coa."type",
+				coa.parent_code,
+				coa.kind,
+				coa.is_active,
+				0 AS depth,
+				ARRAY[coa.code]::varchar[] AS path
+			FROM chart_of_accounts coa
+			WHERE coa.deleted_at IS NULL
+			AND ` + start + filter + `
+
+			UNION ALL
+
+			SELECT
+				coa.code,
+				coa."name",
+				coa."type",
+				coa.parent_code,
+				coa.kind,
+				coa.is_active,
+				tree.depth + 1,
+				tree.path || coa.code
+			FROM chart_of_accounts coa
+			JOIN tree ON coa.parent_code = tree.code
+			WHERE coa.deleted_at IS NULL
+			AND NOT coa.code = ANY(tree.path)` + filter + `
+		)
+		SELECT
+			tree.code,
+			tree."name",
+			tree."type",
+			tree.parent_code,
+			tree.kind,
+			tree.is_active,
+			tree.depth,
+			array_to_string(tree.path, '/') AS path,
+			NOT EXISTS (
+				SELECT 1 FROM chart_of_accounts child
+				WHERE child.parent_code = tree.code
+				AND child.deleted_at IS NULL
+			) AS is_leaf
+		FROM tree
+		ORDER BY tree.path ASC
+	`
+
+	allArgs := append(append(startArgs, args...), args...)
+	if err := r.db.Raw(dataQuery, allArgs...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
 func (r *repository) FindByCode(code string) (*domain.ChartOfAccount, error) {
 	var coa domain.ChartOfAccount
 	result := r.db.Raw(
-		`SELECT code, name, type, parent_code, is_active, created_at, updated_at
+		`SELECT code, name, type, parent_code, kind, is_active, created_at, updated_at
 		 FROM chart_of_accounts WHERE code = ? AND deleted_at IS NULL LIMIT 1`,
 		code,
 	).Scan(&coa)
@@ -162,23 +299,279 @@ func (r *repository) FindByCode(code string) (*domain.ChartOfAccount, error) {
 	return &coa, nil
 }
 
+// FindAncestors returns code and its ancestors, nearest first, up to the root.
+// The path guard stops the walk if the stored hierarchy already has a cycle.
+func (r *repository) FindAncestors(code string) ([]domain.ChartOfAccount, error) {
+	var accounts []domain.ChartOfAccount
+
+	dataQuery := `
+		WITH RECURSIVE ancestors AS (
+			SELECT code, name, type, parent_code, kind, is_active, 1 AS lvl, ARRAY[code]::varchar[] AS path
+			FROM chart_of_accounts
+			WHERE code = ? AND deleted_at IS NULL
+
+			UNION ALL
+
+			SELECT p.code, p.name, p.type, p.parent_code, p.kind, p.is_active, a.lvl + 1, a.path || p.code
+			FROM chart_of_accounts p
+			JOIN ancestors a ON p.code = a.parent_code
+			WHERE p.deleted_at IS NULL
+			AND NOT p.code = ANY(a.path)
+		)
+		SELECT code, name, type, parent_code, kind, is_active
+		FROM ancestors
+		ORDER BY lvl ASC
+	`
+
+	if err := r.db.Raw(dataQuery, code).Scan(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+func (r *repository) FindChildren(code string) ([]domain.ChartOfAccount, error) {
+	var accounts []domain.ChartOfAccount
+	err := r.db.Raw(
+		`SELECT code, name, type, parent_code, kind, is_active, created_at, updated_at
+		 FROM chart_of_accounts
+		 WHERE parent_code = ? AND deleted_at IS NULL
+		 ORDER BY code ASC`,
+		code,
+	).Scan(&accounts).Error
+	return accounts, err
+}
+
+// GetUsage counts the journal lines booked against code on live (not deleted)
+// entries, the net debit-minus-credit balance of the posted ones, the live
+// allocation rules and journal templates that read or post to it, and the
+// subledger document lines booked to it.
+func (r *repository) GetUsage(code string) (*COAUsage, error) {
+	var usage COAUsage
+	err := r.db.Raw(
+		`SELECT
+			COUNT(*) FILTER (WHERE je.status = 'draft') AS draft_lines,
+			COUNT(*) FILTER (WHERE je.status = 'posted') AS posted_lines,
+			COALESCE(SUM(jd.debit - jd.credit) FILTER (WHERE je.status = 'posted'), 0) AS balance,
+			(SELECT COUNT(*) FROM allocation_rules ar
+			 WHERE ar.deleted_at IS NULL
+			 AND (ar.source_coa_code = @code OR ar.basis_coa_code = @code OR EXISTS (
+				SELECT 1 FROM allocation_targets t
+				WHERE t.rule_id = ar.id AND (t.coa_code = @code OR t.basis_coa_code = @code)
+			 ))) AS allocation_rules,
+			(SELECT COUNT(*) FROM journal_templates jt
+			 WHERE jt.deleted_at IS NULL
+			 AND jt.lines @> jsonb_build_array(jsonb_build_object('coaCode', CAST(@code AS text)))
+			) AS journal_templates,
+			(SELECT COUNT(*) FROM sales_invoice_lines WHERE coa_code = @code)
+			+ (SELECT COUNT(*) FROM receipts WHERE bank_coa_code = @code)
+			+ (SELECT COUNT(*) FROM purchase_bill_lines WHERE coa_code = @code)
+			+ (SELECT COUNT(*) FROM payments WHERE bank_coa_code = @code) AS documents
+		 FROM journal_entry_details jd
+		 JOIN journal_entries je ON je.id = jd.journal_entry_id
+		 WHERE jd.coa_code = @code
+		 AND jd.deleted_at IS NULL
+		 AND je.deleted_at IS NULL`,
+		sql.Named("code", code),
+	).Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// FindUsedCodes returns every account code referenced by a journal line on a
+// live entry.
+func (r *repository) FindUsedCodes() ([]string, error) {
+	var codes []string
+	err := r.db.Raw(
+		`SELECT DISTINCT jd.coa_code
+		 FROM journal_entry_details jd
+		 JOIN journal_entries je ON je.id = jd.journal_entry_id
+		 WHERE jd.deleted_at IS NULL
+		 AND je.deleted_at IS NULL`,
+	).Scan(&codes).Error
+	return codes, err
+}
+
+// FindReferencedCodes returns the account codes that live configuration and
+// subledger documents, such as allocation rules, journal templates, invoices
+// and bills, still point at, with what references them.
+func (r *repository) FindReferencedCodes() ([]CodeReference, error) {
+	var refs []CodeReference
+	err := r.db.Raw(
+		`SELECT DISTINCT code, used_by FROM (
+			SELECT ar.source_coa_code AS code, 'allocation rules' AS used_by FROM allocation_rules ar WHERE ar.deleted_at IS NULL
+			UNION SELECT ar.basis_coa_code, 'allocation rules' FROM allocation_rules ar WHERE ar.deleted_at IS NULL
+			UNION SELECT t.coa_code, 'allocation rules' FROM allocation_targets t JOIN allocation_rules ar ON ar.id = t.rule_id WHERE ar.deleted_at IS NULL
+			UNION SELECT t.basis_coa_code, 'allocation rules' FROM allocation_targets t JOIN allocation_rules ar ON ar.id = t.rule_id WHERE ar.deleted_at IS NULL
+			UNION SELECT l->>'coaCode', 'journal templates' FROM journal_templates jt, jsonb_array_elements(jt.lines) l WHERE jt.deleted_at IS NULL
+			UNION SELECT coa_code, 'sales invoices' FROM sales_invoice_lines
+			UNION SELECT bank_coa_code, 'receipts' FROM receipts
+			UNION SELECT coa_code, 'purchase bills' FROM purchase_bill_lines
+			UNION SELECT bank_coa_code, 'payments' FROM payments
+		 ) refs
+		 WHERE code IS NOT NULL`,
+	).Scan(&refs).Error
+	return refs, err
+}
+
 func (r *repository) Create(coa *domain.ChartOfAccount) error {
 	return r.db.Exec(
-		`INSERT INTO chart_of_accounts (code, name, type, parent_code, is_active, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, NOW(), NOW())`,
-		coa.Code, coa.Name, coa.Type, coa.ParentCode, coa.IsActive,
+		`INSERT INTO chart_of_accounts (code, name, type, parent_code, kind, is_active, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		coa.Code, coa.Name, coa.Type, coa.ParentCode, coa.Kind, coa.IsActive,
 	).Error
 }
 
 func (r *repository) Update(coa *domain.ChartOfAccount) error {
 	return r.db.Exec(
 		`UPDATE chart_of_accounts
-		 SET name = ?, type = ?, parent_code = ?, is_active = ?, updated_at = NOW()
+		 SET name = ?, type = ?, parent_code = ?, kind = ?, is_active = ?, updated_at = NOW()
 		 WHERE code = ? AND deleted_at IS NULL`,
-		coa.Name, coa.Type, coa.ParentCode, coa.IsActive, coa.Code,
+		coa.Name, coa.Type, coa.ParentCode, coa.Kind, coa.IsActive, coa.Code,
+	).Error
+}
+
+// Upsert inserts coa or overwrites the row with the same code, reviving it if
+// it had been soft-deleted.
+func (r *repository) Upsert(coa *domain.ChartOfAccount) error {
+	return r.db.Exec(
+		`INSERT INTO chart_of_accounts (code, name, type, parent_code, kind, is_active, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())
+		 ON CONFLICT (code) DO UPDATE SET
+			name = EXCLUDED.name,
+			type = EXCLUDED.type,
+			parent_code = EXCLUDED.parent_code,
+			kind = EXCLUDED.kind,
+			is_active = EXCLUDED.is_active,
+			updated_at = NOW(),
+			deleted_at = NULL`,
+		coa.Code, coa.Name, coa.Type, coa.ParentCode, coa.Kind, coa.IsActive,
+	).Error
+}
+
+// CodeTaken reports whether any row, including a soft-deleted one, already
+// owns code as its primary key.
+func (r *repository) CodeTaken(code string) (bool, error) {
+	var taken bool
+	err := r.db.Raw(`SELECT EXISTS (SELECT 1 FROM chart_of_accounts WHERE code = ?)`, code).Scan(&taken).Error
+	return taken, err
+}
+
+func (r *repository) ChangeCode(oldCode, newCode string) error {
+	return r.db.Exec(
+		`UPDATE chart_of_accounts SET code = ?, updated_at = NOW() WHERE code = ?`,
+		newCode, oldCode,
+	).Error
+}
+
+// MoveChildren re-parents every child of fromCode, deleted ones included, so
+// no row is left pointing at a code that no longer exists.
+func (r *repository) MoveChildren(fromCode, toCode string) error {
+	return r.db.Exec(
+		`UPDATE chart_of_accounts SET parent_code = ?, updated_at = NOW() WHERE parent_code = ?`,
+		toCode, fromCode,
+	).Error
+}
+
+// MoveDetailLines rebooks every journal line from fromCode to toCode. Lines of
+// chained entries remember their original code for hash verification.
+func (r *repository) MoveDetailLines(fromCode, toCode string) error {
+	return r.db.Exec(
+		`UPDATE journal_entry_details jd
+		 SET chained_coa_code = CASE
+				WHEN je.chain_seq IS NOT NULL THEN COALESCE(jd.chained_coa_code, jd.coa_code)
+				ELSE jd.chained_coa_code
+			END,
+			coa_code = ?
+		 FROM journal_entries je
+		 WHERE je.id = jd.journal_entry_id
+		 AND jd.coa_code = ?`,
+		toCode, fromCode,
 	).Error
 }
 
+// MoveDimensionRules carries an account's dimension rules over to its new code.
+func (r *repository) MoveDimensionRules(fromCode, toCode string) error {
+	return r.db.Exec(
+		`UPDATE account_dimensions SET coa_code = ? WHERE coa_code = ?`,
+		toCode, fromCode,
+	).Error
+}
+
+// FindDimensionRules lists the dimension rules of an account.
+func (r *repository) FindDimensionRules(code string) ([]domain.AccountDimension, error) {
+	var rules []domain.AccountDimension
+	err := r.db.Raw(
+		`SELECT coa_code, dimension_code, required FROM account_dimensions WHERE coa_code = ? ORDER BY dimension_code`,
+		code,
+	).Scan(&rules).Error
+	return rules, err
+}
+
+// MoveAllocationRules points every allocation rule and target, deleted ones
+// included, that reads or posts to fromCode at toCode.
+func (r *repository) MoveAllocationRules(fromCode, toCode string) error {
+	if err := r.db.Exec(
+		`UPDATE allocation_rules SET source_coa_code = ?, updated_at = NOW() WHERE source_coa_code = ?`,
+		toCode, fromCode,
+	).Error; err != nil {
+		return err
+	}
+	if err := r.db.Exec(
+		`UPDATE allocation_rules SET basis_coa_code = ?, updated_at = NOW() WHERE basis_coa_code = ?`,
+		toCode, fromCode,
+	).Error; err != nil {
+		return err
+	}
+	if err := r.db.Exec(
+		`UPDATE allocation_targets SET coa_code = ? WHERE coa_code = ?`,
+		toCode, fromCode,
+	).Error; err != nil {
+		return err
+	}
+	return r.db.Exec(
+		`UPDATE allocation_targets SET basis_coa_code = ? WHERE basis_coa_code = ?`,
+		toCode, fromCode,
+	).Error
+}
+
+// MoveTemplateLines rewrites the coaCode of every journal template line,
+// deleted templates included, that books to fromCode.
+func (r *repository) MoveTemplateLines(fromCode, toCode string) error {
+	return r.db.Exec(
+		`UPDATE journal_templates jt
+		 SET lines = (
+			SELECT jsonb_agg(
+				CASE WHEN l->>'coaCode' = @from THEN jsonb_set(l, '{coaCode}', to_jsonb(CAST(@to AS text))) ELSE l END
+				ORDER BY pos
+			)
+			FROM jsonb_array_elements(jt.lines) WITH ORDINALITY AS t(l, pos)
+		 ),
+		 updated_at = NOW()
+		 WHERE jt.lines @> jsonb_build_array(jsonb_build_object('coaCode', CAST(@from AS text)))`,
+		sql.Named("from", fromCode), sql.Named("to", toCode),
+	).Error
+}
+
+// MoveDocumentCodes rebooks the subledger document lines of fromCode, such
+// as invoice and bill lines and the bank accounts of receipts and payments,
+// to toCode.
+func (r *repository) MoveDocumentCodes(fromCode, toCode string) error {
+	for _, query := range []string{
+		`UPDATE sales_invoice_lines SET coa_code = ? WHERE coa_code = ?`,
+		`UPDATE receipts SET bank_coa_code = ?, updated_at = NOW() WHERE bank_coa_code = ?`,
+		`UPDATE purchase_bill_lines SET coa_code = ? WHERE coa_code = ?`,
+		`UPDATE payments SET bank_coa_code = ?, updated_at = NOW() WHERE bank_coa_code = ?`,
+	} {
+		if err := r.db.Exec(query, toCode, fromCode).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *repository) Delete(code string) error {
 	result := r.db.Exec(
 		`UPDATE chart_of_accounts SET deleted_at = NOW() WHERE code = ? AND deleted_at IS NULL`,
@@ -1,20 +1,33 @@
 package coa
 
 import (
+	"fiber.com/session-api/internal/apikey"
 	"fiber.com/session-api/pkg/middleware"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
-func RegisterRoutes(router fiber.Router, handler *Handler) {
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
 	coaRoutes := router.Group("/coa")
-	coaRoutes.Use(middleware.AuthMiddleware())
+	coaRoutes.Use(middleware.AuthMiddleware(), middleware.Idempotency(db))
 
-	coaRoutes.Get("/", handler.GetAll)
-	coaRoutes.Get("/no-paginate", handler.GetAllNoPaginate)
-	coaRoutes.Get("/with-children", handler.GetAllWithChildren)
-	coaRoutes.Get("/:code", handler.GetByCode)
-	coaRoutes.Post("/", handler.Create)
-	coaRoutes.Put("/:code", handler.Update)
-	coaRoutes.Delete("/:code", handler.Delete)
+	read := middleware.RequireScope(apikey.ScopeCOARead)
+	write := middleware.RequireScope(apikey.ScopeCOAWrite)
+
+	coaRoutes.Get("/", read, handler.GetAll)
+	coaRoutes.Get("/no-paginate", read, handler.GetAllNoPaginate)
+	coaRoutes.Get("/with-children", read, handler.GetAllWithChildren)
+	coaRoutes.Get("/tree", read, handler.GetTree)
+	coaRoutes.Get("/export", read, handler.Export)
+	coaRoutes.Post("/import", write, middleware.DBTransaction(db), handler.Import)
+	coaRoutes.Get("/templates", read, handler.GetTemplates)
+	coaRoutes.Post("/templates/:name/apply", middleware.RequireRole("admin"), middleware.DBTransaction(db), handler.ApplyTemplate)
+	coaRoutes.Get("/:code", read, handler.GetByCode)
+	coaRoutes.Get("/:code/history", read, handler.GetHistory)
+	coaRoutes.Post("/", write, middleware.DBTransaction(db), handler.Create)
+	coaRoutes.Put("/:code", write, middleware.DBTransaction(db), handler.Update)
+	coaRoutes.Post("/:code/renumber", write, middleware.RequireRole("admin"), middleware.DBTransaction(db), handler.Renumber)
+	coaRoutes.Post("/:code/merge", write, middleware.RequireRole("admin"), middleware.DBTransaction(db), handler.Merge)
+	coaRoutes.Delete("/:code", write, middleware.DBTransaction(db), handler.Delete)
 }
@@ -1,30 +1,83 @@
 package coa
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"strings"
 
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
 	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/event"
 	"fiber.com/session-api/pkg/model"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 type Service interface {
-	GetAll(req *model.PaginationRequest) ([]COAResponse, *model.MetaPagination, error)
-	GetAllNoPagination() ([]COAResponse, error)
-	GetAllWithChildren(req *model.PaginationRequest) ([]CoaReqursiveResponse, *model.MetaPagination, error)
+	GetAll(req *model.PaginationRequest, kind string) ([]COAResponse, *model.MetaPagination, error)
+	GetAllNoPagination(kind string) ([]COAResponse, error)
+	GetAllWithChildren(req *model.PaginationRequest, kind string) ([]CoaReqursiveResponse, *model.MetaPagination, error)
+	GetTree(q *COATreeQuery) ([]*COATreeNode, error)
 	GetByCode(code string) (*COAResponse, error)
-	Create(req *CreateCOARequest) (*COAResponse, error)
-	Update(code string, req *UpdateCOARequest) (*COAResponse, error)
-	Delete(code string) error
+	GetHistory(code string, req *model.PaginationRequest) ([]audit.AuditLogResponse, *model.MetaPagination, error)
+	Create(req *CreateCOARequest, actor audit.Actor, tx *gorm.DB) (*COAResponse, error)
+	Update(code string, req *UpdateCOARequest, actor audit.Actor, tx *gorm.DB) (*COAResponse, error)
+	Delete(code string, force bool, actor audit.Actor, tx *gorm.DB) error
+	Renumber(code string, req *RenumberRequest, actor audit.Actor, tx *gorm.DB) (*CodeChangeResponse, error)
+	Merge(code string, req *MergeRequest, actor audit.Actor, tx *gorm.DB) (*CodeChangeResponse, error)
+	Export(format string) ([]byte, error)
+	Import(rows []ImportRow, mode string, dryRun bool, actor audit.Actor, tx *gorm.DB) (*ImportResult, error)
+	GetTemplates() ([]TemplateResponse, error)
+	ApplyTemplate(name string, actor audit.Actor, tx *gorm.DB) (*ImportResult, error)
 }
 
 type service struct {
-	repo Repository
+	repo         Repository
+	auditService audit.Service
 }
 
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
+func NewService(repo Repository, auditService audit.Service) Service {
+	return &service{repo: repo, auditService: auditService}
+}
+
+// record audits a change to an account and publishes it as coa.updated in
+// the same transaction.
+func record(tx *gorm.DB, actor audit.Actor, code, action string, before, after any) error {
+	if err := audit.Record(tx, actor, audit.EntityCOA, code, action, before, after); err != nil {
+		return err
+	}
+	return event.PublishCOAChange(tx, code, action, before, after)
+}
+
+// controlAccounts maps the subledger control accounts to the setting that
+// names them. Subledgers post to these codes by configuration, so they must
+// not be renumbered, merged, deactivated or deleted behind its back.
+func controlAccounts() map[string]string {
+	accounts := map[string]string{}
+	if code := config.AppConfig.ARControlCode; code != "" {
+		accounts[code] = "AR_CONTROL_CODE"
+	}
+	if code := config.AppConfig.APControlCode; code != "" {
+		accounts[code] = "AP_CONTROL_CODE"
+	}
+	return accounts
+}
+
+func checkControlAccount(code, operation string) error {
+	if setting, ok := controlAccounts()[code]; ok {
+		return fiber.NewError(fiber.StatusConflict, controlAccountMessage(code, setting, operation))
+	}
+	return nil
+}
+
+func controlAccountMessage(code, setting, operation string) string {
+	return fmt.Sprintf(
+		"COA %s is configured as %s and cannot be %s; point %s at another account first",
+		code, setting, operation, setting,
+	)
 }
 
 func toResponse(c *domain.ChartOfAccount) *COAResponse {
@@ -33,12 +86,13 @@ func toResponse(c *domain.ChartOfAccount) *COAResponse {
 		Name:       c.Name,
 		Type:       c.Type,
 		ParentCode: c.ParentCode,
+		Kind:       c.Kind,
 		IsActive:   c.IsActive,
 	}
 }
 
-func (s *service) GetAll(req *model.PaginationRequest) ([]COAResponse, *model.MetaPagination, error) {
-	accounts, total, err := s.repo.FindAll(req)
+func (s *service) GetAll(req *model.PaginationRequest, kind string) ([]COAResponse, *model.MetaPagination, error) {
+	accounts, total, err := s.repo.FindAll(req, kind)
 	if err != nil {
 		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -59,8 +113,8 @@ func (s *service) GetAll(req *model.PaginationRequest) ([]COAResponse, *model.Me
 	return responses, meta, nil
 }
 
-func (s *service) GetAllNoPagination() ([]COAResponse, error) {
-	coa, err := s.repo.FindAllNoPagination()
+func (s *service) GetAllNoPagination(kind string) ([]COAResponse, error) {
+	coa, err := s.repo.FindAllNoPagination(kind)
 
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -74,8 +128,8 @@ func (s *service) GetAllNoPagination() ([]COAResponse, error) {
 	return responses, nil
 }
 
-func (s *service) GetAllWithChildren(req *model.PaginationRequest) ([]CoaReqursiveResponse, *model.MetaPagination, error) {
-	accounts, total, err := s.repo.FindAllWithChildren(req)
+func (s *service) GetAllWithChildren(req *model.PaginationRequest, kind string) ([]CoaReqursiveResponse, *model.MetaPagination, error) {
+	accounts, total, err := s.repo.FindAllWithChildren(req, kind)
 
 	if err != nil {
 		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -91,6 +145,85 @@ func (s *service) GetAllWithChildren(req *model.PaginationRequest) ([]CoaReqursi
 	return accounts, meta, nil
 }
 
+func (s *service) GetTree(q *COATreeQuery) ([]*COATreeNode, error) {
+	if q.Root != "" {
+		root, err := s.repo.FindByCode(q.Root)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if root == nil {
+			return nil, fiber.NewError(fiber.StatusNotFound, "COA not found")
+		}
+	}
+
+	rows, err := s.repo.FindTree(q)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if q.Search != "" {
+		rows = keepMatchesWithAncestors(rows, q.Search)
+	}
+
+	return buildTree(rows), nil
+}
+
+// keepMatchesWithAncestors drops every row that neither matches search (by
+// code or name) nor sits on the path to a matching row.
+func keepMatchesWithAncestors(rows []COATreeRow, search string) []COATreeRow {
+	needle := strings.ToLower(search)
+	keep := map[string]bool{}
+
+	for _, r := range rows {
+		if strings.Contains(strings.ToLower(r.Code), needle) || strings.Contains(strings.ToLower(r.Name), needle) {
+			for _, code := range strings.Split(r.Path, "/") {
+				keep[code] = true
+			}
+		}
+	}
+
+	filtered := make([]COATreeRow, 0, len(keep))
+	for _, r := range rows {
+		if keep[r.Code] {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// buildTree nests rows that arrive in path order, so a parent is always seen
+// before its children.
+func buildTree(rows []COATreeRow) []*COATreeNode {
+	roots := []*COATreeNode{}
+	nodes := make(map[string]*COATreeNode, len(rows))
+
+	for _, r := range rows {
+		node := &COATreeNode{
+			Code:       r.Code,
+			Name:       r.Name,
+			Type:       r.Type,
+			ParentCode: r.ParentCode,
+			Kind:       r.Kind,
+			IsActive:   r.IsActive,
+			Depth:      r.Depth,
+			Path:       r.Path,
+			IsLeaf:     r.IsLeaf,
+			Children:   []*COATreeNode{},
+		}
+		nodes[r.Code] = node
+
+		if r.Depth > 0 && r.ParentCode != nil {
+			if parent, ok := nodes[*r.ParentCode]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
 func (s *service) GetByCode(code string) (*COAResponse, error) {
 	coa, err := s.repo.FindByCode(code)
 	if err != nil {
@@ -102,12 +235,18 @@ func (s *service) GetByCode(code string) (*COAResponse, error) {
 	return toResponse(coa), nil
 }
 
-func (s *service) Create(req *CreateCOARequest) (*COAResponse, error) {
+func (s *service) GetHistory(code string, req *model.PaginationRequest) ([]audit.AuditLogResponse, *model.MetaPagination, error) {
+	return s.auditService.GetHistory(audit.EntityCOA, code, req)
+}
+
+func (s *service) Create(req *CreateCOARequest, actor audit.Actor, tx *gorm.DB) (*COAResponse, error) {
+	txRepo := NewRepository(tx)
+
 	if req.ParentCode != nil && *req.ParentCode == req.Code {
 		return nil, fiber.NewError(fiber.StatusBadRequest, "Parent COA code cannot be its own parent")
 	}
 
-	existing, err := s.repo.FindByCode(req.Code)
+	existing, err := txRepo.FindByCode(req.Code)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -116,10 +255,22 @@ func (s *service) Create(req *CreateCOARequest) (*COAResponse, error) {
 		return nil, fiber.NewError(fiber.StatusConflict, "COA code already exists")
 	}
 
+	// The primary key outlives a soft delete, so a trashed code can only be
+	// taken over by reviving its row.
+	revive, err := txRepo.CodeTaken(req.Code)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if revive && !req.Revive {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"COA code %s belongs to a deleted account; set revive to true to restore it with these details", req.Code,
+		))
+	}
+
 	var finalParentCode *string
 
 	if req.ParentCode != nil && *req.ParentCode != "" {
-		parent, err := s.repo.FindByCode(*req.ParentCode)
+		parent, err := txRepo.FindByCode(*req.ParentCode)
 		if err != nil {
 			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 		}
@@ -136,39 +287,67 @@ func (s *service) Create(req *CreateCOARequest) (*COAResponse, error) {
 		isActive = *req.IsActive
 	}
 
+	kind := domain.AccountKindPostable
+	if req.Kind != "" {
+		if !validKind(req.Kind) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid account kind")
+		}
+		kind = req.Kind
+	}
+
 	coa := &domain.ChartOfAccount{
 		Code:       req.Code,
 		Name:       req.Name,
 		Type:       req.Type,
 		ParentCode: finalParentCode,
+		Kind:       kind,
 		IsActive:   isActive,
 	}
 
-	if err := s.repo.Create(coa); err != nil {
+	if err := validateHierarchy(txRepo, coa); err != nil {
+		return nil, err
+	}
+
+	write, action := txRepo.Create, audit.ActionCreate
+	if revive {
+		write, action = txRepo.Upsert, audit.ActionRestore
+	}
+	if err := write(coa); err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	created, err := s.repo.FindByCode(req.Code)
+	created, err := txRepo.FindByCode(req.Code)
 	if err != nil || created == nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to fetch created COA")
 	}
 
-	return toResponse(created), nil
+	resp := toResponse(created)
+	if err := record(tx, actor, resp.Code, action, nil, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
 }
 
-func (s *service) Update(code string, req *UpdateCOARequest) (*COAResponse, error) {
-	existing, err := s.repo.FindByCode(code)
+func (s *service) Update(code string, req *UpdateCOARequest, actor audit.Actor, tx *gorm.DB) (*COAResponse, error) {
+	txRepo := NewRepository(tx)
+
+	existing, err := txRepo.FindByCode(code)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 	if existing == nil {
 		return nil, fiber.NewError(fiber.StatusNotFound, "COA not found")
 	}
+	before := toResponse(existing)
 
 	if req.Name != "" {
 		existing.Name = req.Name
 	}
-	if req.Type != "" {
+	if req.Type != "" && req.Type != existing.Type {
+		if err := checkControlAccount(code, "given another type"); err != nil {
+			return nil, err
+		}
 		existing.Type = req.Type
 	}
 	if req.ParentCode != nil {
@@ -178,7 +357,7 @@ func (s *service) Update(code string, req *UpdateCOARequest) (*COAResponse, erro
 			if *req.ParentCode == code {
 				return nil, fiber.NewError(fiber.StatusBadRequest, "Parent COA code cannot be its own parent")
 			}
-			parent, err := s.repo.FindByCode(*req.ParentCode)
+			parent, err := txRepo.FindByCode(*req.ParentCode)
 			if err != nil {
 				return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 			}
@@ -189,16 +368,143 @@ func (s *service) Update(code string, req *UpdateCOARequest) (*COAResponse, erro
 		}
 	}
 	if req.IsActive != nil {
+		if !*req.IsActive && existing.IsActive {
+			if err := checkControlAccount(code, "deactivated"); err != nil {
+				return nil, err
+			}
+		}
 		existing.IsActive = *req.IsActive
 	}
+	if req.Kind != "" && req.Kind != existing.Kind {
+		if err := checkControlAccount(code, "made a "+string(req.Kind)+" account"); err != nil {
+			return nil, err
+		}
+		if err := checkKindChange(txRepo, existing.Code, req.Kind); err != nil {
+			return nil, err
+		}
+		existing.Kind = req.Kind
+	}
+
+	if existing.Type != before.Type || !sameParent(existing.ParentCode, before.ParentCode) {
+		if err := validateHierarchy(txRepo, existing); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := txRepo.Update(existing); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after := toResponse(existing)
+	if err := record(tx, actor, code, audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+
+	return after, nil
+}
+
+// Delete soft-deletes an account that nothing references any more. Accounts
+// with journal lines must be deactivated instead. With force, children are
+// moved up to the deleted account's parent rather than blocking the delete.
+func (s *service) Delete(code string, force bool, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	existing, err := txRepo.FindByCode(code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if existing == nil {
+		return fiber.NewError(fiber.StatusNotFound, "COA not found")
+	}
+	if err := checkControlAccount(code, "deleted"); err != nil {
+		return err
+	}
+
+	usage, err := txRepo.GetUsage(code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if usage.DraftLines > 0 || usage.PostedLines > 0 || usage.Balance != 0 {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA %s cannot be deleted: it has %d posted and %d draft journal lines (balance %.2f); deactivate it instead",
+			code, usage.PostedLines, usage.DraftLines, usage.Balance,
+		))
+	}
+	if usage.AllocationRules > 0 || usage.JournalTemplates > 0 || usage.Documents > 0 {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA %s cannot be deleted: %d allocation rules, %d journal templates and %d subledger document lines use it; change or delete them first",
+			code, usage.AllocationRules, usage.JournalTemplates, usage.Documents,
+		))
+	}
 
-	if err := s.repo.Update(existing); err != nil {
+	children, err := txRepo.FindChildren(code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if len(children) > 0 && !force {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"COA %s cannot be deleted: it has %d child accounts; move them first or delete with force",
+			code, len(children),
+		))
+	}
+
+	for _, child := range children {
+		before := toResponse(&child)
+		child.ParentCode = existing.ParentCode
+		if err := txRepo.Update(&child); err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if err := record(tx, actor, child.Code, audit.ActionUpdate, before, toResponse(&child)); err != nil {
+			return err
+		}
+	}
+
+	if err := txRepo.Delete(code); err != nil {
+		return err
+	}
+
+	return record(tx, actor, code, audit.ActionDelete, toResponse(existing), nil)
+}
+
+func (s *service) Export(format string) ([]byte, error) {
+	accounts, err := s.repo.FindAllNoPagination("")
+	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return toResponse(existing), nil
+	data, err := encodeChart(accounts, format)
+	if err != nil {
+		var fe *fiber.Error
+		if errors.As(err, &fe) {
+			return nil, err
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return data, nil
+}
+
+func (s *service) Import(rows []ImportRow, mode string, dryRun bool, actor audit.Actor, tx *gorm.DB) (*ImportResult, error) {
+	return importChart(tx, rows, mode, dryRun, actor)
 }
 
-func (s *service) Delete(code string) error {
-	return s.repo.Delete(code)
+func (s *service) GetTemplates() ([]TemplateResponse, error) {
+	return listTemplates()
+}
+
+// ApplyTemplate seeds an empty chart from one of the bundled templates.
+func (s *service) ApplyTemplate(name string, actor audit.Actor, tx *gorm.DB) (*ImportResult, error) {
+	rows, err := loadTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := NewRepository(tx).FindAllNoPagination("")
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if len(existing) > 0 {
+		return nil, fiber.NewError(fiber.StatusConflict, "Templates can only be applied to an empty chart of accounts; use import instead")
+	}
+
+	return importChart(tx, rows, ImportModeUpsert, false, actor)
 }
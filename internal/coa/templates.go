@@ -0,0 +1,44 @@
+package coa
+
+import (
+	"bytes"
+	"embed"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//go:embed templates/*.csv
+var templateFS embed.FS
+
+// chartTemplates lists the bundled seed charts, keyed by the name used in the
+// API and CLI. Each one lives in templates/<name>.csv in the import format.
+var chartTemplates = map[string]string{
+	"sak-etap": "Standard Indonesian chart of accounts following SAK-ETAP",
+	"services": "Generic chart for a services company",
+}
+
+func loadTemplate(name string) ([]ImportRow, error) {
+	if _, ok := chartTemplates[name]; !ok {
+		return nil, fiber.NewError(fiber.StatusNotFound, "COA template not found")
+	}
+
+	raw, err := templateFS.ReadFile("templates/" + name + ".csv")
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return DecodeChart(bytes.NewReader(raw), FormatCSV)
+}
+
+func listTemplates() ([]TemplateResponse, error) {
+	templates := make([]TemplateResponse, 0, len(chartTemplates))
+	for name, description := range chartTemplates {
+		rows, err := loadTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, TemplateResponse{Name: name, Description: description, Accounts: len(rows)})
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
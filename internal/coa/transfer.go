@@ -0,0 +1,123 @@
+package coa
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/export"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = export.CSV
+	FormatXLSX = export.XLSX
+)
+
+const xlsxSheet = "COA"
+
+// transferColumns is the column layout shared by import and export.
+var transferColumns = []string{"code", "name", "type", "parent", "active", "kind"}
+
+func encodeChart(accounts []domain.ChartOfAccount, format string) ([]byte, error) {
+	if format != FormatCSV && format != FormatXLSX {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "format must be csv or xlsx")
+	}
+
+	records := [][]any{make([]any, len(transferColumns))}
+	for i, c := range transferColumns {
+		records[0][i] = c
+	}
+	for _, a := range accounts {
+		parent := ""
+		if a.ParentCode != nil {
+			parent = *a.ParentCode
+		}
+		records = append(records, []any{
+			a.Code, a.Name, string(a.Type), parent, strconv.FormatBool(a.IsActive), string(a.Kind),
+		})
+	}
+	return export.Table(xlsxSheet, records, format)
+}
+
+// DecodeChart reads an import file. The first row must be a header naming the
+// columns (in any order); code, name and type are required.
+func DecodeChart(r io.Reader, format string) ([]ImportRow, error) {
+	var records [][]string
+
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		all, err := reader.ReadAll()
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid CSV file: "+err.Error())
+		}
+		records = all
+
+	case FormatXLSX:
+		f, err := excelize.OpenReader(r)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid XLSX file: "+err.Error())
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "XLSX file has no sheets")
+		}
+		all, err := f.GetRows(sheets[0])
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid XLSX file: "+err.Error())
+		}
+		records = all
+
+	default:
+		return nil, fiber.NewError(fiber.StatusBadRequest, "format must be csv or xlsx")
+	}
+
+	if len(records) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Import file is empty")
+	}
+
+	index := map[string]int{}
+	for i, name := range records[0] {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"code", "name", "type"} {
+		if _, ok := index[required]; !ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Import file is missing the %q column", required))
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := index[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rows := make([]ImportRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		rows = append(rows, ImportRow{
+			Line:   i + 2,
+			Code:   field(record, "code"),
+			Name:   field(record, "name"),
+			Type:   strings.ToLower(field(record, "type")),
+			Parent: field(record, "parent"),
+			Active: strings.ToLower(field(record, "active")),
+			Kind:   strings.ToLower(field(record, "kind")),
+		})
+	}
+	return rows, nil
+}
@@ -0,0 +1,72 @@
+package dimension
+
+type CreateDimensionRequest struct {
+	Code string `json:"code" validate:"required,max=20"  example:"CC"`
+	Name string `json:"name" validate:"required,max=100" example:"Cost Centre"`
+}
+
+type UpdateDimensionRequest struct {
+	Name     string `json:"name"     validate:"omitempty,max=100" example:"Cost Centre"`
+	IsActive *bool  `json:"isActive" validate:"omitempty"         example:"true"`
+}
+
+type CreateValueRequest struct {
+	Code string `json:"code" validate:"required,max=20"  example:"JKT"`
+	Name string `json:"name" validate:"required,max=100" example:"Jakarta Branch"`
+}
+
+type UpdateValueRequest struct {
+	Name     string `json:"name"     validate:"omitempty,max=100" example:"Jakarta Branch"`
+	IsActive *bool  `json:"isActive" validate:"omitempty"         example:"true"`
+}
+
+type ValueResponse struct {
+	Code     string `json:"code"     gorm:"column:code"`
+	Name     string `json:"name"     gorm:"column:name"`
+	IsActive bool   `json:"isActive" gorm:"column:is_active"`
+}
+
+type DimensionResponse struct {
+	Code     string          `json:"code"`
+	Name     string          `json:"name"`
+	IsActive bool            `json:"isActive"`
+	Values   []ValueResponse `json:"values"`
+}
+
+// AccountRule allows a dimension on an account's journal lines; Required
+// makes it mandatory on every line.
+type AccountRule struct {
+	DimensionCode string `json:"dimensionCode" gorm:"column:dimension_code" example:"CC"`
+	DimensionName string `json:"dimensionName" gorm:"column:dimension_name"`
+	Required      bool   `json:"required"      gorm:"column:required"       example:"true"`
+}
+
+// SetAccountRulesRequest replaces all dimension rules of one account.
+type SetAccountRulesRequest struct {
+	Rules []AccountRule `json:"rules"`
+}
+
+type AccountRulesResponse struct {
+	CoaCode string        `json:"coaCode"`
+	Rules   []AccountRule `json:"rules"`
+}
+
+// Swagger Responses
+
+type SwaggerDimensionResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    DimensionResponse `json:"data"`
+}
+
+type SwaggerDimensionListResponse struct {
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Data    []DimensionResponse `json:"data"`
+}
+
+type SwaggerAccountRulesResponse struct {
+	Code    int                  `json:"code"`
+	Message string               `json:"message"`
+	Data    AccountRulesResponse `json:"data"`
+}
@@ -0,0 +1,243 @@
+package dimension
+
+import (
+	"fmt"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetAll godoc
+// @Summary      List dimensions
+// @Description  Returns every analytical dimension (cost centre, project, department, ...) with its values
+// @Tags         Dimension
+// @Produce      json
+// @Success      200  {object}  SwaggerDimensionListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /dimensions [get]
+func (h *Handler) GetAll(c *fiber.Ctx) error {
+	dims, err := h.service.GetAll()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get all dimensions", dims)
+}
+
+// GetByCode godoc
+// @Summary      Get dimension by code
+// @Description  Returns a single dimension with its values
+// @Tags         Dimension
+// @Produce      json
+// @Param        code  path  string  true  "Dimension code (e.g. CC)"
+// @Success      200  {object}  SwaggerDimensionResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /dimensions/{code} [get]
+func (h *Handler) GetByCode(c *fiber.Ctx) error {
+	dim, err := h.service.GetByCode(c.Params("code"))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fmt.Sprintf("Success get dimension %s", dim.Code), dim)
+}
+
+// Create godoc
+// @Summary      Create a dimension
+// @Description  Creates a new analytical dimension. The code is its unique identifier.
+// @Tags         Dimension
+// @Accept       json
+// @Produce      json
+// @Param        body body CreateDimensionRequest true "Dimension payload"
+// @Success      201  {object}  SwaggerDimensionResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /dimensions [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req CreateDimensionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	dim, err := h.service.Create(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Dimension created successfully", dim)
+}
+
+// Update godoc
+// @Summary      Update a dimension
+// @Description  Renames or deactivates a dimension. Inactive dimensions cannot be used on new journal lines.
+// @Tags         Dimension
+// @Accept       json
+// @Produce      json
+// @Param        code  path  string                  true  "Dimension code (e.g. CC)"
+// @Param        body  body  UpdateDimensionRequest  true  "Dimension update payload"
+// @Success      200  {object}  SwaggerDimensionResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /dimensions/{code} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	var req UpdateDimensionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	dim, err := h.service.Update(c.Params("code"), &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Dimension updated successfully", dim)
+}
+
+// CreateValue godoc
+// @Summary      Add a dimension value
+// @Description  Adds a value (e.g. a branch or project) to a dimension
+// @Tags         Dimension
+// @Accept       json
+// @Produce      json
+// @Param        code  path  string              true  "Dimension code (e.g. CC)"
+// @Param        body  body  CreateValueRequest  true  "Value payload"
+// @Success      201  {object}  SwaggerDimensionResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /dimensions/{code}/values [post]
+func (h *Handler) CreateValue(c *fiber.Ctx) error {
+	var req CreateValueRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	dim, err := h.service.CreateValue(c.Params("code"), &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Dimension value created successfully", dim)
+}
+
+// UpdateValue godoc
+// @Summary      Update a dimension value
+// @Description  Renames or deactivates a dimension value. Inactive values cannot be used on new journal lines.
+// @Tags         Dimension
+// @Accept       json
+// @Produce      json
+// @Param        code   path  string              true  "Dimension code (e.g. CC)"
+// @Param        value  path  string              true  "Value code (e.g. JKT)"
+// @Param        body   body  UpdateValueRequest  true  "Value update payload"
+// @Success      200  {object}  SwaggerDimensionResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /dimensions/{code}/values/{value} [put]
+func (h *Handler) UpdateValue(c *fiber.Ctx) error {
+	var req UpdateValueRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	dim, err := h.service.UpdateValue(c.Params("code"), c.Params("value"), &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Dimension value updated successfully", dim)
+}
+
+// GetAccountRules godoc
+// @Summary      Get an account's dimension rules
+// @Description  Returns the dimensions allowed on the account's journal lines and which of them are required
+// @Tags         Dimension
+// @Produce      json
+// @Param        coaCode  path  string  true  "COA Code (e.g. 5-1001)"
+// @Success      200  {object}  SwaggerAccountRulesResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /dimensions/accounts/{coaCode} [get]
+func (h *Handler) GetAccountRules(c *fiber.Ctx) error {
+	rules, err := h.service.GetAccountRules(c.Params("coaCode"))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get account dimension rules", rules)
+}
+
+// SetAccountRules godoc
+// @Summary      Set an account's dimension rules
+// @Description  Replaces the dimensions allowed on the account's journal lines. Dimensions left out are no longer allowed on the account; required ones must be on every line.
+// @Tags         Dimension
+// @Accept       json
+// @Produce      json
+// @Param        coaCode  path  string                  true  "COA Code (e.g. 5-1001)"
+// @Param        body     body  SetAccountRulesRequest  true  "Dimension rules"
+// @Success      200  {object}  SwaggerAccountRulesResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /dimensions/accounts/{coaCode} [put]
+func (h *Handler) SetAccountRules(c *fiber.Ctx) error {
+	var req SetAccountRulesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	rules, err := h.service.SetAccountRules(c.Params("coaCode"), &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Account dimension rules updated successfully", rules)
+}
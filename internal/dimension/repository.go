@@ -0,0 +1,146 @@
+package dimension
+
+import (
+	"fiber.com/session-api/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAll() ([]domain.Dimension, error)
+	FindByCode(code string) (*domain.Dimension, error)
+	FindValues(dimensionCodes []string) ([]domain.DimensionValue, error)
+	FindValue(dimensionCode, code string) (*domain.DimensionValue, error)
+	Create(d *domain.Dimension) error
+	Update(d *domain.Dimension) error
+	CreateValue(v *domain.DimensionValue) error
+	UpdateValue(v *domain.DimensionValue) error
+	FindAccountRules(coaCode string) ([]AccountRule, error)
+	ReplaceAccountRules(coaCode string, rules []domain.AccountDimension) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) FindAll() ([]domain.Dimension, error) {
+	var dims []domain.Dimension
+	err := r.db.Raw(
+		`SELECT code, name, is_active, created_at, updated_at
+		 FROM dimensions
+		 ORDER BY code ASC`,
+	).Scan(&dims).Error
+	return dims, err
+}
+
+func (r *repository) FindByCode(code string) (*domain.Dimension, error) {
+	var d domain.Dimension
+	result := r.db.Raw(
+		`SELECT code, name, is_active, created_at, updated_at
+		 FROM dimensions WHERE code = ? LIMIT 1`,
+		code,
+	).Scan(&d)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &d, nil
+}
+
+func (r *repository) FindValues(dimensionCodes []string) ([]domain.DimensionValue, error) {
+	var values []domain.DimensionValue
+	if len(dimensionCodes) == 0 {
+		return values, nil
+	}
+	err := r.db.Raw(
+		`SELECT dimension_code, code, name, is_active, created_at, updated_at
+		 FROM dimension_values
+		 WHERE dimension_code IN ?
+		 ORDER BY dimension_code ASC, code ASC`,
+		dimensionCodes,
+	).Scan(&values).Error
+	return values, err
+}
+
+func (r *repository) FindValue(dimensionCode, code string) (*domain.DimensionValue, error) {
+	var v domain.DimensionValue
+	result := r.db.Raw(
+		`SELECT dimension_code, code, name, is_active, created_at, updated_at
+		 FROM dimension_values WHERE dimension_code = ? AND code = ? LIMIT 1`,
+		dimensionCode, code,
+	).Scan(&v)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (r *repository) Create(d *domain.Dimension) error {
+	return r.db.Exec(
+		`INSERT INTO dimensions (code, name, is_active, created_at, updated_at)
+		 VALUES (?, ?, ?, NOW(), NOW())`,
+		d.Code, d.Name, d.IsActive,
+	).Error
+}
+
+func (r *repository) Update(d *domain.Dimension) error {
+	return r.db.Exec(
+		`UPDATE dimensions SET name = ?, is_active = ?, updated_at = NOW() WHERE code = ?`,
+		d.Name, d.IsActive, d.Code,
+	).Error
+}
+
+func (r *repository) CreateValue(v *domain.DimensionValue) error {
+	return r.db.Exec(
+		`INSERT INTO dimension_values (dimension_code, code, name, is_active, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, NOW(), NOW())`,
+		v.DimensionCode, v.Code, v.Name, v.IsActive,
+	).Error
+}
+
+func (r *repository) UpdateValue(v *domain.DimensionValue) error {
+	return r.db.Exec(
+		`UPDATE dimension_values SET name = ?, is_active = ?, updated_at = NOW()
+		 WHERE dimension_code = ? AND code = ?`,
+		v.Name, v.IsActive, v.DimensionCode, v.Code,
+	).Error
+}
+
+func (r *repository) FindAccountRules(coaCode string) ([]AccountRule, error) {
+	var rules []AccountRule
+	err := r.db.Raw(
+		`SELECT ad.dimension_code, d.name AS dimension_name, ad.required
+		 FROM account_dimensions ad
+		 JOIN dimensions d ON d.code = ad.dimension_code
+		 WHERE ad.coa_code = ?
+		 ORDER BY ad.dimension_code ASC`,
+		coaCode,
+	).Scan(&rules).Error
+	return rules, err
+}
+
+func (r *repository) ReplaceAccountRules(coaCode string, rules []domain.AccountDimension) error {
+	if err := r.db.Exec(`DELETE FROM account_dimensions WHERE coa_code = ?`, coaCode).Error; err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if err := r.db.Exec(
+			`INSERT INTO account_dimensions (coa_code, dimension_code, required) VALUES (?, ?, ?)`,
+			rule.CoaCode, rule.DimensionCode, rule.Required,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
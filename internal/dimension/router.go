@@ -0,0 +1,26 @@
+package dimension
+
+import (
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	dimensionRoutes := router.Group("/dimensions")
+	dimensionRoutes.Use(middleware.AuthMiddleware(), middleware.Idempotency(db))
+
+	read := middleware.RequireScope(apikey.ScopeCOARead)
+	write := middleware.RequireScope(apikey.ScopeCOAWrite)
+
+	dimensionRoutes.Get("/", read, handler.GetAll)
+	dimensionRoutes.Get("/accounts/:coaCode", read, handler.GetAccountRules)
+	dimensionRoutes.Put("/accounts/:coaCode", write, middleware.DBTransaction(db), handler.SetAccountRules)
+	dimensionRoutes.Get("/:code", read, handler.GetByCode)
+	dimensionRoutes.Post("/", write, middleware.DBTransaction(db), handler.Create)
+	dimensionRoutes.Put("/:code", write, middleware.DBTransaction(db), handler.Update)
+	dimensionRoutes.Post("/:code/values", write, middleware.DBTransaction(db), handler.CreateValue)
+	dimensionRoutes.Put("/:code/values/:value", write, middleware.DBTransaction(db), handler.UpdateValue)
+}
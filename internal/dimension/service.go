@@ -0,0 +1,336 @@
+package dimension
+
+import (
+	"fmt"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/coa"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/event"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetAll() ([]DimensionResponse, error)
+	GetByCode(code string) (*DimensionResponse, error)
+	Create(req *CreateDimensionRequest, actor audit.Actor, tx *gorm.DB) (*DimensionResponse, error)
+	Update(code string, req *UpdateDimensionRequest, actor audit.Actor, tx *gorm.DB) (*DimensionResponse, error)
+	CreateValue(code string, req *CreateValueRequest, actor audit.Actor, tx *gorm.DB) (*DimensionResponse, error)
+	UpdateValue(code, value string, req *UpdateValueRequest, actor audit.Actor, tx *gorm.DB) (*DimensionResponse, error)
+	GetAccountRules(coaCode string) (*AccountRulesResponse, error)
+	SetAccountRules(coaCode string, req *SetAccountRulesRequest, actor audit.Actor, tx *gorm.DB) (*AccountRulesResponse, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func checkCode(field, code string) error {
+	if code == "" {
+		return fiber.NewError(fiber.StatusBadRequest, field+" is required")
+	}
+	if len(code) > 20 {
+		return fiber.NewError(fiber.StatusBadRequest, field+" must be at most 20 characters")
+	}
+	return nil
+}
+
+func toResponses(dims []domain.Dimension, values []domain.DimensionValue) []DimensionResponse {
+	byDim := make(map[string][]ValueResponse)
+	for _, v := range values {
+		byDim[v.DimensionCode] = append(byDim[v.DimensionCode], ValueResponse{
+			Code:     v.Code,
+			Name:     v.Name,
+			IsActive: v.IsActive,
+		})
+	}
+
+	res := make([]DimensionResponse, len(dims))
+	for i, d := range dims {
+		vals := byDim[d.Code]
+		if vals == nil {
+			vals = []ValueResponse{}
+		}
+		res[i] = DimensionResponse{
+			Code:     d.Code,
+			Name:     d.Name,
+			IsActive: d.IsActive,
+			Values:   vals,
+		}
+	}
+	return res
+}
+
+func findDimension(repo Repository, code string) (*DimensionResponse, error) {
+	d, err := repo.FindByCode(code)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if d == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Dimension not found")
+	}
+
+	values, err := repo.FindValues([]string{code})
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return &toResponses([]domain.Dimension{*d}, values)[0], nil
+}
+
+func (s *service) GetAll() ([]DimensionResponse, error) {
+	dims, err := s.repo.FindAll()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	codes := make([]string, len(dims))
+	for i, d := range dims {
+		codes[i] = d.Code
+	}
+	values, err := s.repo.FindValues(codes)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return toResponses(dims, values), nil
+}
+
+func (s *service) GetByCode(code string) (*DimensionResponse, error) {
+	return findDimension(s.repo, code)
+}
+
+func (s *service) Create(req *CreateDimensionRequest, actor audit.Actor, tx *gorm.DB) (*DimensionResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if err := checkCode("code", req.Code); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "name is required")
+	}
+
+	existing, err := txRepo.FindByCode(req.Code)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if existing != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, "Dimension code already exists")
+	}
+
+	if err := txRepo.Create(&domain.Dimension{Code: req.Code, Name: req.Name, IsActive: true}); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findDimension(txRepo, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityDimension, req.Code, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) Update(code string, req *UpdateDimensionRequest, actor audit.Actor, tx *gorm.DB) (*DimensionResponse, error) {
+	txRepo := NewRepository(tx)
+
+	existing, err := txRepo.FindByCode(code)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if existing == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Dimension not found")
+	}
+	before, err := findDimension(txRepo, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		existing.Name = req.Name
+	}
+	if req.IsActive != nil {
+		existing.IsActive = *req.IsActive
+	}
+	if err := txRepo.Update(existing); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findDimension(txRepo, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityDimension, code, audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) CreateValue(code string, req *CreateValueRequest, actor audit.Actor, tx *gorm.DB) (*DimensionResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := findDimension(txRepo, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkCode("code", req.Code); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "name is required")
+	}
+
+	existing, err := txRepo.FindValue(code, req.Code)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if existing != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Value %s already exists in dimension %s", req.Code, code))
+	}
+
+	if err := txRepo.CreateValue(&domain.DimensionValue{
+		DimensionCode: code,
+		Code:          req.Code,
+		Name:          req.Name,
+		IsActive:      true,
+	}); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findDimension(txRepo, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityDimension, code, audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) UpdateValue(code, value string, req *UpdateValueRequest, actor audit.Actor, tx *gorm.DB) (*DimensionResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := findDimension(txRepo, code)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := txRepo.FindValue(code, value)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if existing == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Dimension value not found")
+	}
+
+	if req.Name != "" {
+		existing.Name = req.Name
+	}
+	if req.IsActive != nil {
+		existing.IsActive = *req.IsActive
+	}
+	if err := txRepo.UpdateValue(existing); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findDimension(txRepo, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityDimension, code, audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func findAccountRules(repo Repository, coaCode string) (*AccountRulesResponse, error) {
+	rules, err := repo.FindAccountRules(coaCode)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rules == nil {
+		rules = []AccountRule{}
+	}
+	return &AccountRulesResponse{CoaCode: coaCode, Rules: rules}, nil
+}
+
+func checkAccount(tx *gorm.DB, coaCode string) error {
+	account, err := coa.NewRepository(tx).FindByCode(coaCode)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if account == nil {
+		return fiber.NewError(fiber.StatusNotFound, "COA not found")
+	}
+	return nil
+}
+
+func (s *service) GetAccountRules(coaCode string) (*AccountRulesResponse, error) {
+	return findAccountRules(s.repo, coaCode)
+}
+
+// SetAccountRules replaces the dimension rules of an account. Lines already
+// booked are not re-checked; the new rules apply to journals created or
+// posted from now on.
+func (s *service) SetAccountRules(coaCode string, req *SetAccountRulesRequest, actor audit.Actor, tx *gorm.DB) (*AccountRulesResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if err := checkAccount(tx, coaCode); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	rules := make([]domain.AccountDimension, 0, len(req.Rules))
+	for _, r := range req.Rules {
+		if err := checkCode("dimensionCode", r.DimensionCode); err != nil {
+			return nil, err
+		}
+		if seen[r.DimensionCode] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Dimension %s appears more than once", r.DimensionCode))
+		}
+		seen[r.DimensionCode] = true
+
+		d, err := txRepo.FindByCode(r.DimensionCode)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if d == nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Dimension %s does not exist", r.DimensionCode))
+		}
+
+		rules = append(rules, domain.AccountDimension{
+			CoaCode:       coaCode,
+			DimensionCode: r.DimensionCode,
+			Required:      r.Required,
+		})
+	}
+
+	before, err := findAccountRules(txRepo, coaCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txRepo.ReplaceAccountRules(coaCode, rules); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findAccountRules(txRepo, coaCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityCOA, coaCode, audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	if err := event.PublishCOAChange(tx, coaCode, audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AllocationBasis string
+
+const (
+	// AllocationBasisFixed splits by the targets' fixed percentages.
+	AllocationBasisFixed AllocationBasis = "fixed"
+	// AllocationBasisBalance splits in proportion to each target's basis
+	// account balance over the allocated period.
+	AllocationBasisBalance AllocationBasis = "account_balance"
+)
+
+// AllocationRule moves the period balance of a source account, optionally
+// only the lines tagged with one dimension value, onto its targets.
+type AllocationRule struct {
+	ID                   uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name                 string          `gorm:"type:varchar(100);not null"                     json:"name"`
+	SourceCoaCode        string          `gorm:"type:varchar(20);not null;index"                json:"sourceCoaCode"`
+	SourceDimensionCode  *string         `gorm:"type:varchar(20)"                               json:"sourceDimensionCode,omitempty"`
+	SourceDimensionValue *string         `gorm:"type:varchar(20)"                               json:"sourceDimensionValue,omitempty"`
+	Basis                AllocationBasis `gorm:"type:varchar(20);not null"                      json:"basis"`
+	BasisCoaCode         *string         `gorm:"type:varchar(20)"                               json:"basisCoaCode,omitempty"`
+	IsActive             bool            `gorm:"not null;default:true"                          json:"isActive"`
+	CreatedAt            time.Time       `json:"createdAt"`
+	UpdatedAt            time.Time       `json:"updatedAt"`
+	DeletedAt            gorm.DeletedAt  `gorm:"index"                                          json:"-"`
+}
+
+// AllocationTarget receives a share of the allocated amount, optionally
+// tagged with a dimension value. BasisCoaCode overrides the rule's basis
+// account for this target.
+type AllocationTarget struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RuleID         uuid.UUID `gorm:"type:uuid;not null;index"                       json:"ruleId"`
+	Seq            int       `gorm:"not null"                                       json:"seq"`
+	CoaCode        string    `gorm:"type:varchar(20);not null"                      json:"coaCode"`
+	DimensionCode  *string   `gorm:"type:varchar(20)"                               json:"dimensionCode,omitempty"`
+	DimensionValue *string   `gorm:"type:varchar(20)"                               json:"dimensionValue,omitempty"`
+	Percent        float64   `gorm:"type:numeric(9,4);not null;default:0"           json:"percent"`
+	BasisCoaCode   *string   `gorm:"type:varchar(20)"                               json:"basisCoaCode,omitempty"`
+}
+
+// AllocationRun records that a rule was applied to a period and which draft
+// journal it produced.
+type AllocationRun struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	RuleID         uuid.UUID `gorm:"type:uuid;not null;index:idx_allocation_run_period" json:"ruleId"`
+	Period         string    `gorm:"type:varchar(7);not null;index:idx_allocation_run_period" json:"period"`
+	JournalEntryID uuid.UUID `gorm:"type:uuid;not null"                             json:"journalEntryId"`
+	Amount         float64   `gorm:"type:numeric(20,2);not null"                    json:"amount"`
+	CreatedBy      uuid.UUID `gorm:"type:uuid;not null"                             json:"createdBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a credential for machine-to-machine integrations. The key ID is
+// the service identity recorded in CreatedBy columns; only a SHA-256 hash of
+// the secret part is stored.
+type APIKey struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name        string     `gorm:"type:varchar(100);not null"                     json:"name"`
+	ServiceName string     `gorm:"type:varchar(100);not null"                     json:"serviceName"`
+	Prefix      string     `gorm:"type:varchar(16);uniqueIndex;not null"          json:"prefix"`
+	SecretHash  string     `gorm:"type:varchar(64);not null"                      json:"-"`
+	Scopes      string     `gorm:"type:varchar(500);not null"                     json:"scopes"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	CreatedBy   uuid.UUID  `gorm:"type:uuid;not null"                             json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
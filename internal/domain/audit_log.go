@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// AuditLog is an append-only record of a single mutation. Rows are written in
+// the same transaction as the change they describe and a database trigger
+// rejects any UPDATE, DELETE or TRUNCATE on the table.
+type AuditLog struct {
+	ID         uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ActorID    string         `gorm:"type:varchar(64);index"                         json:"actorId"`
+	ActorName  string         `gorm:"type:varchar(100)"                              json:"actorName"`
+	ActorType  string         `gorm:"type:varchar(20);not null"                      json:"actorType"`
+	IPAddress  string         `gorm:"type:varchar(64)"                               json:"ipAddress"`
+	EntityType string         `gorm:"type:varchar(50);not null;index:idx_audit_entity" json:"entityType"`
+	EntityID   string         `gorm:"type:varchar(100);not null;index:idx_audit_entity" json:"entityId"`
+	Action     string         `gorm:"type:varchar(50);not null;index"                json:"action"`
+	Before     datatypes.JSON `gorm:"type:jsonb"                                     json:"before,omitempty"`
+	After      datatypes.JSON `gorm:"type:jsonb"                                     json:"after,omitempty"`
+	Changes    datatypes.JSON `gorm:"type:jsonb"                                     json:"changes,omitempty"`
+	CreatedAt  time.Time      `gorm:"index"                                          json:"createdAt"`
+}
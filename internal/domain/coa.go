@@ -16,11 +16,21 @@ const (
 	AccountTypeExpense   AccountType = "expense"
 )
 
+// AccountKind separates summary accounts, which only group children, from
+// detail accounts that can receive journal lines.
+type AccountKind string
+
+const (
+	AccountKindHeader   AccountKind = "header"
+	AccountKindPostable AccountKind = "postable"
+)
+
 type ChartOfAccount struct {
 	Code       string         `gorm:"type:varchar(20);primaryKey"   json:"code"`
 	Name       string         `gorm:"type:varchar(200);not null"    json:"name"`
 	Type       AccountType    `gorm:"type:varchar(20);not null"     json:"type"`
 	ParentCode *string        `gorm:"type:varchar(20);index"        json:"parentCode,omitempty"`
+	Kind       AccountKind    `gorm:"type:varchar(20);not null;default:'postable'" json:"kind"`
 	IsActive   bool           `gorm:"not null;default:true"          json:"isActive"`
 	CreatedAt  time.Time      `json:"createdAt"`
 	UpdatedAt  time.Time      `json:"updatedAt"`
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Dimension is an analytical axis such as cost centre, project or department
+// that journal lines can be tagged with, instead of splitting accounts.
+type Dimension struct {
+	Code      string    `gorm:"type:varchar(20);primaryKey"  json:"code"`
+	Name      string    `gorm:"type:varchar(100);not null"   json:"name"`
+	IsActive  bool      `gorm:"not null;default:true"         json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type DimensionValue struct {
+	DimensionCode string    `gorm:"type:varchar(20);primaryKey" json:"dimensionCode"`
+	Code          string    `gorm:"type:varchar(20);primaryKey" json:"code"`
+	Name          string    `gorm:"type:varchar(100);not null"  json:"name"`
+	IsActive      bool      `gorm:"not null;default:true"        json:"isActive"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// AccountDimension allows a dimension on an account's journal lines and says
+// whether every line must carry it. Dimensions without a row are not allowed
+// on that account.
+type AccountDimension struct {
+	CoaCode       string `gorm:"type:varchar(20);primaryKey" json:"coaCode"`
+	DimensionCode string `gorm:"type:varchar(20);primaryKey" json:"dimensionCode"`
+	Required      bool   `gorm:"not null;default:false"       json:"required"`
+}
+
+// JournalLineDimension tags one journal line with one value per dimension.
+type JournalLineDimension struct {
+	DetailID      string `gorm:"type:uuid;primaryKey"        json:"detailId"`
+	DimensionCode string `gorm:"type:varchar(20);primaryKey;index:idx_line_dimension_value,priority:1" json:"dimensionCode"`
+	ValueCode     string `gorm:"type:varchar(20);not null;index:idx_line_dimension_value,priority:2"  json:"valueCode"`
+}
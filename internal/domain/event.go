@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// OutboxEvent is a domain event, written in the same transaction as the
+// change it describes so it is published if and only if the change commits.
+// Seq gives consumers a total order; DispatchedAt is set once the webhook
+// dispatcher has queued the event's deliveries.
+type OutboxEvent struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Seq          int64          `gorm:"autoIncrement;uniqueIndex;not null"             json:"seq"`
+	Type         string         `gorm:"type:varchar(50);not null;index"                json:"type"`
+	EntityType   string         `gorm:"type:varchar(50);not null"                      json:"entityType"`
+	EntityID     string         `gorm:"type:varchar(100);not null"                     json:"entityId"`
+	Payload      datatypes.JSON `gorm:"type:jsonb"                                     json:"payload"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	DispatchedAt *time.Time     `gorm:"index"                                          json:"dispatchedAt,omitempty"`
+}
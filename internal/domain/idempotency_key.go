@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// IdempotencyKey records a mutating request sent with an Idempotency-Key
+// header so a retry replays the stored response instead of running again.
+// Keys are scoped per caller (user or API key). StatusCode stays 0 while the
+// first request is still running.
+type IdempotencyKey struct {
+	Scope       string    `gorm:"type:varchar(80);primaryKey"  json:"scope"`
+	Key         string    `gorm:"type:varchar(255);primaryKey" json:"key"`
+	RequestHash string    `gorm:"type:varchar(64);not null"    json:"-"`
+	StatusCode  int       `gorm:"not null;default:0"           json:"statusCode"`
+	ContentType string    `gorm:"type:varchar(100)"            json:"contentType"`
+	Response    []byte    `gorm:"type:bytea"                   json:"-"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ExpiresAt   time.Time `gorm:"not null;index"               json:"expiresAt"`
+}
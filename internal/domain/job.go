@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// Job is one unit of background work in the Postgres-backed queue. Workers
+// lease queued jobs whose RunAt has passed; a running job whose LeasedUntil
+// lapses (e.g. its worker died) is leased again. Result holds the optional
+// output, downloadable as ResultName with ResultType as content type.
+type Job struct {
+	ID              uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Type            string         `gorm:"type:varchar(50);not null;index"                json:"type"`
+	Payload         datatypes.JSON `gorm:"type:jsonb"                                     json:"payload"`
+	Status          JobStatus      `gorm:"type:varchar(20);not null;index"                json:"status"`
+	Attempts        int            `gorm:"not null;default:0"                             json:"attempts"`
+	MaxAttempts     int            `gorm:"not null;default:1"                             json:"maxAttempts"`
+	RunAt           time.Time      `gorm:"not null;index"                                 json:"runAt"`
+	LeasedUntil     *time.Time     `json:"leasedUntil,omitempty"`
+	CancelRequested bool           `gorm:"not null;default:false"                         json:"cancelRequested"`
+	Progress        int            `gorm:"not null;default:0"                             json:"progress"`
+	ProgressMessage string         `gorm:"type:varchar(255)"                              json:"progressMessage"`
+	Result          []byte         `gorm:"type:bytea"                                     json:"-"`
+	ResultName      string         `gorm:"type:varchar(255)"                              json:"resultName"`
+	ResultType      string         `gorm:"type:varchar(100)"                              json:"resultType"`
+	Error           string         `gorm:"type:text"                                      json:"error"`
+	CreatedBy       string         `gorm:"type:varchar(64);not null;index"                json:"createdBy"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	StartedAt       *time.Time     `json:"startedAt,omitempty"`
+	FinishedAt      *time.Time     `json:"finishedAt,omitempty"`
+}
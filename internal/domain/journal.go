@@ -14,14 +14,42 @@ const (
 	JournalStatusPosted JournalStatus = "posted"
 )
 
+type JournalType string
+
+const (
+	JournalTypeGeneral    JournalType = "general"
+	JournalTypeOpening    JournalType = "opening"
+	JournalTypeAllocation JournalType = "allocation"
+	JournalTypeRecurring  JournalType = "recurring"
+	JournalTypeReversal   JournalType = "reversal"
+
+	JournalTypeSalesInvoice JournalType = "sales_invoice"
+	JournalTypeReceipt      JournalType = "receipt"
+	JournalTypePurchaseBill JournalType = "purchase_bill"
+	JournalTypePayment      JournalType = "payment"
+)
+
 type JournalEntry struct {
 	ID          uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
 	Date        time.Time      `gorm:"type:date;not null"                             json:"date"`
 	Reference   string         `gorm:"type:varchar(100);uniqueIndex;not null"          json:"reference"`
 	Description string         `gorm:"type:text"                                      json:"description"`
 	Status      JournalStatus  `gorm:"type:varchar(20);not null;default:'draft'"      json:"status"`
+	Type        JournalType    `gorm:"type:varchar(20);not null;default:'general'"    json:"type"`
 	CreatedBy   uuid.UUID      `gorm:"type:uuid;not null"                             json:"createdBy"`
 	CreatedAt   time.Time      `json:"createdAt"`
 	UpdatedAt   time.Time      `json:"updatedAt"`
 	DeletedAt   gorm.DeletedAt `gorm:"index"                                          json:"-"`
+
+	// ReverseOn schedules an automatic reversal once the entry is posted. The
+	// reversal links back through ReversalOfID; at most one live reversal
+	// may exist per entry.
+	ReverseOn    *time.Time `gorm:"type:date"                                                             json:"reverseOn,omitempty"`
+	ReversalOfID *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_journal_reversal_of,where:deleted_at IS NULL" json:"reversalOfId,omitempty"`
+
+	// Tamper-evidence chain, assigned atomically when the entry is posted.
+	// Hash covers the canonical content of the entry plus PrevHash.
+	ChainSeq *int64  `gorm:"uniqueIndex"     json:"chainSeq,omitempty"`
+	PrevHash *string `gorm:"type:varchar(64)" json:"prevHash,omitempty"`
+	Hash     *string `gorm:"type:varchar(64)" json:"hash,omitempty"`
 }
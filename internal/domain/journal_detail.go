@@ -5,11 +5,14 @@ import (
 )
 
 type JournalEntryDetail struct {
-	ID             string         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
-	JournalEntryID string         `gorm:"type:uuid;not null;index"                       json:"journalEntryId"`
-	CoaCode        string         `gorm:"type:varchar(20);not null;index"                json:"coaCode"`
-	Debit          float64        `gorm:"type:numeric(20,2);not null;default:0"          json:"debit"`
-	Credit         float64        `gorm:"type:numeric(20,2);not null;default:0"          json:"credit"`
-	Description    string         `gorm:"type:text"                                      json:"description"`
+	ID             string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	JournalEntryID string  `gorm:"type:uuid;not null;index"                       json:"journalEntryId"`
+	CoaCode        string  `gorm:"type:varchar(20);not null;index"                json:"coaCode"`
+	Debit          float64 `gorm:"type:numeric(20,2);not null;default:0"          json:"debit"`
+	Credit         float64 `gorm:"type:numeric(20,2);not null;default:0"          json:"credit"`
+	Description    string  `gorm:"type:text"                                      json:"description"`
+	// ChainedCoaCode keeps the code a chained line was posted with after a
+	// renumber or merge moves it, so the journal hash chain still verifies.
+	ChainedCoaCode *string        `gorm:"type:varchar(20)"                               json:"-"`
 	DeletedAt      gorm.DeletedAt `gorm:"index"                                          json:"-"`
 }
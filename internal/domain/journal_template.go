@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type Recurrence string
+
+const (
+	// RecurrenceMonthly runs every month on DayOfMonth.
+	RecurrenceMonthly Recurrence = "monthly"
+	// RecurrenceQuarterly runs every third month, counted from the start
+	// date's month, on DayOfMonth.
+	RecurrenceQuarterly Recurrence = "quarterly"
+	// RecurrenceEndOfMonth runs on the last day of every month.
+	RecurrenceEndOfMonth Recurrence = "end_of_month"
+)
+
+// JournalTemplate is a saved journal that the scheduler books on every
+// occurrence of its recurrence between StartDate and EndDate. Lines holds
+// the journal detail lines as JSON. Occurrences missed while the template
+// was paused, i.e. before ResumedAt, are skipped rather than caught up.
+type JournalTemplate struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Name        string         `gorm:"type:varchar(100);not null"                     json:"name"`
+	Description string         `gorm:"type:text"                                      json:"description"`
+	Lines       datatypes.JSON `gorm:"type:jsonb;not null"                            json:"lines"`
+	Recurrence  Recurrence     `gorm:"type:varchar(20);not null"                      json:"recurrence"`
+	DayOfMonth  int            `gorm:"not null;default:1"                             json:"dayOfMonth"`
+	StartDate   time.Time      `gorm:"type:date;not null"                             json:"startDate"`
+	EndDate     *time.Time     `gorm:"type:date"                                      json:"endDate,omitempty"`
+	AutoPost    bool           `gorm:"not null;default:false"                         json:"autoPost"`
+	IsPaused    bool           `gorm:"not null;default:false"                         json:"isPaused"`
+	ResumedAt   *time.Time     `gorm:"type:date"                                      json:"resumedAt,omitempty"`
+	LastError   string         `gorm:"type:text"                                      json:"lastError,omitempty"`
+	CreatedBy   uuid.UUID      `gorm:"type:uuid;not null"                             json:"createdBy"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"                                          json:"-"`
+}
+
+// JournalTemplateRun marks one occurrence of a template as booked. The
+// unique (template, date) pair keeps the scheduler idempotent.
+type JournalTemplateRun struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"        json:"id"`
+	TemplateID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_template_run_date"  json:"templateId"`
+	RunDate        time.Time  `gorm:"type:date;not null;uniqueIndex:idx_template_run_date"  json:"runDate"`
+	JournalEntryID *uuid.UUID `gorm:"type:uuid"                                             json:"journalEntryId,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
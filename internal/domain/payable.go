@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Vendor is an accounts payable party. PaymentTermDays sets the default
+// due date of its bills.
+type Vendor struct {
+	ID              uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"                   json:"id"`
+	Code            string         `gorm:"type:varchar(20);not null;uniqueIndex:idx_vendor_code,where:deleted_at IS NULL" json:"code"`
+	Name            string         `gorm:"type:varchar(150);not null"                                       json:"name"`
+	Email           string         `gorm:"type:varchar(150)"                                                json:"email"`
+	PaymentTermDays int            `gorm:"not null;default:30"                                              json:"paymentTermDays"`
+	IsActive        bool           `gorm:"not null;default:true"                                            json:"isActive"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index"                                                            json:"-"`
+}
+
+type PurchaseBillStatus string
+
+const (
+	PurchaseBillStatusDraft PurchaseBillStatus = "draft"
+	// PurchaseBillStatusOpen is a posted bill with an amount still owed.
+	PurchaseBillStatusOpen PurchaseBillStatus = "open"
+	PurchaseBillStatusPaid PurchaseBillStatus = "paid"
+)
+
+// PurchaseBill is an open item owed to a vendor once posted: it debits the
+// expense or asset lines and credits the AP control account for Total.
+// VendorReference is the vendor's own invoice number. AmountPaid is the sum
+// of posted payment allocations.
+type PurchaseBill struct {
+	ID              uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Number          string             `gorm:"type:varchar(30);uniqueIndex;not null"          json:"number"`
+	VendorID        uuid.UUID          `gorm:"type:uuid;not null;index"                       json:"vendorId"`
+	Date            time.Time          `gorm:"type:date;not null"                             json:"date"`
+	DueDate         time.Time          `gorm:"type:date;not null;index"                       json:"dueDate"`
+	VendorReference string             `gorm:"type:varchar(50)"                               json:"vendorReference"`
+	Description     string             `gorm:"type:text"                                      json:"description"`
+	Status          PurchaseBillStatus `gorm:"type:varchar(20);not null;index"                json:"status"`
+	Total           float64            `gorm:"type:numeric(20,2);not null;default:0"          json:"total"`
+	AmountPaid      float64            `gorm:"type:numeric(20,2);not null;default:0"          json:"amountPaid"`
+	JournalEntryID  *uuid.UUID         `gorm:"type:uuid"                                      json:"journalEntryId,omitempty"`
+	CreatedBy       uuid.UUID          `gorm:"type:uuid;not null"                             json:"createdBy"`
+	PostedAt        *time.Time         `json:"postedAt,omitempty"`
+	CreatedAt       time.Time          `json:"createdAt"`
+	UpdatedAt       time.Time          `json:"updatedAt"`
+}
+
+// PurchaseBillLine debits an expense or asset account, optionally tagged
+// with dimension values carried over to the journal line.
+type PurchaseBillLine struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	BillID      uuid.UUID      `gorm:"type:uuid;not null;index"                       json:"billId"`
+	Seq         int            `gorm:"not null"                                       json:"seq"`
+	CoaCode     string         `gorm:"type:varchar(20);not null"                      json:"coaCode"`
+	Description string         `gorm:"type:text"                                      json:"description"`
+	Amount      float64        `gorm:"type:numeric(20,2);not null"                    json:"amount"`
+	Dimensions  datatypes.JSON `gorm:"type:jsonb"                                     json:"dimensions"`
+}
+
+type PaymentStatus string
+
+const (
+	PaymentStatusDraft  PaymentStatus = "draft"
+	PaymentStatusPosted PaymentStatus = "posted"
+)
+
+// Payment is money paid to a vendor out of BankCoaCode. It is fully
+// allocated to the vendor's bills; posting debits the AP control account
+// and settles the allocated amounts.
+type Payment struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Number         string        `gorm:"type:varchar(30);uniqueIndex;not null"          json:"number"`
+	VendorID       uuid.UUID     `gorm:"type:uuid;not null;index"                       json:"vendorId"`
+	Date           time.Time     `gorm:"type:date;not null"                             json:"date"`
+	Amount         float64       `gorm:"type:numeric(20,2);not null"                    json:"amount"`
+	BankCoaCode    string        `gorm:"type:varchar(20);not null"                      json:"bankCoaCode"`
+	Description    string        `gorm:"type:text"                                      json:"description"`
+	Status         PaymentStatus `gorm:"type:varchar(20);not null;index"                json:"status"`
+	JournalEntryID *uuid.UUID    `gorm:"type:uuid"                                      json:"journalEntryId,omitempty"`
+	CreatedBy      uuid.UUID     `gorm:"type:uuid;not null"                             json:"createdBy"`
+	PostedAt       *time.Time    `json:"postedAt,omitempty"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+}
+
+// PaymentAllocation applies part of a payment to one bill.
+type PaymentAllocation struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	PaymentID uuid.UUID `gorm:"type:uuid;not null;index"                       json:"paymentId"`
+	BillID    uuid.UUID `gorm:"type:uuid;not null;index"                       json:"billId"`
+	Amount    float64   `gorm:"type:numeric(20,2);not null"                    json:"amount"`
+}
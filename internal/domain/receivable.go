@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Customer is an accounts receivable party. PaymentTermDays sets the
+// default due date of its invoices.
+type Customer struct {
+	ID              uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"                   json:"id"`
+	Code            string         `gorm:"type:varchar(20);not null;uniqueIndex:idx_customer_code,where:deleted_at IS NULL" json:"code"`
+	Name            string         `gorm:"type:varchar(150);not null"                                       json:"name"`
+	Email           string         `gorm:"type:varchar(150)"                                                json:"email"`
+	PaymentTermDays int            `gorm:"not null;default:30"                                              json:"paymentTermDays"`
+	IsActive        bool           `gorm:"not null;default:true"                                            json:"isActive"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index"                                                            json:"-"`
+}
+
+type SalesInvoiceStatus string
+
+const (
+	SalesInvoiceStatusDraft SalesInvoiceStatus = "draft"
+	// SalesInvoiceStatusOpen is a posted invoice with an amount still owed.
+	SalesInvoiceStatusOpen SalesInvoiceStatus = "open"
+	SalesInvoiceStatusPaid SalesInvoiceStatus = "paid"
+)
+
+// SalesInvoice is an open item of a customer once posted: it debits the AR
+// control account for Total and credits the revenue lines. AmountPaid is
+// the sum of posted receipt allocations.
+type SalesInvoice struct {
+	ID             uuid.UUID          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Number         string             `gorm:"type:varchar(30);uniqueIndex;not null"          json:"number"`
+	CustomerID     uuid.UUID          `gorm:"type:uuid;not null;index"                       json:"customerId"`
+	Date           time.Time          `gorm:"type:date;not null"                             json:"date"`
+	DueDate        time.Time          `gorm:"type:date;not null;index"                       json:"dueDate"`
+	Description    string             `gorm:"type:text"                                      json:"description"`
+	Status         SalesInvoiceStatus `gorm:"type:varchar(20);not null;index"                json:"status"`
+	Total          float64            `gorm:"type:numeric(20,2);not null;default:0"          json:"total"`
+	AmountPaid     float64            `gorm:"type:numeric(20,2);not null;default:0"          json:"amountPaid"`
+	JournalEntryID *uuid.UUID         `gorm:"type:uuid"                                      json:"journalEntryId,omitempty"`
+	CreatedBy      uuid.UUID          `gorm:"type:uuid;not null"                             json:"createdBy"`
+	PostedAt       *time.Time         `json:"postedAt,omitempty"`
+	CreatedAt      time.Time          `json:"createdAt"`
+	UpdatedAt      time.Time          `json:"updatedAt"`
+}
+
+// SalesInvoiceLine credits a revenue account, optionally tagged with
+// dimension values carried over to the journal line.
+type SalesInvoiceLine struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	InvoiceID   uuid.UUID      `gorm:"type:uuid;not null;index"                       json:"invoiceId"`
+	Seq         int            `gorm:"not null"                                       json:"seq"`
+	CoaCode     string         `gorm:"type:varchar(20);not null"                      json:"coaCode"`
+	Description string         `gorm:"type:text"                                      json:"description"`
+	Amount      float64        `gorm:"type:numeric(20,2);not null"                    json:"amount"`
+	Dimensions  datatypes.JSON `gorm:"type:jsonb"                                     json:"dimensions"`
+}
+
+type ReceiptStatus string
+
+const (
+	ReceiptStatusDraft  ReceiptStatus = "draft"
+	ReceiptStatusPosted ReceiptStatus = "posted"
+)
+
+// Receipt is money received from a customer into BankCoaCode. It is fully
+// allocated to the customer's invoices; posting credits the AR control
+// account and settles the allocated amounts.
+type Receipt struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	Number         string        `gorm:"type:varchar(30);uniqueIndex;not null"          json:"number"`
+	CustomerID     uuid.UUID     `gorm:"type:uuid;not null;index"                       json:"customerId"`
+	Date           time.Time     `gorm:"type:date;not null"                             json:"date"`
+	Amount         float64       `gorm:"type:numeric(20,2);not null"                    json:"amount"`
+	BankCoaCode    string        `gorm:"type:varchar(20);not null"                      json:"bankCoaCode"`
+	Description    string        `gorm:"type:text"                                      json:"description"`
+	Status         ReceiptStatus `gorm:"type:varchar(20);not null;index"                json:"status"`
+	JournalEntryID *uuid.UUID    `gorm:"type:uuid"                                      json:"journalEntryId,omitempty"`
+	CreatedBy      uuid.UUID     `gorm:"type:uuid;not null"                             json:"createdBy"`
+	PostedAt       *time.Time    `json:"postedAt,omitempty"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+}
+
+// ReceiptAllocation applies part of a receipt to one invoice.
+type ReceiptAllocation struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	ReceiptID uuid.UUID `gorm:"type:uuid;not null;index"                       json:"receiptId"`
+	InvoiceID uuid.UUID `gorm:"type:uuid;not null;index"                       json:"invoiceId"`
+	Amount    float64   `gorm:"type:numeric(20,2);not null"                    json:"amount"`
+}
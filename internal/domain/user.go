@@ -16,4 +16,11 @@ type User struct {
 	CreatedAt time.Time      `json:"createdAt"`
 	UpdatedAt time.Time      `json:"updatedAt"`
 	DeletedAt gorm.DeletedAt `gorm:"index"                                          json:"-"`
+
+	TOTPSecret   string `gorm:"column:totp_secret;type:varchar(64)"                json:"-"`
+	TOTPEnabled  bool   `gorm:"column:totp_enabled;not null;default:false"         json:"totpEnabled"`
+	TOTPLastStep int64  `gorm:"column:totp_last_step;not null;default:0"           json:"-"`
+
+	// OIDCSubject links the user to an identity provider account ("sub" claim).
+	OIDCSubject *string `gorm:"column:oidc_subject;type:varchar(255);uniqueIndex" json:"-"`
 }
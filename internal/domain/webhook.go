@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WebhookEndpoint receives outbox events over HTTP. Requests are signed with
+// Secret. EventTypes lists the subscribed event types; empty means all.
+type WebhookEndpoint struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	URL         string         `gorm:"type:text;not null"                             json:"url"`
+	Description string         `gorm:"type:varchar(255)"                              json:"description"`
+	Secret      string         `gorm:"type:varchar(100);not null"                     json:"-"`
+	EventTypes  datatypes.JSON `gorm:"type:jsonb"                                     json:"eventTypes"`
+	IsActive    bool           `gorm:"not null;default:true"                          json:"isActive"`
+	CreatedBy   string         `gorm:"type:varchar(64);not null"                      json:"createdBy"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	DeletedAt   gorm.DeletedAt `gorm:"index"                                          json:"-"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryDead marks a delivery that ran out of attempts. It
+	// stays in the dead-letter list until it is redelivered.
+	WebhookDeliveryDead WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event sent to one endpoint.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"              json:"id"`
+	EndpointID     uuid.UUID             `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_event"  json:"endpointId"`
+	EventID        uuid.UUID             `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_event"  json:"eventId"`
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);not null;index"                             json:"status"`
+	Attempts       int                   `gorm:"not null;default:0"                                          json:"attempts"`
+	NextAttemptAt  time.Time             `gorm:"not null;index"                                              json:"nextAttemptAt"`
+	LastStatusCode int                   `gorm:"not null;default:0"                                          json:"lastStatusCode"`
+	LastError      string                `gorm:"type:text"                                                   json:"lastError"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	UpdatedAt      time.Time             `json:"updatedAt"`
+}
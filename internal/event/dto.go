@@ -0,0 +1,25 @@
+package event
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// StreamQuery filters the event stream. Types is a comma-separated list of
+// event types; Account limits it to events touching one COA code.
+type StreamQuery struct {
+	Types   string `query:"types"`
+	Account string `query:"account"`
+}
+
+// StreamEvent is the data of one Server-Sent Event. Seq is also sent as the
+// SSE id, which clients echo back in Last-Event-ID to resume.
+type StreamEvent struct {
+	ID         string          `json:"id"`
+	Seq        int64           `json:"seq"`
+	Type       string          `json:"type"`
+	EntityType string          `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+}
@@ -0,0 +1,87 @@
+// Package event writes domain events to the transactional outbox and
+// streams them to clients over Server-Sent Events.
+package event
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	TypeJournalCreated  = "journal.created"
+	TypeJournalPosted   = "journal.posted"
+	TypeJournalReversed = "journal.reversed"
+	TypeCOAUpdated      = "coa.updated"
+	TypePeriodClosed    = "period.closed"
+)
+
+// Types lists every event type, e.g. to validate webhook subscriptions.
+var Types = []string{
+	TypeJournalCreated,
+	TypeJournalPosted,
+	TypeJournalReversed,
+	TypeCOAUpdated,
+	TypePeriodClosed,
+}
+
+// COAChange is the payload of coa.updated. Action is the audit action that
+// changed the account (create, update, delete, renumber, merge, restore).
+type COAChange struct {
+	Code   string `json:"code"`
+	Action string `json:"action"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// JournalReversal is the payload of journal.reversed.
+type JournalReversal struct {
+	JournalID         string `json:"journalId"`
+	Reference         string `json:"reference"`
+	ReversalID        string `json:"reversalId"`
+	ReversalReference string `json:"reversalReference"`
+	Date              string `json:"date"`
+}
+
+// PeriodClosure is the payload of period.closed.
+type PeriodClosure struct {
+	Period    string `json:"period"`
+	JournalID string `json:"journalId,omitempty"`
+	Date      string `json:"date"`
+}
+
+// Channel is the Postgres NOTIFY channel carrying the seq of each new
+// event, so every API instance can stream it.
+const Channel = "outbox_events"
+
+// Publish writes an event to the outbox inside tx, so it is only published
+// when the surrounding change commits. Listeners on Channel are notified on
+// commit too.
+func Publish(tx *gorm.DB, eventType, entityType, entityID string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to publish event: "+err.Error())
+	}
+
+	var seq int64
+	if err := tx.Raw(
+		`INSERT INTO outbox_events (id, type, entity_type, entity_id, payload, created_at)
+		 VALUES (gen_random_uuid(), ?, ?, ?, ?, NOW())
+		 RETURNING seq`,
+		eventType, entityType, entityID, payload,
+	).Scan(&seq).Error; err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to publish event: "+err.Error())
+	}
+
+	if err := tx.Exec(`SELECT pg_notify(?, ?)`, Channel, strconv.FormatInt(seq, 10)).Error; err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to publish event: "+err.Error())
+	}
+	return nil
+}
+
+// PublishCOAChange publishes coa.updated for one account.
+func PublishCOAChange(tx *gorm.DB, code, action string, before, after any) error {
+	return Publish(tx, TypeCOAUpdated, "coa", code, COAChange{Code: code, Action: action, Before: before, After: after})
+}
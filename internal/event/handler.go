@@ -0,0 +1,213 @@
+package event
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// retryMillis is the reconnect delay suggested to EventSource clients.
+const retryMillis = 5000
+
+type Handler struct {
+	hub *Hub
+}
+
+func NewHandler(hub *Hub) *Handler {
+	return &Handler{hub: hub}
+}
+
+// filter decides which events a stream receives.
+type filter struct {
+	types   []string
+	account string
+}
+
+// newFilter parses the stream query. API keys without the coa:read scope
+// do not see coa.updated.
+func newFilter(query *StreamQuery, canReadCOA bool) (*filter, error) {
+	visible := slices.Clone(Types)
+	if !canReadCOA {
+		visible = slices.DeleteFunc(visible, func(t string) bool { return t == TypeCOAUpdated })
+	}
+
+	f := &filter{types: visible, account: strings.TrimSpace(query.Account)}
+	if query.Types == "" {
+		return f, nil
+	}
+
+	f.types = nil
+	for _, t := range strings.Split(query.Types, ",") {
+		t = strings.TrimSpace(t)
+		if !slices.Contains(Types, t) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Unknown event type: "+t)
+		}
+		if !slices.Contains(visible, t) {
+			return nil, fiber.NewError(fiber.StatusForbidden, "API key is missing the required scope: "+apikey.ScopeCOARead)
+		}
+		f.types = append(f.types, t)
+	}
+	return f, nil
+}
+
+func (f *filter) match(e StreamEvent) bool {
+	if !slices.Contains(f.types, e.Type) {
+		return false
+	}
+	if f.account == "" {
+		return true
+	}
+
+	switch e.Type {
+	case TypeCOAUpdated:
+		return e.EntityID == f.account
+	case TypeJournalCreated, TypeJournalPosted:
+		var journal struct {
+			Details []struct {
+				CoaCode string `json:"coaCode"`
+			} `json:"details"`
+		}
+		if err := json.Unmarshal(e.Data, &journal); err != nil {
+			return false
+		}
+		for _, d := range journal.Details {
+			if d.CoaCode == f.account {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeEvent(w *bufio.Writer, e StreamEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Seq, e.Type, data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// Stream godoc
+// @Summary      Stream domain events
+// @Description  Opens a Server-Sent Events stream of journal, COA and period events as they are committed. Each event carries its sequence number as the SSE id; reconnect with the Last-Event-ID header (or lastEventId query) to receive what was missed. Ids follow insertion, not commit order, so a resume also resends the last 100 events before that id; skip ids already handled. A comment line is sent as a heartbeat while idle. The account filter matches coa.updated for that code and journal.created/journal.posted with a line on it.
+// @Tags         Events
+// @Produce      text/event-stream
+// @Param        types        query   string  false  "Comma-separated event types, e.g. journal.posted,coa.updated"
+// @Param        account      query   string  false  "COA code"
+// @Param        lastEventId  query   int     false  "Resume after this event id"
+// @Param        Last-Event-ID header int     false  "Resume after this event id"
+// @Success      200  {object}  StreamEvent
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /events/stream [get]
+func (h *Handler) Stream(c *fiber.Ctx) error {
+	var query StreamQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	canReadCOA := true
+	if c.Locals("authType") == middleware.AuthTypeAPIKey {
+		scopes, _ := c.Locals("scopes").([]string)
+		canReadCOA = slices.Contains(scopes, apikey.ScopeCOARead)
+	}
+	f, err := newFilter(&query, canReadCOA)
+	if err != nil {
+		return err
+	}
+
+	lastID := c.Get("Last-Event-ID", c.Query("lastEventId"))
+	var after int64
+	resume := lastID != ""
+	if resume {
+		if after, err = strconv.ParseInt(lastID, 10, 64); err != nil || after < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Last-Event-ID must be an event id")
+		}
+	}
+
+	heartbeat := time.Duration(max(config.AppConfig.SSEHeartbeatSeconds, 1)) * time.Second
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		h.stream(w, f, resume, after, heartbeat)
+	})
+	return nil
+}
+
+// stream writes events until the client goes away. It subscribes before
+// replaying missed events so nothing committed in between is lost, and
+// skips events it has already sent. A resume replays the window below the
+// client's last id too: an event can commit after one with a higher seq,
+// so the client may not have seen it yet.
+func (h *Handler) stream(w *bufio.Writer, f *filter, resume bool, after int64, heartbeat time.Duration) {
+	events, cancel := h.hub.Subscribe()
+	defer cancel()
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", retryMillis); err != nil {
+		return
+	}
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	sent := newSeqWindow(after)
+	if resume {
+		err := h.hub.Replay(sent.floor(), func(e StreamEvent) error {
+			if !sent.add(e.Seq) || !f.match(e) {
+				return nil
+			}
+			return writeEvent(w, e)
+		})
+		if err != nil {
+			log.Printf("event stream: replay: %v", err)
+			return
+		}
+	}
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				// Dropped for falling behind, or shutting down; the
+				// client reconnects with Last-Event-ID.
+				return
+			}
+			if !sent.add(e.Seq) || !f.match(e) {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := w.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
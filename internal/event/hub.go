@@ -0,0 +1,271 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+const (
+	// subscriberBuffer is how many events a slow stream may fall behind
+	// before it is dropped. The client then reconnects with Last-Event-ID.
+	subscriberBuffer = 256
+
+	// replayPageSize is how many outbox rows are read per query when
+	// catching up.
+	replayPageSize = 500
+
+	// resumeWindow is how far below a resume point events are replayed.
+	// seq is taken when an event is inserted, not when it commits, so an
+	// event can commit after one with a higher seq; resuming strictly after
+	// the last seq seen would skip it.
+	resumeWindow = 100
+
+	reconnectDelay = 5 * time.Second
+)
+
+// seqWindow remembers the seqs handled within resumeWindow of the highest
+// one, so events replayed again are dropped.
+type seqWindow struct {
+	seen map[int64]struct{}
+	top  int64
+}
+
+func newSeqWindow(top int64) *seqWindow {
+	return &seqWindow{seen: map[int64]struct{}{}, top: top}
+}
+
+// floor is where a replay covering the window starts.
+func (w *seqWindow) floor() int64 {
+	return max(w.top-resumeWindow, 0)
+}
+
+// add records seq and reports whether it is new. A seq below the window is
+// never replayed, so it is always new.
+func (w *seqWindow) add(seq int64) bool {
+	if _, ok := w.seen[seq]; ok {
+		return false
+	}
+	w.seen[seq] = struct{}{}
+	w.top = max(w.top, seq)
+
+	if len(w.seen) > 2*resumeWindow {
+		for s := range w.seen {
+			if s < w.floor() {
+				delete(w.seen, s)
+			}
+		}
+	}
+	return true
+}
+
+// Hub listens for NOTIFY on Channel and fans new events out to the streams
+// open on this instance. Every instance runs its own Hub, so a change
+// committed through any of them reaches every client.
+type Hub struct {
+	db   *gorm.DB
+	repo Repository
+
+	mu   sync.Mutex
+	subs map[chan StreamEvent]struct{}
+	// seen holds the seqs broadcast lately, so a catch-up after a lost
+	// connection can replay the window below the last one without sending
+	// any twice.
+	seen *seqWindow
+}
+
+func NewHub(db *gorm.DB) *Hub {
+	return newHub(db, NewRepository(db))
+}
+
+func newHub(db *gorm.DB, repo Repository) *Hub {
+	return &Hub{db: db, repo: repo, subs: map[chan StreamEvent]struct{}{}, seen: newSeqWindow(0)}
+}
+
+// Subscribe registers a stream. The channel is closed when the stream falls
+// too far behind or the hub stops; cancel unregisters it.
+func (h *Hub) Subscribe() (events <-chan StreamEvent, cancel func()) {
+	ch := make(chan StreamEvent, subscriberBuffer)
+
+	h.mu.Lock()
+	h.subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[ch]; ok {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+func (h *Hub) broadcast(e StreamEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.seen.add(e.Seq) {
+		return
+	}
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Start listens until the returned stop function is called. A lost
+// connection is re-established, and events committed meanwhile are
+// replayed from the outbox.
+func (h *Hub) Start() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		if err := h.seed(); err != nil {
+			log.Printf("event hub: seed: %v", err)
+		}
+
+		for {
+			if err := h.listen(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("event hub: listen: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(reconnectDelay):
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		for ch := range h.subs {
+			delete(h.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// listen holds one pooled connection in LISTEN mode until ctx is done or
+// the connection fails.
+func (h *Hub) listen(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("database driver does not support LISTEN")
+		}
+		pgConn := stdConn.Conn()
+
+		if _, err := pgConn.Exec(ctx, "LISTEN "+Channel); err != nil {
+			return err
+		}
+		defer func() {
+			// The connection goes back to the pool; stop queueing
+			// notifications on it.
+			_, _ = pgConn.Exec(context.Background(), "UNLISTEN "+Channel)
+		}()
+
+		if err := h.catchUp(); err != nil {
+			return err
+		}
+
+		for {
+			n, err := pgConn.WaitForNotification(ctx)
+			if err != nil {
+				return err
+			}
+			seq, err := strconv.ParseInt(n.Payload, 10, 64)
+			if err != nil {
+				continue
+			}
+			e, err := h.repo.FindBySeq(seq)
+			if err != nil {
+				return err
+			}
+			if e != nil {
+				h.broadcast(*e)
+			}
+		}
+	})
+}
+
+// seed marks the events already in the outbox as seen, so the first
+// catch-up only broadcasts what is committed from now on.
+func (h *Hub) seed() error {
+	seq, err := h.repo.LastSeq()
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	h.seen = newSeqWindow(seq)
+	from := h.seen.floor()
+	h.mu.Unlock()
+
+	return h.Replay(from, func(e StreamEvent) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.seen.add(e.Seq)
+		return nil
+	})
+}
+
+// catchUp broadcasts events committed while the listening connection was
+// down. It replays the window below the last seq seen, since an event can
+// commit after a later one, and broadcast drops those already sent.
+func (h *Hub) catchUp() error {
+	h.mu.Lock()
+	from := h.seen.floor()
+	h.mu.Unlock()
+
+	return h.Replay(from, func(e StreamEvent) error {
+		h.broadcast(e)
+		return nil
+	})
+}
+
+// Replay calls fn for every event after seq, in order, until fn fails.
+func (h *Hub) Replay(seq int64, fn func(StreamEvent) error) error {
+	for {
+		events, err := h.repo.FindAfter(seq, replayPageSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := fn(e); err != nil {
+				return err
+			}
+			seq = e.Seq
+		}
+		if len(events) < replayPageSize {
+			return nil
+		}
+	}
+}
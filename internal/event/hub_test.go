@@ -0,0 +1,220 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// outbox is an in-memory Repository. Seqs are taken by insert and only
+// become visible on commit, as in Postgres.
+type outbox struct {
+	mu        sync.Mutex
+	next      int64
+	committed map[int64]StreamEvent
+}
+
+func newOutbox() *outbox {
+	return &outbox{committed: map[int64]StreamEvent{}}
+}
+
+// insert takes the next seq for an event that is not committed yet.
+func (o *outbox) insert() int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.next++
+	return o.next
+}
+
+func (o *outbox) commit(seq int64) StreamEvent {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	e := StreamEvent{Seq: seq, Type: TypeJournalPosted, EntityType: "journal", CreatedAt: time.Now()}
+	o.committed[seq] = e
+	return e
+}
+
+func (o *outbox) FindBySeq(seq int64) (*StreamEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if e, ok := o.committed[seq]; ok {
+		return &e, nil
+	}
+	return nil, nil
+}
+
+func (o *outbox) FindAfter(seq int64, limit int) ([]StreamEvent, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var events []StreamEvent
+	for s, e := range o.committed {
+		if s > seq {
+			events = append(events, e)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Seq < events[j].Seq })
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (o *outbox) LastSeq() (int64, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var last int64
+	for s := range o.committed {
+		last = max(last, s)
+	}
+	return last, nil
+}
+
+// waitSubscribed waits until a stream has subscribed to h.
+func waitSubscribed(t *testing.T, h *Hub) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		h.mu.Lock()
+		n := len(h.subs)
+		h.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stream never subscribed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// closeSubs ends every stream, as stopping the hub does.
+func closeSubs(h *Hub) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		delete(h.subs, ch)
+		close(ch)
+	}
+}
+
+// ids lists the SSE ids written to a stream.
+func ids(out string) []string {
+	var ids []string
+	for _, line := range strings.Split(out, "\n") {
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestStreamResumeReplaysEventCommittedOutOfOrder(t *testing.T) {
+	repo := newOutbox()
+	hub := newHub(nil, repo)
+	h := NewHandler(hub)
+	f, err := newFilter(&StreamQuery{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two transactions insert events 1 and 2; 2 commits first and the
+	// client sees it, then disconnects before 1 commits.
+	first, second := repo.insert(), repo.insert()
+	repo.commit(second)
+	repo.commit(first)
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.stream(bufio.NewWriter(&buf), f, true, second, time.Hour)
+	}()
+	waitSubscribed(t, hub)
+	closeSubs(hub)
+	<-done
+
+	got := ids(buf.String())
+	if len(got) != 2 || got[0] != "1" || got[1] != "2" {
+		t.Fatalf("resume after %d sent ids %v, want [1 2]", second, got)
+	}
+}
+
+func TestStreamSkipsLiveEventAlreadyReplayed(t *testing.T) {
+	repo := newOutbox()
+	hub := newHub(nil, repo)
+	h := NewHandler(hub)
+	f, _ := newFilter(&StreamQuery{}, true)
+
+	first, second := repo.insert(), repo.insert()
+	repo.commit(second)
+	late := repo.commit(first)
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.stream(bufio.NewWriter(&buf), f, true, second, time.Hour)
+	}()
+
+	// The NOTIFY of the late commit reaches the stream after its replay.
+	waitSubscribed(t, hub)
+	hub.broadcast(late)
+	closeSubs(hub)
+	<-done
+
+	if got := ids(buf.String()); len(got) != 2 {
+		t.Fatalf("sent ids %v, want each of 1 and 2 once", got)
+	}
+}
+
+func TestHubCatchUpBroadcastsEventCommittedOutOfOrder(t *testing.T) {
+	repo := newOutbox()
+	hub := newHub(nil, repo)
+
+	events, cancel := hub.Subscribe()
+	defer cancel()
+
+	// Event 2 commits and is broadcast; the listening connection drops
+	// before event 1 commits.
+	first, second := repo.insert(), repo.insert()
+	hub.broadcast(repo.commit(second))
+	repo.commit(first)
+
+	if err := hub.catchUp(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int64
+	for len(events) > 0 {
+		got = append(got, (<-events).Seq)
+	}
+	if len(got) != 2 || got[0] != second || got[1] != first {
+		t.Fatalf("broadcast seqs %v, want [%d %d]", got, second, first)
+	}
+}
+
+func TestHubSeedSkipsExistingEvents(t *testing.T) {
+	repo := newOutbox()
+	hub := newHub(nil, repo)
+	repo.commit(repo.insert())
+
+	if err := hub.seed(); err != nil {
+		t.Fatal(err)
+	}
+	events, cancel := hub.Subscribe()
+	defer cancel()
+
+	next := repo.insert()
+	repo.commit(next)
+	if err := hub.catchUp(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 || (<-events).Seq != next {
+		t.Fatalf("catch-up after seed should only broadcast seq %d", next)
+	}
+}
@@ -0,0 +1,48 @@
+package event
+
+import "gorm.io/gorm"
+
+type Repository interface {
+	FindBySeq(seq int64) (*StreamEvent, error)
+	FindAfter(seq int64, limit int) ([]StreamEvent, error)
+	LastSeq() (int64, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+const streamColumns = `id, seq, type, entity_type, entity_id, created_at, payload AS data`
+
+func (r *repository) FindBySeq(seq int64) (*StreamEvent, error) {
+	var e StreamEvent
+	result := r.db.Raw(`SELECT `+streamColumns+` FROM outbox_events WHERE seq = ? LIMIT 1`, seq).Scan(&e)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+// FindAfter lists up to limit events with a seq above the given one, in
+// order.
+func (r *repository) FindAfter(seq int64, limit int) ([]StreamEvent, error) {
+	var events []StreamEvent
+	err := r.db.Raw(
+		`SELECT `+streamColumns+` FROM outbox_events WHERE seq > ? ORDER BY seq LIMIT ?`,
+		seq, limit,
+	).Scan(&events).Error
+	return events, err
+}
+
+func (r *repository) LastSeq() (int64, error) {
+	var seq int64
+	err := r.db.Raw(`SELECT COALESCE(MAX(seq), 0) FROM outbox_events`).Scan(&seq).Error
+	return seq, err
+}
@@ -0,0 +1,15 @@
+package event
+
+import (
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler) {
+	eventRoutes := router.Group("/events")
+	eventRoutes.Use(middleware.AuthMiddleware(), middleware.RequireScope(apikey.ScopeJournalRead))
+
+	eventRoutes.Get("/stream", handler.Stream)
+}
@@ -0,0 +1,70 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type JobQuery struct {
+	Page   int    `query:"page"`
+	Limit  int    `query:"limit"`
+	Status string `query:"status"`
+	Type   string `query:"type"`
+}
+
+// JobResponse is the status of a job. Result is inlined for JSON results;
+// other results are downloaded from GET /jobs/{id}/result.
+type JobResponse struct {
+	ID              string          `json:"id"`
+	Type            string          `json:"type"`
+	Status          string          `json:"status"`
+	Attempts        int             `json:"attempts"`
+	MaxAttempts     int             `json:"maxAttempts"`
+	Progress        int             `json:"progress"`
+	ProgressMessage string          `json:"progressMessage,omitempty"`
+	CancelRequested bool            `json:"cancelRequested"`
+	Error           string          `json:"error,omitempty"`
+	HasResult       bool            `json:"hasResult"`
+	ResultName      string          `json:"resultName,omitempty"`
+	Result          json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	CreatedBy       string          `json:"createdBy"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	RunAt           time.Time       `json:"runAt"`
+	StartedAt       *time.Time      `json:"startedAt,omitempty"`
+	FinishedAt      *time.Time      `json:"finishedAt,omitempty"`
+}
+
+// JobRow is a job without its payload and result bytes, as listed.
+type JobRow struct {
+	ID              string     `gorm:"column:id"`
+	Type            string     `gorm:"column:type"`
+	Status          string     `gorm:"column:status"`
+	Attempts        int        `gorm:"column:attempts"`
+	MaxAttempts     int        `gorm:"column:max_attempts"`
+	Progress        int        `gorm:"column:progress"`
+	ProgressMessage string     `gorm:"column:progress_message"`
+	CancelRequested bool       `gorm:"column:cancel_requested"`
+	Error           string     `gorm:"column:error"`
+	HasResult       bool       `gorm:"column:has_result"`
+	ResultName      string     `gorm:"column:result_name"`
+	CreatedBy       string     `gorm:"column:created_by"`
+	CreatedAt       time.Time  `gorm:"column:created_at"`
+	RunAt           time.Time  `gorm:"column:run_at"`
+	StartedAt       *time.Time `gorm:"column:started_at"`
+	FinishedAt      *time.Time `gorm:"column:finished_at"`
+}
+
+// Swagger Responses
+
+type SwaggerJobResponse struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    JobResponse `json:"data"`
+}
+
+type SwaggerJobListResponse struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    []JobResponse `json:"data"`
+	Meta    any           `json:"meta,omitempty"`
+}
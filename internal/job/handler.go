@@ -0,0 +1,147 @@
+package job
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func jobID(c *fiber.Ctx) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "Invalid job ID")
+	}
+	return id, nil
+}
+
+func isAdmin(c *fiber.Ctx) bool {
+	role, _ := c.Locals("role").(string)
+	return role == "admin"
+}
+
+// GetAll godoc
+// @Summary      List background jobs
+// @Description  Returns background jobs newest first. Admins see every job, other callers only their own.
+// @Tags         Jobs
+// @Produce      json
+// @Param        page    query  int     false  "Page number"    minimum(1)
+// @Param        limit   query  int     false  "Items per page" minimum(1) maximum(100)
+// @Param        status  query  string  false  "Status (queued, running, succeeded, failed, cancelled)"
+// @Param        type    query  string  false  "Job type, e.g. journal.bulk"
+// @Success      200  {object}  SwaggerJobListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /jobs [get]
+func (h *Handler) GetAll(c *fiber.Ctx) error {
+	var query JobQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 || query.Limit > 100 {
+		query.Limit = 10
+	}
+
+	jobs, meta, err := h.service.GetAll(&query, audit.ActorFromCtx(c), isAdmin(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get all jobs", jobs, meta)
+}
+
+// GetByID godoc
+// @Summary      Get job status
+// @Description  Returns the status and progress of a background job. JSON results are included inline.
+// @Tags         Jobs
+// @Produce      json
+// @Param        id   path  string  true  "Job ID (UUID)"
+// @Success      200  {object}  SwaggerJobResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /jobs/{id} [get]
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	job, err := h.service.GetByID(id, audit.ActorFromCtx(c), isAdmin(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get job", job)
+}
+
+// GetResult godoc
+// @Summary      Download job result
+// @Description  Downloads the output of a succeeded job as a file
+// @Tags         Jobs
+// @Produce      octet-stream
+// @Param        id   path  string  true  "Job ID (UUID)"
+// @Success      200  {file}    file
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /jobs/{id}/result [get]
+func (h *Handler) GetResult(c *fiber.Ctx) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.GetResult(id, audit.ActorFromCtx(c), isAdmin(c))
+	if err != nil {
+		return err
+	}
+
+	c.Attachment(result.Name)
+	if result.ContentType != "" {
+		c.Set(fiber.HeaderContentType, result.ContentType)
+	}
+	return c.Send(result.Data)
+}
+
+// Cancel godoc
+// @Summary      Cancel a job
+// @Description  Cancels a queued job right away; a running job stops at its next safe point
+// @Tags         Jobs
+// @Produce      json
+// @Param        id   path  string  true  "Job ID (UUID)"
+// @Success      200  {object}  SwaggerJobResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /jobs/{id}/cancel [post]
+func (h *Handler) Cancel(c *fiber.Ctx) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	job, err := h.service.Cancel(id, audit.ActorFromCtx(c), isAdmin(c))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Job cancellation requested", job)
+}
@@ -0,0 +1,210 @@
+package job
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(j *domain.Job) error
+	FindByID(id uuid.UUID) (*domain.Job, error)
+	FindAll(query *JobQuery, createdBy string) ([]JobRow, int64, error)
+	Lease(types []string, until time.Time) (*domain.Job, error)
+	Heartbeat(id uuid.UUID, until time.Time) (bool, error)
+	SetProgress(id uuid.UUID, percent int, message string) error
+	Succeed(id uuid.UUID, result *Result) error
+	Retry(id uuid.UUID, runAt time.Time, message string) error
+	Fail(id uuid.UUID, message string) error
+	MarkCancelled(id uuid.UUID) error
+	Release(id uuid.UUID) error
+	RequestCancel(id uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(j *domain.Job) error {
+	return r.db.Exec(
+		`INSERT INTO jobs (id, type, payload, status, attempts, max_attempts, run_at, cancel_requested, progress, created_by, created_at)
+		 VALUES (?, ?, ?, ?, 0, ?, ?, false, 0, ?, NOW())`,
+		j.ID, j.Type, j.Payload, j.Status, j.MaxAttempts, j.RunAt, j.CreatedBy,
+	).Error
+}
+
+func (r *repository) FindByID(id uuid.UUID) (*domain.Job, error) {
+	var j domain.Job
+	result := r.db.Raw(
+		`SELECT id, type, payload, status, attempts, max_attempts, run_at, leased_until, cancel_requested,
+			progress, COALESCE(progress_message, '') AS progress_message, result,
+			COALESCE(result_name, '') AS result_name, COALESCE(result_type, '') AS result_type,
+			COALESCE(error, '') AS error, created_by, created_at, started_at, finished_at
+		 FROM jobs
+		 WHERE id = ?
+		 LIMIT 1`,
+		id,
+	).Scan(&j)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &j, nil
+}
+
+// FindAll lists jobs newest first. A non-empty createdBy limits the list to
+// that caller's jobs.
+func (r *repository) FindAll(query *JobQuery, createdBy string) ([]JobRow, int64, error) {
+	where := "1 = 1"
+	var args []interface{}
+	if createdBy != "" {
+		where += " AND created_by = ?"
+		args = append(args, createdBy)
+	}
+	if query.Status != "" {
+		where += " AND status = ?"
+		args = append(args, query.Status)
+	}
+	if query.Type != "" {
+		where += " AND type = ?"
+		args = append(args, query.Type)
+	}
+
+	var total int64
+	if err := r.db.Raw(`SELECT COUNT(*) FROM jobs WHERE `+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []JobRow
+	err := r.db.Raw(
+		`SELECT id, type, status, attempts, max_attempts, progress,
+			COALESCE(progress_message, '') AS progress_message, cancel_requested,
+			COALESCE(error, '') AS error, result IS NOT NULL AS has_result,
+			COALESCE(result_name, '') AS result_name, created_by, created_at, run_at, started_at, finished_at
+		 FROM jobs
+		 WHERE `+where+`
+		 ORDER BY created_at DESC
+		 LIMIT ? OFFSET ?`,
+		append(args, query.Limit, (query.Page-1)*query.Limit)...,
+	).Scan(&rows).Error
+	return rows, total, err
+}
+
+// Lease claims the next runnable job of one of types until the given time:
+// a queued job that is due, or a running job whose lease has lapsed. SKIP
+// LOCKED lets concurrent workers lease different jobs without waiting.
+func (r *repository) Lease(types []string, until time.Time) (*domain.Job, error) {
+	var j domain.Job
+	result := r.db.Raw(
+		`UPDATE jobs
+		 SET status = 'running', attempts = attempts + 1, leased_until = ?, started_at = COALESCE(started_at, NOW())
+		 WHERE id = (
+			SELECT id FROM jobs
+			WHERE type IN ?
+			AND ((status = 'queued' AND run_at <= NOW()) OR (status = 'running' AND leased_until < NOW()))
+			ORDER BY run_at ASC, created_at ASC
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		 )
+		 RETURNING id, type, payload, status, attempts, max_attempts, run_at, leased_until, cancel_requested, created_by, created_at, started_at`,
+		until, types,
+	).Scan(&j)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &j, nil
+}
+
+// Heartbeat extends the lease of a running job and reports whether its
+// cancellation was requested.
+func (r *repository) Heartbeat(id uuid.UUID, until time.Time) (bool, error) {
+	var cancelRequested bool
+	err := r.db.Raw(
+		`UPDATE jobs SET leased_until = ? WHERE id = ? AND status = 'running' RETURNING cancel_requested`,
+		until, id,
+	).Scan(&cancelRequested).Error
+	return cancelRequested, err
+}
+
+func (r *repository) SetProgress(id uuid.UUID, percent int, message string) error {
+	return r.db.Exec(
+		`UPDATE jobs SET progress = ?, progress_message = ? WHERE id = ?`,
+		percent, message, id,
+	).Error
+}
+
+func (r *repository) Succeed(id uuid.UUID, result *Result) error {
+	var data []byte
+	var name, contentType string
+	if result != nil {
+		data, name, contentType = result.Data, result.Name, result.ContentType
+	}
+	return r.db.Exec(
+		`UPDATE jobs
+		 SET status = 'succeeded', progress = 100, result = ?, result_name = ?, result_type = ?, error = '',
+			leased_until = NULL, finished_at = NOW()
+		 WHERE id = ?`,
+		data, name, contentType, id,
+	).Error
+}
+
+func (r *repository) Retry(id uuid.UUID, runAt time.Time, message string) error {
+	return r.db.Exec(
+		`UPDATE jobs SET status = 'queued', run_at = ?, error = ?, leased_until = NULL WHERE id = ?`,
+		runAt, message, id,
+	).Error
+}
+
+func (r *repository) Fail(id uuid.UUID, message string) error {
+	return r.db.Exec(
+		`UPDATE jobs SET status = 'failed', error = ?, leased_until = NULL, finished_at = NOW() WHERE id = ?`,
+		message, id,
+	).Error
+}
+
+func (r *repository) MarkCancelled(id uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE jobs SET status = 'cancelled', leased_until = NULL, finished_at = NOW() WHERE id = ?`,
+		id,
+	).Error
+}
+
+// Release hands a job back to the queue without counting the attempt, e.g.
+// when its worker shuts down mid-run.
+func (r *repository) Release(id uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE jobs SET status = 'queued', attempts = attempts - 1, leased_until = NULL WHERE id = ? AND status = 'running'`,
+		id,
+	).Error
+}
+
+// RequestCancel cancels a queued job at once and flags a running one for
+// its worker to stop. It reports false when the job has already finished.
+func (r *repository) RequestCancel(id uuid.UUID) (bool, error) {
+	result := r.db.Exec(
+		`UPDATE jobs
+		 SET cancel_requested = true,
+			status = CASE WHEN status = 'queued' THEN 'cancelled' ELSE status END,
+			finished_at = CASE WHEN status = 'queued' THEN NOW() ELSE finished_at END
+		 WHERE id = ? AND status IN ('queued', 'running')`,
+		id,
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
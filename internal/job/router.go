@@ -0,0 +1,17 @@
+package job
+
+import (
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler) {
+	jobRoutes := router.Group("/jobs")
+	jobRoutes.Use(middleware.AuthMiddleware())
+
+	jobRoutes.Get("/", handler.GetAll)
+	jobRoutes.Get("/:id", handler.GetByID)
+	jobRoutes.Get("/:id/result", handler.GetResult)
+	jobRoutes.Post("/:id/cancel", handler.Cancel)
+}
@@ -0,0 +1,149 @@
+package job
+
+import (
+	"math"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/model"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetAll(query *JobQuery, actor audit.Actor, isAdmin bool) ([]JobResponse, *model.MetaPagination, error)
+	GetByID(id uuid.UUID, actor audit.Actor, isAdmin bool) (*JobResponse, error)
+	GetResult(id uuid.UUID, actor audit.Actor, isAdmin bool) (*Result, error)
+	Cancel(id uuid.UUID, actor audit.Actor, isAdmin bool) (*JobResponse, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func toResponse(j *domain.Job) *JobResponse {
+	res := &JobResponse{
+		ID:              j.ID.String(),
+		Type:            j.Type,
+		Status:          string(j.Status),
+		Attempts:        j.Attempts,
+		MaxAttempts:     j.MaxAttempts,
+		Progress:        j.Progress,
+		ProgressMessage: j.ProgressMessage,
+		CancelRequested: j.CancelRequested,
+		Error:           j.Error,
+		HasResult:       j.Result != nil,
+		ResultName:      j.ResultName,
+		CreatedBy:       j.CreatedBy,
+		CreatedAt:       j.CreatedAt,
+		RunAt:           j.RunAt,
+		StartedAt:       j.StartedAt,
+		FinishedAt:      j.FinishedAt,
+	}
+	if j.ResultType == "application/json" {
+		res.Result = j.Result
+	}
+	return res
+}
+
+// findOwned loads a job visible to the actor: admins see every job, other
+// callers only the jobs they enqueued.
+func findOwned(repo Repository, id uuid.UUID, actor audit.Actor, isAdmin bool) (*domain.Job, error) {
+	j, err := repo.FindByID(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if j == nil || (!isAdmin && j.CreatedBy != actor.ID) {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Job not found")
+	}
+	return j, nil
+}
+
+func (s *service) GetAll(query *JobQuery, actor audit.Actor, isAdmin bool) ([]JobResponse, *model.MetaPagination, error) {
+	createdBy := actor.ID
+	if isAdmin {
+		createdBy = ""
+	}
+
+	rows, total, err := s.repo.FindAll(query, createdBy)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	res := make([]JobResponse, len(rows))
+	for i, r := range rows {
+		res[i] = JobResponse{
+			ID:              r.ID,
+			Type:            r.Type,
+			Status:          r.Status,
+			Attempts:        r.Attempts,
+			MaxAttempts:     r.MaxAttempts,
+			Progress:        r.Progress,
+			ProgressMessage: r.ProgressMessage,
+			CancelRequested: r.CancelRequested,
+			Error:           r.Error,
+			HasResult:       r.HasResult,
+			ResultName:      r.ResultName,
+			CreatedBy:       r.CreatedBy,
+			CreatedAt:       r.CreatedAt,
+			RunAt:           r.RunAt,
+			StartedAt:       r.StartedAt,
+			FinishedAt:      r.FinishedAt,
+		}
+	}
+
+	meta := &model.MetaPagination{
+		Page:      query.Page,
+		Limit:     query.Limit,
+		TotalPage: int(math.Ceil(float64(total) / float64(query.Limit))),
+		TotalData: int(total),
+	}
+	return res, meta, nil
+}
+
+func (s *service) GetByID(id uuid.UUID, actor audit.Actor, isAdmin bool) (*JobResponse, error) {
+	j, err := findOwned(s.repo, id, actor, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(j), nil
+}
+
+func (s *service) GetResult(id uuid.UUID, actor audit.Actor, isAdmin bool) (*Result, error) {
+	j, err := findOwned(s.repo, id, actor, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if j.Status != domain.JobStatusSucceeded || j.Result == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Job has no result")
+	}
+
+	name := j.ResultName
+	if name == "" {
+		name = j.ID.String()
+	}
+	return &Result{Name: name, ContentType: j.ResultType, Data: j.Result}, nil
+}
+
+// Cancel stops a queued job right away. A running job is flagged and
+// stops once its worker notices, at its next heartbeat.
+func (s *service) Cancel(id uuid.UUID, actor audit.Actor, isAdmin bool) (*JobResponse, error) {
+	if _, err := findOwned(s.repo, id, actor, isAdmin); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.repo.RequestCancel(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusConflict, "Job has already finished")
+	}
+
+	return s.GetByID(id, actor, isAdmin)
+}
@@ -0,0 +1,256 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// leaseDuration is how long a worker holds a job between heartbeats. A job
+// whose worker stops heartbeating is picked up again once it lapses.
+const leaseDuration = 2 * time.Minute
+
+// Result is the output of a job, downloadable from GET /jobs/{id}/result.
+type Result struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// JSONResult encodes v as a JSON result.
+func JSONResult(name string, v any) (*Result, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Name: name, ContentType: "application/json", Data: data}, nil
+}
+
+// Runner executes one job. ctx is cancelled when the job is cancelled or
+// the worker shuts down; a runner should stop at the next safe point.
+// Returning an error retries the job with backoff until its attempts run
+// out.
+type Runner func(ctx context.Context, run *Run) (*Result, error)
+
+// Run is the job being executed, handed to its Runner.
+type Run struct {
+	Job  *domain.Job
+	repo Repository
+}
+
+// Progress records how far the job has come, as done out of total.
+func (r *Run) Progress(done, total int, message string) {
+	percent := 0
+	if total > 0 {
+		percent = min(done*100/total, 99)
+	}
+	if err := r.repo.SetProgress(r.Job.ID, percent, message); err != nil {
+		log.Printf("job %s: progress: %v", r.Job.ID, err)
+	}
+}
+
+// Handle adapts a runner that takes a typed payload, decoded from the JSON
+// stored by Enqueue.
+func Handle[T any](fn func(ctx context.Context, run *Run, payload T) (*Result, error)) Runner {
+	return func(ctx context.Context, run *Run) (*Result, error) {
+		var payload T
+		if len(run.Job.Payload) > 0 {
+			if err := json.Unmarshal(run.Job.Payload, &payload); err != nil {
+				return nil, fmt.Errorf("decode payload: %w", err)
+			}
+		}
+		return fn(ctx, run, payload)
+	}
+}
+
+// Enqueue stores a job inside tx, so it is only queued when the surrounding
+// work commits. payload must be JSON-encodable.
+func Enqueue(tx *gorm.DB, jobType string, payload any, actor audit.Actor) (*JobResponse, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	j := &domain.Job{
+		ID:          uuid.New(),
+		Type:        jobType,
+		Payload:     data,
+		Status:      domain.JobStatusQueued,
+		MaxAttempts: max(config.AppConfig.JobMaxAttempts, 1),
+		RunAt:       time.Now(),
+		CreatedBy:   actor.ID,
+	}
+	repo := NewRepository(tx)
+	if err := repo.Create(j); err != nil {
+		return nil, err
+	}
+
+	stored, err := repo.FindByID(j.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(stored), nil
+}
+
+// Worker leases jobs from the queue and runs them with their registered
+// Runner.
+type Worker struct {
+	repo    Repository
+	runners map[string]Runner
+}
+
+func NewWorker(db *gorm.DB) *Worker {
+	return &Worker{repo: NewRepository(db), runners: map[string]Runner{}}
+}
+
+// Register sets the runner for a job type. It must be called before Start.
+func (w *Worker) Register(jobType string, runner Runner) {
+	w.runners[jobType] = runner
+}
+
+// Start launches n workers polling the queue every poll interval while it
+// is empty. The returned stop function waits for them to finish; jobs they
+// were running go back to the queue.
+func (w *Worker) Start(n int, poll time.Duration) (stop func()) {
+	types := make([]string, 0, len(w.runners))
+	for t := range w.runners {
+		types = append(types, t)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for range max(n, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.loop(ctx, types, poll)
+		}()
+	}
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func (w *Worker) loop(ctx context.Context, types []string, poll time.Duration) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		j, err := w.repo.Lease(types, time.Now().Add(leaseDuration))
+		if err != nil {
+			log.Printf("job: lease: %v", err)
+		}
+		if j != nil {
+			w.run(ctx, j)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(poll):
+		}
+	}
+}
+
+func (w *Worker) run(ctx context.Context, j *domain.Job) {
+	if j.CancelRequested {
+		w.finish(j.ID, w.repo.MarkCancelled(j.ID))
+		return
+	}
+	if j.Attempts > j.MaxAttempts {
+		// The lease lapsed on the last attempt, e.g. the worker died.
+		w.finish(j.ID, w.repo.Fail(j.ID, "Job did not finish within its attempts"))
+		return
+	}
+
+	jobCtx, cancelJob := context.WithCancel(ctx)
+	defer cancelJob()
+
+	var cancelled bool
+	var mu sync.Mutex
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		ticker := time.NewTicker(leaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+				cancelRequested, err := w.repo.Heartbeat(j.ID, time.Now().Add(leaseDuration))
+				if err != nil {
+					log.Printf("job %s: heartbeat: %v", j.ID, err)
+					continue
+				}
+				if cancelRequested {
+					mu.Lock()
+					cancelled = true
+					mu.Unlock()
+					cancelJob()
+					return
+				}
+			}
+		}
+	}()
+
+	result, err := w.execute(jobCtx, j)
+	cancelJob()
+	<-heartbeatDone
+
+	mu.Lock()
+	wasCancelled := cancelled
+	mu.Unlock()
+
+	switch {
+	case wasCancelled:
+		w.finish(j.ID, w.repo.MarkCancelled(j.ID))
+	case ctx.Err() != nil:
+		w.finish(j.ID, w.repo.Release(j.ID))
+	case err == nil:
+		w.finish(j.ID, w.repo.Succeed(j.ID, result))
+	case j.Attempts < j.MaxAttempts:
+		log.Printf("job %s (%s) attempt %d failed: %v", j.ID, j.Type, j.Attempts, err)
+		w.finish(j.ID, w.repo.Retry(j.ID, time.Now().Add(utils.Backoff(j.Attempts)), err.Error()))
+	default:
+		log.Printf("job %s (%s) failed: %v", j.ID, j.Type, err)
+		w.finish(j.ID, w.repo.Fail(j.ID, err.Error()))
+	}
+}
+
+// execute runs the job's runner, turning a panic into an error.
+func (w *Worker) execute(ctx context.Context, j *domain.Job) (result *Result, err error) {
+	runner, ok := w.runners[j.Type]
+	if !ok {
+		return nil, errors.New("no runner registered for job type " + j.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return runner(ctx, &Run{Job: j, repo: w.repo})
+}
+
+func (w *Worker) finish(id uuid.UUID, err error) {
+	if err != nil {
+		log.Printf("job %s: update status: %v", id, err)
+	}
+}
@@ -0,0 +1,229 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/job"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// bulkReason turns an item error into the message shown in its result.
+func bulkReason(err error) string {
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return fe.Message
+	}
+	return err.Error()
+}
+
+// resolveBulk validates req and returns the journal IDs it targets, in order
+// and without duplicates.
+func (s *service) resolveBulk(req *BulkRequest) ([]string, error) {
+	if req.Mode == "" {
+		req.Mode = BulkModeBestEffort
+	}
+	if req.Action != BulkActionPost && req.Action != BulkActionDelete {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "action must be post or delete")
+	}
+	if req.Mode != BulkModeAtomic && req.Mode != BulkModeBestEffort {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "mode must be atomic or best_effort")
+	}
+	if len(req.IDs) > 0 && req.Filter != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Send either ids or filter, not both")
+	}
+	if len(req.IDs) == 0 && req.Filter == nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "ids or filter is required")
+	}
+
+	if req.Filter == nil {
+		seen := make(map[string]bool, len(req.IDs))
+		ids := make([]string, 0, len(req.IDs))
+		for _, id := range req.IDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+
+	for _, d := range []string{req.Filter.StartDate, req.Filter.EndDate} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "filter dates must use the YYYY-MM-DD format")
+		}
+	}
+
+	ids, err := s.repo.FindDraftIDs(req.Filter)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return ids, nil
+}
+
+// Bulk applies req to every targeted journal. Sets up to
+// BULK_ASYNC_THRESHOLD journals are processed right away; larger ones are
+// queued as a background job whose progress is read from GET /jobs/{id}.
+func (s *service) Bulk(db *gorm.DB, req *BulkRequest, actor audit.Actor) (*BulkResult, error) {
+	ids, err := s.resolveBulk(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) <= config.AppConfig.BulkAsyncThreshold {
+		return s.RunBulk(context.Background(), db, req.Action, req.Mode, ids, actor, nil), nil
+	}
+
+	queued, err := job.Enqueue(db, JobTypeBulk, BulkJobPayload{
+		Action: req.Action,
+		Mode:   req.Mode,
+		IDs:    ids,
+		Actor:  actor,
+	}, actor)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return &BulkResult{
+		JobID:  queued.ID,
+		Status: queued.Status,
+		Action: req.Action,
+		Mode:   req.Mode,
+		Total:  len(ids),
+		Items:  []BulkItemResult{},
+	}, nil
+}
+
+// BulkJobRunner runs the bulk actions queued by Bulk. The per-journal
+// results become the job's JSON result.
+func BulkJobRunner(svc Service, db *gorm.DB) job.Runner {
+	return job.Handle(func(ctx context.Context, run *job.Run, p BulkJobPayload) (*job.Result, error) {
+		res := svc.RunBulk(ctx, db, p.Action, p.Mode, p.IDs, p.Actor, func(processed, succeeded, failed int) {
+			run.Progress(processed, len(p.IDs), fmt.Sprintf("%d succeeded, %d failed", succeeded, failed))
+		})
+		return job.JSONResult("bulk-"+p.Action+".json", res)
+	})
+}
+
+// RunBulk processes ids in batches of BULK_BATCH_SIZE. In best_effort mode
+// each batch is its own transaction and a failing journal is rolled back to
+// a savepoint without affecting the others. In atomic mode everything runs
+// in one transaction that the first failure rolls back. Once ctx is done
+// the remaining journals are skipped (and an atomic run rolled back).
+// progress, when set, is called after every batch.
+func (s *service) RunBulk(ctx context.Context, db *gorm.DB, action, mode string, ids []string, actor audit.Actor, progress func(processed, succeeded, failed int)) *BulkResult {
+	batchSize := max(config.AppConfig.BulkBatchSize, 1)
+	items := make([]BulkItemResult, len(ids))
+	for i, id := range ids {
+		items[i] = BulkItemResult{JournalID: id, Status: BulkItemSkipped}
+	}
+
+	res := &BulkResult{
+		Status: BulkStatusCompleted,
+		Action: action,
+		Mode:   mode,
+		Total:  len(ids),
+		Items:  items,
+	}
+	count := func() {
+		res.Processed, res.Succeeded, res.Failed = 0, 0, 0
+		for _, item := range items {
+			switch item.Status {
+			case BulkItemSuccess:
+				res.Succeeded++
+			case BulkItemFailed:
+				res.Failed++
+			}
+			if item.Status != BulkItemSkipped {
+				res.Processed++
+			}
+		}
+		if progress != nil {
+			progress(res.Processed, res.Succeeded, res.Failed)
+		}
+	}
+
+	if mode == BulkModeAtomic {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := range items {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if err := s.applyBulk(tx, action, items[i].JournalID, actor); err != nil {
+					items[i].Status = BulkItemFailed
+					items[i].Reason = bulkReason(err)
+					return err
+				}
+				items[i].Status = BulkItemSuccess
+				if (i+1)%batchSize == 0 {
+					count()
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			res.Status = BulkStatusFailed
+			res.Error = "Nothing was applied: " + bulkReason(err)
+			for i := range items {
+				if items[i].Status == BulkItemSuccess {
+					items[i].Status = BulkItemRolledBack
+				}
+			}
+		}
+		count()
+		return res
+	}
+
+	for start := 0; start < len(items) && ctx.Err() == nil; start += batchSize {
+		batch := items[start:min(start+batchSize, len(items))]
+		err := db.Transaction(func(tx *gorm.DB) error {
+			for i := range batch {
+				if err := tx.SavePoint("bulk_item").Error; err != nil {
+					return err
+				}
+				if err := s.applyBulk(tx, action, batch[i].JournalID, actor); err != nil {
+					if rbErr := tx.RollbackTo("bulk_item").Error; rbErr != nil {
+						return rbErr
+					}
+					batch[i].Status = BulkItemFailed
+					batch[i].Reason = bulkReason(err)
+					continue
+				}
+				batch[i].Status = BulkItemSuccess
+			}
+			return nil
+		})
+		if err != nil {
+			for i := range batch {
+				if batch[i].Status != BulkItemFailed {
+					batch[i].Status = BulkItemFailed
+					batch[i].Reason = bulkReason(err)
+				}
+			}
+		}
+		count()
+	}
+	return res
+}
+
+func (s *service) applyBulk(tx *gorm.DB, action, id string, actor audit.Actor) error {
+	journalID, err := uuid.Parse(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid journal entry ID")
+	}
+
+	if action == BulkActionDelete {
+		return s.Delete(journalID, actor, tx)
+	}
+	return s.PostJournal(journalID, actor, tx)
+}
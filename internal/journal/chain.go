@@ -0,0 +1,267 @@
+package journal
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const chainVerifyBatch = 500
+
+type canonicalLine struct {
+	CoaCode     string `json:"coaCode"`
+	Debit       string `json:"debit"`
+	Credit      string `json:"credit"`
+	Description string `json:"description"`
+}
+
+type canonicalEntry struct {
+	ID          string          `json:"id"`
+	Date        string          `json:"date"`
+	Reference   string          `json:"reference"`
+	Description string          `json:"description"`
+	CreatedBy   string          `json:"createdBy"`
+	Lines       []canonicalLine `json:"lines"`
+}
+
+// canonicalContent serialises the parts of an entry covered by the chain:
+// the header and its detail lines sorted deterministically, with amounts
+// fixed to two decimals so float formatting cannot change the hash.
+func canonicalContent(entry ChainRow, details []ChainDetailRow) []byte {
+	lines := make([]canonicalLine, len(details))
+	for i, d := range details {
+		lines[i] = canonicalLine{
+			CoaCode:     d.CoaCode,
+			Debit:       strconv.FormatFloat(d.Debit, 'f', 2, 64),
+			Credit:      strconv.FormatFloat(d.Credit, 'f', 2, 64),
+			Description: d.Description,
+		}
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		if a.CoaCode != b.CoaCode {
+			return a.CoaCode < b.CoaCode
+		}
+		if a.Debit != b.Debit {
+			return a.Debit < b.Debit
+		}
+		if a.Credit != b.Credit {
+			return a.Credit < b.Credit
+		}
+		return a.Description < b.Description
+	})
+
+	raw, _ := json.Marshal(canonicalEntry{
+		ID:          entry.ID,
+		Date:        entry.Date.Format("2006-01-02"),
+		Reference:   entry.Reference,
+		Description: entry.Description,
+		CreatedBy:   entry.CreatedBy,
+		Lines:       lines,
+	})
+	return raw
+}
+
+func chainHash(prevHash string, content []byte) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// AppendToChain links a freshly posted entry to the chain head. It must run
+// inside the posting transaction; the advisory lock keeps concurrent posts
+// from claiming the same sequence number.
+func AppendToChain(txRepo Repository, id uuid.UUID) error {
+	if err := txRepo.LockChain(); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	head, err := txRepo.FindChainHead()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	entry, _, err := txRepo.FindByID(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if entry == nil {
+		return fiber.NewError(fiber.StatusNotFound, "Journal entry not found")
+	}
+
+	details, err := txRepo.FindChainDetails([]string{id.String()})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	row := ChainRow{
+		ID:          entry.ID.String(),
+		Date:        entry.Date,
+		Reference:   entry.Reference,
+		Description: entry.Description,
+		CreatedBy:   entry.CreatedBy.String(),
+	}
+
+	seq := int64(1)
+	var prevHash *string
+	if head != nil {
+		seq = head.ChainSeq + 1
+		prevHash = &head.Hash
+	}
+
+	hash := chainHash(deref(prevHash), canonicalContent(row, details))
+	if err := txRepo.AssignChain(id, seq, prevHash, hash); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// VerifyChain walks the whole chain in sequence order, recomputing every hash,
+// and stops at the first broken link.
+func VerifyChain(repo Repository) (*ChainVerifyResponse, error) {
+	res := &ChainVerifyResponse{Valid: true}
+
+	var prevHash string
+	var lastSeq int64
+
+	for {
+		rows, err := repo.FindChainBatch(lastSeq, chainVerifyBatch)
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		ids := make([]string, len(rows))
+		for i, r := range rows {
+			ids[i] = r.ID
+		}
+		details, err := repo.FindChainDetails(ids)
+		if err != nil {
+			return nil, err
+		}
+		byEntry := map[string][]ChainDetailRow{}
+		for _, d := range details {
+			byEntry[d.JournalEntryID] = append(byEntry[d.JournalEntryID], d)
+		}
+
+		for _, r := range rows {
+			if brk := checkLink(r, byEntry[r.ID], lastSeq, prevHash); brk != nil {
+				res.Valid = false
+				res.FirstBreak = brk
+				return res, nil
+			}
+
+			res.CheckedEntries++
+			res.HeadSeq = r.ChainSeq
+			res.HeadHash = r.Hash
+			lastSeq = r.ChainSeq
+			prevHash = r.Hash
+		}
+	}
+
+	return res, nil
+}
+
+func checkLink(r ChainRow, details []ChainDetailRow, lastSeq int64, prevHash string) *ChainBreak {
+	brk := &ChainBreak{ChainSeq: r.ChainSeq, JournalID: r.ID, Reference: r.Reference, StoredHash: r.Hash}
+
+	switch {
+	case r.ChainSeq != lastSeq+1:
+		brk.Reason = fmt.Sprintf("sequence gap: expected %d", lastSeq+1)
+	case deref(r.PrevHash) != prevHash:
+		brk.Reason = "previous hash does not match the preceding entry"
+		brk.ExpectedHash = prevHash
+	case r.DeletedAt != nil:
+		brk.Reason = "posted entry has been deleted"
+	default:
+		expected := chainHash(prevHash, canonicalContent(r, details))
+		if expected == r.Hash {
+			return nil
+		}
+		brk.Reason = "content hash mismatch"
+		brk.ExpectedHash = expected
+	}
+	return brk
+}
+
+// BuildCheckpoint summarises the chained entries dated in period (YYYY-MM)
+// and signs the result together with the current chain head.
+func BuildCheckpoint(repo Repository, period string) (*ChainCheckpoint, error) {
+	start, err := time.Parse("2006-01", period)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "period must be formatted as YYYY-MM")
+	}
+	end := start.AddDate(0, 1, -1)
+
+	rows, err := repo.FindChainByDateRange(start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	head, err := repo.FindChainHead()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	cp := &ChainCheckpoint{
+		Period:      period,
+		EntryCount:  len(rows),
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	digest := sha256.New()
+	for i, r := range rows {
+		if i == 0 {
+			cp.FirstSeq = r.ChainSeq
+		}
+		cp.LastSeq = r.ChainSeq
+		digest.Write([]byte(r.Hash))
+	}
+	cp.PeriodDigest = hex.EncodeToString(digest.Sum(nil))
+
+	if head != nil {
+		cp.HeadSeq = head.ChainSeq
+		cp.HeadHash = head.Hash
+	}
+
+	cp.Signature = signCheckpoint(cp)
+	return cp, nil
+}
+
+func signCheckpoint(cp *ChainCheckpoint) string {
+	payload := strings.Join([]string{
+		cp.Period,
+		strconv.Itoa(cp.EntryCount),
+		strconv.FormatInt(cp.FirstSeq, 10),
+		strconv.FormatInt(cp.LastSeq, 10),
+		cp.PeriodDigest,
+		strconv.FormatInt(cp.HeadSeq, 10),
+		cp.HeadHash,
+		cp.GeneratedAt.Format(time.RFC3339),
+	}, "|")
+
+	mac := hmac.New(sha256.New, []byte(config.AppConfig.ChainSigningKey))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
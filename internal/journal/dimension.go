@@ -0,0 +1,76 @@
+package journal
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// lineDimensions is the part of a journal line that dimension rules check.
+type lineDimensions struct {
+	CoaCode    string
+	Dimensions map[string]string
+}
+
+// checkDimensions enforces the per-account dimension rules: a line may only
+// carry dimensions configured for its account, each value must be an active
+// value of its dimension, and required dimensions must be present. An empty
+// value counts as no value.
+func checkDimensions(repo Repository, lines []lineDimensions) error {
+	codes := make([]string, 0, len(lines))
+	used := map[string]bool{}
+	for _, l := range lines {
+		codes = append(codes, l.CoaCode)
+		for dim, value := range l.Dimensions {
+			if value != "" {
+				used[dim] = true
+			}
+		}
+	}
+
+	rows, err := repo.FindDimensionRules(codes)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	rules := make(map[string]map[string]bool)
+	for _, r := range rows {
+		if rules[r.CoaCode] == nil {
+			rules[r.CoaCode] = make(map[string]bool)
+		}
+		rules[r.CoaCode][r.DimensionCode] = r.Required
+	}
+
+	dimCodes := make([]string, 0, len(used))
+	for dim := range used {
+		dimCodes = append(dimCodes, dim)
+	}
+	values, err := repo.FindActiveDimensionValues(dimCodes)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	active := make(map[string]bool, len(values))
+	for _, v := range values {
+		active[v.DimensionCode+"/"+v.Code] = true
+	}
+
+	for i, l := range lines {
+		allowed := rules[l.CoaCode]
+		for dim, value := range l.Dimensions {
+			if value == "" {
+				continue
+			}
+			if _, ok := allowed[dim]; !ok {
+				return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Line %d: dimension %s is not used by COA %s", i+1, dim, l.CoaCode))
+			}
+			if !active[dim+"/"+value] {
+				return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Line %d: %s is not an active value of dimension %s", i+1, value, dim))
+			}
+		}
+		for dim, required := range allowed {
+			if required && l.Dimensions[dim] == "" {
+				return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Line %d: COA %s requires dimension %s", i+1, l.CoaCode, dim))
+			}
+		}
+	}
+	return nil
+}
@@ -2,27 +2,37 @@ package journal
 
 import (
 	"time"
+
+	"fiber.com/session-api/internal/audit"
 )
 
+// JournalDetailRequest is one journal line. Dimensions maps a dimension code
+// to the value the line is tagged with, e.g. {"CC": "JKT"}.
 type JournalDetailRequest struct {
-	CoaCode     string  `json:"coaCode"     validate:"required"  example:"5-1001"`
-	Debit       float64 `json:"debit"       validate:"min=0"     example:"5000000"`
-	Credit      float64 `json:"credit"      validate:"min=0"     example:"0"`
-	Description string  `json:"description" validate:"omitempty" example:"Pembayaran gaji bulan Februari"`
+	CoaCode     string            `json:"coaCode"              validate:"required"  example:"5-1001"`
+	Debit       float64           `json:"debit"                validate:"min=0"     example:"5000000"`
+	Credit      float64           `json:"credit"               validate:"min=0"     example:"0"`
+	Description string            `json:"description"          validate:"omitempty" example:"Pembayaran gaji bulan Februari"`
+	Dimensions  map[string]string `json:"dimensions,omitempty" validate:"omitempty"`
 }
 
+// CreateJournalRequest creates a draft journal. ReverseOn (YYYY-MM-DD,
+// after today) marks an accrual: once the journal is posted, a reversing
+// entry dated ReverseOn is booked and posted automatically.
 type CreateJournalRequest struct {
 	Description string                 `json:"description" validate:"omitempty" example:"Pembayaran gaji bulan Februari"`
 	Details     []JournalDetailRequest `json:"details"     validate:"required,min=2,dive"`
+	ReverseOn   string                 `json:"reverseOn"   validate:"omitempty" example:"2026-03-01"`
 }
 
 type JournalDetailResponse struct {
-	ID          string  `json:"id"`
-	CoaCode     string  `json:"coaCode"`
-	CoaName     string  `json:"coaName"`
-	Debit       float64 `json:"debit"`
-	Credit      float64 `json:"credit"`
-	Description string  `json:"description"`
+	ID          string            `json:"id"`
+	CoaCode     string            `json:"coaCode"`
+	CoaName     string            `json:"coaName"`
+	Debit       float64           `json:"debit"`
+	Credit      float64           `json:"credit"`
+	Description string            `json:"description"`
+	Dimensions  map[string]string `json:"dimensions,omitempty"`
 }
 
 type JournalListResponse struct {
@@ -31,6 +41,7 @@ type JournalListResponse struct {
 	Reference   string    `json:"reference"`
 	Description string    `json:"description"`
 	Status      string    `json:"status"`
+	Type        string    `json:"type"`
 	CreatedBy   string    `json:"createdBy"`
 	TotalDebit  float64   `json:"totalDebit"`
 	TotalCredit float64   `json:"totalCredit"`
@@ -42,10 +53,106 @@ type JournalDetailedResponse struct {
 	Reference   string                  `json:"reference"`
 	Description string                  `json:"description"`
 	Status      string                  `json:"status"`
+	Type        string                  `json:"type"`
 	CreatedBy   string                  `json:"createdBy"`
+	ReverseOn   *time.Time              `json:"reverseOn,omitempty"`
+	ReversalOf  string                  `json:"reversalOf,omitempty"`
 	Details     []JournalDetailResponse `json:"details"`
 }
 
+// PendingReversal is a posted journal whose automatic reversal has not been
+// booked yet. Due is set once ReverseOn has been reached.
+type PendingReversal struct {
+	JournalID   string    `json:"journalId"   gorm:"column:id"`
+	Reference   string    `json:"reference"   gorm:"column:reference"`
+	Date        time.Time `json:"date"        gorm:"column:date"`
+	Description string    `json:"description" gorm:"column:description"`
+	ReverseOn   time.Time `json:"reverseOn"   gorm:"column:reverse_on"`
+	Amount      float64   `json:"amount"      gorm:"column:amount"`
+	Due         bool      `json:"due"         gorm:"-"`
+}
+
+// ReversalRunResult summarises one pass of the auto-reversal job.
+type ReversalRunResult struct {
+	Created int               `json:"created"`
+	Failed  []ReversalFailure `json:"failed,omitempty"`
+}
+
+type ReversalFailure struct {
+	JournalID string `json:"journalId"`
+	Reference string `json:"reference"`
+	Reason    string `json:"reason"`
+}
+
+const (
+	BulkActionPost   = "post"
+	BulkActionDelete = "delete"
+
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best_effort"
+
+	BulkItemSuccess    = "success"
+	BulkItemFailed     = "failed"
+	BulkItemRolledBack = "rolled_back"
+	BulkItemSkipped    = "skipped"
+
+	BulkStatusCompleted = "completed"
+	BulkStatusFailed    = "failed"
+
+	// JobTypeBulk is the background job running a large bulk action.
+	JobTypeBulk = "journal.bulk"
+)
+
+// BulkRequest applies one action to many draft journals, given either by
+// IDs or by a Filter over drafts. In atomic mode the first failure rolls
+// everything back; best_effort commits every journal that succeeds. Only
+// post and delete exist: journals go straight from draft to posted, so
+// there is no submit step to apply in bulk.
+type BulkRequest struct {
+	Action string      `json:"action" example:"post"        enums:"post,delete"`
+	Mode   string      `json:"mode"   example:"best_effort" enums:"atomic,best_effort"`
+	IDs    []string    `json:"ids"`
+	Filter *BulkFilter `json:"filter,omitempty"`
+}
+
+// BulkFilter selects draft journals. Dates are YYYY-MM-DD and inclusive.
+type BulkFilter struct {
+	StartDate string `json:"startDate" example:"2026-01-01"`
+	EndDate   string `json:"endDate"   example:"2026-01-31"`
+	Type      string `json:"type"      example:"general"`
+	Search    string `json:"search"    example:"gaji"`
+}
+
+type BulkItemResult struct {
+	JournalID string `json:"journalId"`
+	Status    string `json:"status" enums:"success,failed,rolled_back,skipped"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// BulkResult reports a bulk action. Status is completed, or failed when an
+// atomic run was rolled back. A bulk action queued in the background only
+// carries JobID and Status "queued"; its job result holds the full report.
+type BulkResult struct {
+	JobID     string           `json:"jobId,omitempty"`
+	Status    string           `json:"status" enums:"completed,failed,queued"`
+	Action    string           `json:"action"`
+	Mode      string           `json:"mode"`
+	Total     int              `json:"total"`
+	Processed int              `json:"processed"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Error     string           `json:"error,omitempty"`
+	Items     []BulkItemResult `json:"items"`
+}
+
+// BulkJobPayload is the payload of a JobTypeBulk job.
+type BulkJobPayload struct {
+	Action string      `json:"action"`
+	Mode   string      `json:"mode"`
+	IDs    []string    `json:"ids"`
+	Actor  audit.Actor `json:"actor"`
+}
+
 // Swagger Responses
 
 type SwaggerJournalResponse struct {
@@ -53,3 +160,50 @@ type SwaggerJournalResponse struct {
 	Message string                  `json:"message"`
 	Data    JournalDetailedResponse `json:"data"`
 }
+
+type SwaggerPendingReversalListResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    []PendingReversal `json:"data"`
+}
+
+type SwaggerBulkResponse struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    BulkResult `json:"data"`
+}
+
+type ChainBreak struct {
+	ChainSeq     int64  `json:"chainSeq"`
+	JournalID    string `json:"journalId"`
+	Reference    string `json:"reference"`
+	Reason       string `json:"reason"`
+	ExpectedHash string `json:"expectedHash,omitempty"`
+	StoredHash   string `json:"storedHash,omitempty"`
+}
+
+type ChainVerifyResponse struct {
+	Valid          bool        `json:"valid"`
+	CheckedEntries int         `json:"checkedEntries"`
+	HeadSeq        int64       `json:"headSeq"`
+	HeadHash       string      `json:"headHash"`
+	FirstBreak     *ChainBreak `json:"firstBreak,omitempty"`
+}
+
+type CheckpointQuery struct {
+	Period string `query:"period" validate:"required" example:"2026-01"`
+}
+
+// ChainCheckpoint pins the chain state for one accounting period. Signature
+// is an HMAC-SHA256 over the other fields using CHAIN_SIGNING_KEY.
+type ChainCheckpoint struct {
+	Period       string    `json:"period"`
+	EntryCount   int       `json:"entryCount"`
+	FirstSeq     int64     `json:"firstSeq"`
+	LastSeq      int64     `json:"lastSeq"`
+	PeriodDigest string    `json:"periodDigest"`
+	HeadSeq      int64     `json:"headSeq"`
+	HeadHash     string    `json:"headHash"`
+	GeneratedAt  time.Time `json:"generatedAt"`
+	Signature    string    `json:"signature"`
+}
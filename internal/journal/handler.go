@@ -3,6 +3,8 @@ package journal
 import (
 	"fmt"
 
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/middleware"
 	"fiber.com/session-api/pkg/model"
 	"fiber.com/session-api/pkg/utils"
 
@@ -13,10 +15,11 @@ import (
 
 type Handler struct {
 	service Service
+	db      *gorm.DB
 }
 
-func NewHandler(service Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service Service, db *gorm.DB) *Handler {
+	return &Handler{service: service, db: db}
 }
 
 // GetAll godoc
@@ -78,16 +81,161 @@ func (h *Handler) GetByID(c *fiber.Ctx) error {
 	return utils.SuccessResponse(c, fiber.StatusOK, fmt.Sprintf("Success get journal %s", entry.Reference), entry)
 }
 
+// GetHistory godoc
+// @Summary      Get journal entry history
+// @Description  Returns the audit trail of a journal entry (newest first)
+// @Tags         Journal
+// @Produce      json
+// @Param        id     path   string  true  "Journal Entry ID (UUID)"
+// @Param        page   query  int     false "Page number"    minimum(1)
+// @Param        limit  query  int     false "Items per page" minimum(1) maximum(100)
+// @Success      200  {object}  model.SwaggerJournalListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal/{id}/history [get]
+func (h *Handler) GetHistory(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid journal entry ID")
+	}
+
+	var req model.PaginationRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 10
+	}
+
+	logs, meta, err := h.service.GetHistory(id, &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get journal history", logs, meta)
+}
+
+// Bulk godoc
+// @Summary      Post or delete journals in bulk
+// @Description  Posts or deletes many draft journals, given by ids or by a filter over drafts (date range, type, search). Every journal gets its own result. best_effort (default) commits each journal that succeeds, in batched transactions; atomic rolls everything back on the first failure. There is no submit action: journals only move from draft to posted, with no review status in between. Sets larger than BULK_ASYNC_THRESHOLD run as a background job: the response is 202 with a jobId to follow on GET /jobs/{id}.
+// @Tags         Journal
+// @Accept       json
+// @Produce      json
+// @Param        request          body    journal.BulkRequest  true   "Bulk request"
+// @Param        Idempotency-Key  header  string               false  "Retry-safe key"
+// @Success      200  {object}  SwaggerBulkResponse
+// @Success      202  {object}  SwaggerBulkResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal/bulk [post]
+func (h *Handler) Bulk(c *fiber.Ctx) error {
+	var req BulkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	res, err := h.service.Bulk(h.db, &req, audit.ActorFromCtx(c))
+	if err != nil {
+		return err
+	}
+
+	if res.JobID != "" {
+		return utils.SuccessResponse(c, fiber.StatusAccepted, "Bulk action started in the background", res)
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, fmt.Sprintf("Bulk %s finished: %d succeeded, %d failed", res.Action, res.Succeeded, res.Failed), res)
+}
+
+// GetPendingReversals godoc
+// @Summary      List pending auto-reversals
+// @Description  Returns the posted accruals whose automatic reversal has not been booked yet, soonest first. Due entries are reversed on the next scheduler pass.
+// @Tags         Journal
+// @Produce      json
+// @Success      200  {object}  SwaggerPendingReversalListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal/reversals/pending [get]
+func (h *Handler) GetPendingReversals(c *fiber.Ctx) error {
+	rows, err := h.service.GetPendingReversals()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get pending reversals", rows)
+}
+
+// VerifyChain godoc
+// @Summary      Verify the journal hash chain
+// @Description  Recomputes the hash of every posted journal entry in chain order and reports the first broken link
+// @Tags         Journal
+// @Produce      json
+// @Success      200  {object}  ChainVerifyResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal/chain/verify [get]
+func (h *Handler) VerifyChain(c *fiber.Ctx) error {
+	res, err := h.service.VerifyChain()
+	if err != nil {
+		return err
+	}
+
+	message := "Journal chain is intact"
+	if !res.Valid {
+		message = "Journal chain is broken"
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, message, res)
+}
+
+// ExportCheckpoint godoc
+// @Summary      Export a signed chain checkpoint
+// @Description  Returns an HMAC-signed summary of the hash chain for one accounting period
+// @Tags         Journal
+// @Produce      json
+// @Param        period  query  string  true  "Period (YYYY-MM)"
+// @Success      200  {object}  ChainCheckpoint
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal/chain/checkpoint [get]
+func (h *Handler) ExportCheckpoint(c *fiber.Ctx) error {
+	var q CheckpointQuery
+	if err := c.QueryParser(&q); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if q.Period == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "period is required")
+	}
+
+	cp, err := h.service.ExportCheckpoint(q.Period)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, fmt.Sprintf("Success export checkpoint %s", cp.Period), cp)
+}
+
 // Create godoc
 // @Summary      Create a new journal entry
-// @Description  Creates a journal entry with detail lines. This endpoint uses a DB transaction.
+// @Description  Creates a journal entry with detail lines. This endpoint uses a DB transaction. With reverseOn the entry is an accrual: once posted, a reversing entry dated reverseOn is booked and posted automatically.
 // @Tags         Journal
 // @Accept       json
 // @Produce      json
-// @Param        request body journal.CreateJournalRequest true "Create Journal Request"
+// @Param        request          body    journal.CreateJournalRequest  true   "Create Journal Request"
+// @Param        Idempotency-Key  header  string                        false  "Retry-safe key: a repeat with the same key and body replays the first response, a different body returns 409"
 // @Success      201  {object}  SwaggerJournalResponse
 // @Failure      400  {object}  model.SwaggerErrorResponse
 // @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
 // @Failure      500  {object}  model.SwaggerErrorResponse
 // @Security     CookieAuth
 // @Router       /journal [post]
@@ -101,18 +249,13 @@ func (h *Handler) Create(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Journal entry must have at least 2 detail lines")
 	}
 
-	createdByStr := c.Locals("userId").(string)
-	createdBy, err := uuid.Parse(createdByStr)
+	tx, err := middleware.TxFromCtx(c)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
-	}
-
-	tx, ok := c.Locals("tx").(*gorm.DB)
-	if !ok || tx == nil {
-		return fiber.NewError(fiber.StatusInternalServerError, "Database transaction not available")
+		return err
 	}
 
-	entry, err := h.service.Create(&req, createdBy, tx)
+	// For API-key requests the actor ID is the key ID, so CreatedBy records the integration.
+	entry, err := h.service.Create(&req, audit.ActorFromCtx(c), tx)
 	if err != nil {
 		return err
 	}
@@ -138,7 +281,12 @@ func (h *Handler) PostJournal(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid journal entry ID")
 	}
 
-	if err := h.service.PostJournal(id); err != nil {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.PostJournal(id, audit.ActorFromCtx(c), tx); err != nil {
 		return err
 	}
 
@@ -163,7 +311,12 @@ func (h *Handler) Delete(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid journal entry ID")
 	}
 
-	if err := h.service.Delete(id); err != nil {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Delete(id, audit.ActorFromCtx(c), tx); err != nil {
 		return err
 	}
 
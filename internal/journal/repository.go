@@ -1,6 +1,8 @@
 package journal
 
 import (
+	"time"
+
 	"fiber.com/session-api/internal/domain"
 	"fiber.com/session-api/pkg/model"
 
@@ -10,20 +12,63 @@ import (
 )
 
 type JournalDetailRow struct {
-	ID          string  `gorm:"column:id"`
-	CoaCode     string  `gorm:"column:coa_code"`
-	CoaName     string  `gorm:"column:coa_name"`
-	Debit       float64 `gorm:"column:debit"`
-	Credit      float64 `gorm:"column:credit"`
-	Description string  `gorm:"column:description"`
+	ID          string            `gorm:"column:id"`
+	CoaCode     string            `gorm:"column:coa_code"`
+	CoaName     string            `gorm:"column:coa_name"`
+	Debit       float64           `gorm:"column:debit"`
+	Credit      float64           `gorm:"column:credit"`
+	Description string            `gorm:"column:description"`
+	Dimensions  map[string]string `gorm:"-"`
+}
+
+// DimensionRuleRow is one dimension allowed on an account's lines.
+type DimensionRuleRow struct {
+	CoaCode       string `gorm:"column:coa_code"`
+	DimensionCode string `gorm:"column:dimension_code"`
+	Required      bool   `gorm:"column:required"`
+}
+
+// ChainRow is the slice of a journal entry covered by the hash chain.
+type ChainRow struct {
+	ID          string     `gorm:"column:id"`
+	Date        time.Time  `gorm:"column:date"`
+	Reference   string     `gorm:"column:reference"`
+	Description string     `gorm:"column:description"`
+	CreatedBy   string     `gorm:"column:created_by"`
+	ChainSeq    int64      `gorm:"column:chain_seq"`
+	PrevHash    *string    `gorm:"column:prev_hash"`
+	Hash        string     `gorm:"column:hash"`
+	DeletedAt   *time.Time `gorm:"column:deleted_at"`
+}
+
+type ChainDetailRow struct {
+	JournalEntryID string  `gorm:"column:journal_entry_id"`
+	CoaCode        string  `gorm:"column:coa_code"`
+	Debit          float64 `gorm:"column:debit"`
+	Credit         float64 `gorm:"column:credit"`
+	Description    string  `gorm:"column:description"`
 }
 
 type Repository interface {
 	FindAll(req *model.PaginationRequest) ([]JournalListResponse, int64, error)
 	FindByID(id uuid.UUID) (*domain.JournalEntry, []JournalDetailRow, error)
 	Create(entry *domain.JournalEntry, details []domain.JournalEntryDetail) error
+	CreateLineDimensions(dims []domain.JournalLineDimension) error
+	FindDimensionRules(codes []string) ([]DimensionRuleRow, error)
+	FindActiveDimensionValues(dimensionCodes []string) ([]domain.DimensionValue, error)
 	PostJournal(id uuid.UUID) error
+	FindPendingReversals(dueBy *time.Time) ([]PendingReversal, error)
+	LockEntry(id uuid.UUID) error
+	HasReversal(id uuid.UUID) (bool, error)
+	FindDraftIDs(filter *BulkFilter) ([]string, error)
 	Delete(id uuid.UUID) error
+	FindPostableCodes(codes []string) ([]string, error)
+	LockChain() error
+	FindChainHead() (*ChainRow, error)
+	FindChainBatch(afterSeq int64, limit int) ([]ChainRow, error)
+	FindChainByDateRange(startDate, endDate string) ([]ChainRow, error)
+	FindChainDetails(entryIDs []string) ([]ChainDetailRow, error)
+	AssignChain(id uuid.UUID, seq int64, prevHash *string, hash string) error
 }
 
 type repository struct {
@@ -66,6 +111,7 @@ func (r *repository) FindAll(req *model.PaginationRequest) ([]JournalListRespons
 			je.reference,
 			je.description,
 			je.status,
+			je.type,
 			je.created_by,
 			COALESCE(SUM(jd.debit), 0)  AS total_debit,
 			COALESCE(SUM(jd.credit), 0) AS total_credit
@@ -80,6 +126,7 @@ func (r *repository) FindAll(req *model.PaginationRequest) ([]JournalListRespons
 			je.reference,
 			je.description,
 			je.status,
+			je.type,
 			je.created_by
 		ORDER BY
 			je.date DESC,
@@ -105,9 +152,13 @@ func (r *repository) FindByID(id uuid.UUID) (*domain.JournalEntry, []JournalDeta
 			reference,
 			description,
 			status,
+			type,
 			created_by,
 			created_at,
-			updated_at
+			updated_at,
+			reverse_on,
+			reversal_of_id,
+			chain_seq
 		 FROM journal_entries
 		 WHERE id = ?
 		 AND deleted_at IS NULL
@@ -142,23 +193,56 @@ func (r *repository) FindByID(id uuid.UUID) (*domain.JournalEntry, []JournalDeta
 		return nil, nil, err
 	}
 
+	if len(details) > 0 {
+		ids := make([]string, len(details))
+		for i, d := range details {
+			ids[i] = d.ID
+		}
+
+		var dims []domain.JournalLineDimension
+		if err := r.db.Raw(
+			`SELECT detail_id, dimension_code, value_code
+			 FROM journal_line_dimensions
+			 WHERE detail_id IN ?`,
+			ids,
+		).Scan(&dims).Error; err != nil {
+			return nil, nil, err
+		}
+
+		byDetail := make(map[string]map[string]string)
+		for _, d := range dims {
+			if byDetail[d.DetailID] == nil {
+				byDetail[d.DetailID] = make(map[string]string)
+			}
+			byDetail[d.DetailID][d.DimensionCode] = d.ValueCode
+		}
+		for i := range details {
+			details[i].Dimensions = byDetail[details[i].ID]
+		}
+	}
+
 	return &entry, details, nil
 }
 
 func (r *repository) Create(entry *domain.JournalEntry, details []domain.JournalEntryDetail) error {
 	if err := r.db.Exec(
-		`INSERT INTO journal_entries (id, date, reference, description, status, created_by, created_at, updated_at)
-		 VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())`,
-		entry.ID, entry.Date, entry.Reference, entry.Description, entry.Status, entry.CreatedBy,
+		`INSERT INTO journal_entries (id, date, reference, description, status, type, created_by, reverse_on, reversal_of_id, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		entry.ID, entry.Date, entry.Reference, entry.Description, entry.Status, entry.Type, entry.CreatedBy,
+		entry.ReverseOn, entry.ReversalOfID,
 	).Error; err != nil {
 		return err
 	}
 
-	for _, detail := range details {
+	// Line IDs are assigned here so callers can attach dimensions to them.
+	for i := range details {
+		if details[i].ID == "" {
+			details[i].ID = uuid.NewString()
+		}
 		if err := r.db.Exec(
 			`INSERT INTO journal_entry_details (id, journal_entry_id, coa_code, debit, credit, description)
-			 VALUES (gen_random_uuid(), ?, ?, ?, ?, ?)`,
-			detail.JournalEntryID, detail.CoaCode, detail.Debit, detail.Credit, detail.Description,
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			details[i].ID, details[i].JournalEntryID, details[i].CoaCode, details[i].Debit, details[i].Credit, details[i].Description,
 		).Error; err != nil {
 			return err
 		}
@@ -167,6 +251,51 @@ func (r *repository) Create(entry *domain.JournalEntry, details []domain.Journal
 	return nil
 }
 
+func (r *repository) CreateLineDimensions(dims []domain.JournalLineDimension) error {
+	for _, d := range dims {
+		if err := r.db.Exec(
+			`INSERT INTO journal_line_dimensions (detail_id, dimension_code, value_code)
+			 VALUES (?, ?, ?)`,
+			d.DetailID, d.DimensionCode, d.ValueCode,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindDimensionRules returns the active dimensions allowed on each of codes.
+func (r *repository) FindDimensionRules(codes []string) ([]DimensionRuleRow, error) {
+	var rules []DimensionRuleRow
+	if len(codes) == 0 {
+		return rules, nil
+	}
+	err := r.db.Raw(
+		`SELECT ad.coa_code, ad.dimension_code, ad.required
+		 FROM account_dimensions ad
+		 JOIN dimensions d ON d.code = ad.dimension_code
+		 WHERE ad.coa_code IN ?
+		 AND d.is_active = true`,
+		codes,
+	).Scan(&rules).Error
+	return rules, err
+}
+
+func (r *repository) FindActiveDimensionValues(dimensionCodes []string) ([]domain.DimensionValue, error) {
+	var values []domain.DimensionValue
+	if len(dimensionCodes) == 0 {
+		return values, nil
+	}
+	err := r.db.Raw(
+		`SELECT dimension_code, code, name, is_active
+		 FROM dimension_values
+		 WHERE dimension_code IN ?
+		 AND is_active = true`,
+		dimensionCodes,
+	).Scan(&values).Error
+	return values, err
+}
+
 func (r *repository) PostJournal(id uuid.UUID) error {
 	result := r.db.Exec(
 		`UPDATE journal_entries SET status = 'posted', updated_at = NOW()
@@ -182,6 +311,87 @@ func (r *repository) PostJournal(id uuid.UUID) error {
 	return nil
 }
 
+// FindPendingReversals returns the posted entries still waiting for their
+// automatic reversal, optionally only those due by the given date.
+func (r *repository) FindPendingReversals(dueBy *time.Time) ([]PendingReversal, error) {
+	query := `
+		SELECT
+			je.id,
+			je.reference,
+			je.date,
+			je.description,
+			je.reverse_on,
+			COALESCE(SUM(jd.debit), 0) AS amount
+		FROM journal_entries je
+		LEFT JOIN journal_entry_details jd ON jd.journal_entry_id = je.id AND jd.deleted_at IS NULL
+		WHERE je.reverse_on IS NOT NULL
+		AND je.status = 'posted'
+		AND je.deleted_at IS NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM journal_entries r
+			WHERE r.reversal_of_id = je.id AND r.deleted_at IS NULL
+		)`
+	var args []interface{}
+	if dueBy != nil {
+		query += ` AND je.reverse_on <= ?`
+		args = append(args, *dueBy)
+	}
+	query += `
+		GROUP BY je.id, je.reference, je.date, je.description, je.reverse_on
+		ORDER BY je.reverse_on ASC, je.date ASC`
+
+	var rows []PendingReversal
+	err := r.db.Raw(query, args...).Scan(&rows).Error
+	return rows, err
+}
+
+// LockEntry holds a row lock on the entry until the transaction ends.
+func (r *repository) LockEntry(id uuid.UUID) error {
+	return r.db.Exec(`SELECT id FROM journal_entries WHERE id = ? FOR UPDATE`, id).Error
+}
+
+func (r *repository) HasReversal(id uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Raw(
+		`SELECT COUNT(*) FROM journal_entries WHERE reversal_of_id = ? AND deleted_at IS NULL`,
+		id,
+	).Scan(&count).Error
+	return count > 0, err
+}
+
+// FindDraftIDs returns the draft journals matching filter, oldest first.
+func (r *repository) FindDraftIDs(filter *BulkFilter) ([]string, error) {
+	query := `
+		SELECT id
+		FROM journal_entries
+		WHERE status = 'draft'
+		AND deleted_at IS NULL`
+
+	var args []interface{}
+	if filter.StartDate != "" {
+		query += ` AND date >= ?`
+		args = append(args, filter.StartDate)
+	}
+	if filter.EndDate != "" {
+		query += ` AND date <= ?`
+		args = append(args, filter.EndDate)
+	}
+	if filter.Type != "" {
+		query += ` AND type = ?`
+		args = append(args, filter.Type)
+	}
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query += ` AND (reference ILIKE ? OR description ILIKE ?)`
+		args = append(args, search, search)
+	}
+	query += ` ORDER BY date ASC, created_at ASC`
+
+	var ids []string
+	err := r.db.Raw(query, args...).Scan(&ids).Error
+	return ids, err
+}
+
 func (r *repository) Delete(id uuid.UUID) error {
 	result := r.db.Exec(
 		`UPDATE journal_entries SET deleted_at = NOW()
@@ -196,3 +406,97 @@ func (r *repository) Delete(id uuid.UUID) error {
 	}
 	return nil
 }
+
+// FindPostableCodes returns the subset of codes that exist, are active and are
+// postable (not header) accounts, so they may receive new journal lines.
+func (r *repository) FindPostableCodes(codes []string) ([]string, error) {
+	var postable []string
+	if len(codes) == 0 {
+		return postable, nil
+	}
+	err := r.db.Raw(
+		`SELECT code FROM chart_of_accounts
+		 WHERE code IN ?
+		 AND is_active = true
+		 AND kind = 'postable'
+		 AND deleted_at IS NULL`,
+		codes,
+	).Scan(&postable).Error
+	return postable, err
+}
+
+// LockChain serialises chain appends until the surrounding transaction ends.
+func (r *repository) LockChain() error {
+	return r.db.Exec(`SELECT pg_advisory_xact_lock(hashtext('journal_entries_chain'))`).Error
+}
+
+const chainColumns = `id, date, reference, COALESCE(description, '') AS description, created_by, chain_seq, prev_hash, hash, deleted_at`
+
+func (r *repository) FindChainHead() (*ChainRow, error) {
+	var row ChainRow
+	result := r.db.Raw(
+		`SELECT ` + chainColumns + `
+		 FROM journal_entries
+		 WHERE chain_seq IS NOT NULL
+		 ORDER BY chain_seq DESC
+		 LIMIT 1`,
+	).Scan(&row)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &row, nil
+}
+
+// FindChainBatch returns chained entries after afterSeq in chain order,
+// including soft-deleted ones so tampering by deletion is visible.
+func (r *repository) FindChainBatch(afterSeq int64, limit int) ([]ChainRow, error) {
+	var rows []ChainRow
+	err := r.db.Raw(
+		`SELECT `+chainColumns+`
+		 FROM journal_entries
+		 WHERE chain_seq > ?
+		 ORDER BY chain_seq ASC
+		 LIMIT ?`,
+		afterSeq, limit,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repository) FindChainByDateRange(startDate, endDate string) ([]ChainRow, error) {
+	var rows []ChainRow
+	err := r.db.Raw(
+		`SELECT `+chainColumns+`
+		 FROM journal_entries
+		 WHERE chain_seq IS NOT NULL
+		 AND date >= ? AND date <= ?
+		 ORDER BY chain_seq ASC`,
+		startDate, endDate,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repository) FindChainDetails(entryIDs []string) ([]ChainDetailRow, error) {
+	var rows []ChainDetailRow
+	if len(entryIDs) == 0 {
+		return rows, nil
+	}
+	err := r.db.Raw(
+		`SELECT journal_entry_id, COALESCE(chained_coa_code, coa_code) AS coa_code, debit, credit, COALESCE(description, '') AS description
+		 FROM journal_entry_details
+		 WHERE journal_entry_id IN ?
+		 AND deleted_at IS NULL`,
+		entryIDs,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repository) AssignChain(id uuid.UUID, seq int64, prevHash *string, hash string) error {
+	return r.db.Exec(
+		`UPDATE journal_entries SET chain_seq = ?, prev_hash = ?, hash = ? WHERE id = ? AND chain_seq IS NULL`,
+		seq, prevHash, hash, id,
+	).Error
+}
@@ -0,0 +1,126 @@
+package journal
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/event"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetPendingReversals lists the posted accruals whose reversal has not been
+// booked yet, soonest first.
+func (s *service) GetPendingReversals() ([]PendingReversal, error) {
+	rows, err := s.repo.FindPendingReversals(nil)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rows == nil {
+		rows = []PendingReversal{}
+	}
+
+	today := utils.DateOf(time.Now())
+	for i := range rows {
+		rows[i].Due = !utils.DateOf(rows[i].ReverseOn).After(today)
+	}
+	return rows, nil
+}
+
+// RunReversals books and posts the reversal of every accrual due by now.
+// A reversal is dated on its ReverseOn day even when the job runs late, so
+// it lands in the period the accrual was meant to be undone in. Each one
+// runs in its own transaction; a failing one is retried on the next pass.
+func (s *service) RunReversals(db *gorm.DB, now time.Time) (*ReversalRunResult, error) {
+	today := utils.DateOf(now)
+
+	rows, err := s.repo.FindPendingReversals(&today)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ReversalRunResult{}
+	for _, row := range rows {
+		var booked bool
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			booked, err = s.reverse(tx, uuid.MustParse(row.JournalID))
+			return err
+		})
+		if err != nil {
+			res.Failed = append(res.Failed, ReversalFailure{JournalID: row.JournalID, Reference: row.Reference, Reason: err.Error()})
+			log.Printf("reversal: journal %s: %v", row.Reference, err)
+			continue
+		}
+		if booked {
+			res.Created++
+		}
+	}
+	return res, nil
+}
+
+// reverse books the reversal of one accrual. It reports false when the
+// reversal already exists, e.g. booked by another instance of the API.
+func (s *service) reverse(tx *gorm.DB, id uuid.UUID) (bool, error) {
+	txRepo := NewRepository(tx)
+
+	if err := txRepo.LockEntry(id); err != nil {
+		return false, err
+	}
+	exists, err := txRepo.HasReversal(id)
+	if err != nil || exists {
+		return false, err
+	}
+
+	original, err := findDetailed(txRepo, id)
+	if err != nil {
+		return false, err
+	}
+	if original.Status != string(domain.JournalStatusPosted) || original.ReverseOn == nil {
+		return false, nil
+	}
+
+	details := make([]JournalDetailRequest, len(original.Details))
+	for i, d := range original.Details {
+		details[i] = JournalDetailRequest{
+			CoaCode:     d.CoaCode,
+			Debit:       d.Credit,
+			Credit:      d.Debit,
+			Description: d.Description,
+			Dimensions:  d.Dimensions,
+		}
+	}
+
+	actor := audit.Actor{ID: original.CreatedBy, Name: "scheduler", Type: audit.ActorTypeSystem}
+	reversal, err := CreateDraft(tx, DraftInput{
+		Date:        *original.ReverseOn,
+		Prefix:      "REV",
+		Description: fmt.Sprintf("Reversal of %s: %s", original.Reference, original.Description),
+		Type:        domain.JournalTypeReversal,
+		Details:     details,
+		ReversalOf:  &id,
+	}, actor)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.PostJournal(uuid.MustParse(reversal.ID), actor, tx); err != nil {
+		return false, err
+	}
+	if err := event.Publish(tx, event.TypeJournalReversed, audit.EntityJournal, original.ID, event.JournalReversal{
+		JournalID:         original.ID,
+		Reference:         original.Reference,
+		ReversalID:        reversal.ID,
+		ReversalReference: reversal.Reference,
+		Date:              original.ReverseOn.Format("2006-01-02"),
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
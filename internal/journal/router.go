@@ -1,6 +1,7 @@
 package journal
 
 import (
+	"fiber.com/session-api/internal/apikey"
 	"fiber.com/session-api/pkg/middleware"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,12 +10,20 @@ import (
 
 func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
 	journalRoutes := router.Group("/journal")
-	journalRoutes.Use(middleware.AuthMiddleware())
+	journalRoutes.Use(middleware.AuthMiddleware(), middleware.Idempotency(db))
 
-	journalRoutes.Get("/", handler.GetAll)
-	journalRoutes.Get("/:id", handler.GetByID)
-	journalRoutes.Put("/:id/post", handler.PostJournal)
-	journalRoutes.Delete("/:id", handler.Delete)
+	read := middleware.RequireScope(apikey.ScopeJournalRead)
+	write := middleware.RequireScope(apikey.ScopeJournalWrite)
 
-	journalRoutes.Post("/", middleware.DBTransaction(db), handler.Create)
+	journalRoutes.Get("/", read, handler.GetAll)
+	journalRoutes.Get("/reversals/pending", read, handler.GetPendingReversals)
+	journalRoutes.Post("/bulk", write, handler.Bulk)
+	journalRoutes.Get("/chain/verify", middleware.RequireRole("admin"), handler.VerifyChain)
+	journalRoutes.Get("/chain/checkpoint", middleware.RequireRole("admin"), handler.ExportCheckpoint)
+	journalRoutes.Get("/:id", read, handler.GetByID)
+	journalRoutes.Get("/:id/history", read, handler.GetHistory)
+	journalRoutes.Put("/:id/post", write, middleware.DBTransaction(db), handler.PostJournal)
+	journalRoutes.Delete("/:id", write, middleware.DBTransaction(db), handler.Delete)
+
+	journalRoutes.Post("/", write, middleware.DBTransaction(db), handler.Create)
 }
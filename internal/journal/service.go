@@ -1,13 +1,19 @@
 package journal
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
+	"fiber.com/session-api/internal/audit"
 	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/event"
+	"fiber.com/session-api/internal/subledger"
 	"fiber.com/session-api/pkg/model"
+	"fiber.com/session-api/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -17,17 +23,25 @@ import (
 type Service interface {
 	GetAll(req *model.PaginationRequest) ([]JournalListResponse, *model.MetaPagination, error)
 	GetByID(id uuid.UUID) (*JournalDetailedResponse, error)
-	Create(req *CreateJournalRequest, createdBy uuid.UUID, tx *gorm.DB) (*JournalDetailedResponse, error)
-	PostJournal(id uuid.UUID) error
-	Delete(id uuid.UUID) error
+	GetHistory(id uuid.UUID, req *model.PaginationRequest) ([]audit.AuditLogResponse, *model.MetaPagination, error)
+	Create(req *CreateJournalRequest, actor audit.Actor, tx *gorm.DB) (*JournalDetailedResponse, error)
+	PostJournal(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	GetPendingReversals() ([]PendingReversal, error)
+	Bulk(db *gorm.DB, req *BulkRequest, actor audit.Actor) (*BulkResult, error)
+	RunBulk(ctx context.Context, db *gorm.DB, action, mode string, ids []string, actor audit.Actor, progress func(processed, succeeded, failed int)) *BulkResult
+	RunReversals(db *gorm.DB, now time.Time) (*ReversalRunResult, error)
+	Delete(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	VerifyChain() (*ChainVerifyResponse, error)
+	ExportCheckpoint(period string) (*ChainCheckpoint, error)
 }
 
 type service struct {
-	repo Repository
+	repo         Repository
+	auditService audit.Service
 }
 
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
+func NewService(repo Repository, auditService audit.Service) Service {
+	return &service{repo: repo, auditService: auditService}
 }
 
 func (s *service) GetAll(req *model.PaginationRequest) ([]JournalListResponse, *model.MetaPagination, error) {
@@ -48,7 +62,72 @@ func (s *service) GetAll(req *model.PaginationRequest) ([]JournalListResponse, *
 }
 
 func (s *service) GetByID(id uuid.UUID) (*JournalDetailedResponse, error) {
-	entry, details, err := s.repo.FindByID(id)
+	return findDetailed(s.repo, id)
+}
+
+func (s *service) GetHistory(id uuid.UUID, req *model.PaginationRequest) ([]audit.AuditLogResponse, *model.MetaPagination, error) {
+	return s.auditService.GetHistory(audit.EntityJournal, id.String(), req)
+}
+
+// subledgerControls maps the configured control account codes to their
+// subledgers.
+func subledgerControls() map[string]*subledger.Ledger {
+	controls := map[string]*subledger.Ledger{}
+	for _, l := range subledger.Ledgers {
+		if code := l.ControlCode(); code != "" {
+			controls[code] = l
+		}
+	}
+	return controls
+}
+
+// checkPostable rejects journal lines booked to accounts that are missing,
+// deleted, deactivated or header accounts, and lines on a subledger control
+// account from journals its subledger did not generate.
+func checkPostable(repo Repository, codes []string, journalType domain.JournalType) error {
+	postable, err := repo.FindPostableCodes(codes)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	ok := make(map[string]bool, len(postable))
+	for _, code := range postable {
+		ok[code] = true
+	}
+	for _, code := range codes {
+		if !ok[code] {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("COA %s does not exist, is inactive or is a header account and cannot receive postings", code))
+		}
+	}
+
+	controls := subledgerControls()
+	for _, code := range codes {
+		if control, ok := controls[code]; ok && !slices.Contains(control.JournalTypes, journalType) {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+				"COA %s is the %s control account and only takes postings from the %s subledger", code, control.Name, control.Name,
+			))
+		}
+	}
+	return nil
+}
+
+// ValidateDetails checks the lines of a journal of the given type against the
+// account and dimension rules, as done before a journal is created.
+func ValidateDetails(repo Repository, details []JournalDetailRequest, journalType domain.JournalType) error {
+	codes := make([]string, len(details))
+	lines := make([]lineDimensions, len(details))
+	for i, d := range details {
+		codes[i] = d.CoaCode
+		lines[i] = lineDimensions{CoaCode: d.CoaCode, Dimensions: d.Dimensions}
+	}
+	if err := checkPostable(repo, codes, journalType); err != nil {
+		return err
+	}
+	return checkDimensions(repo, lines)
+}
+
+func findDetailed(repo Repository, id uuid.UUID) (*JournalDetailedResponse, error) {
+	entry, details, err := repo.FindByID(id)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -56,6 +135,10 @@ func (s *service) GetByID(id uuid.UUID) (*JournalDetailedResponse, error) {
 		return nil, fiber.NewError(fiber.StatusNotFound, "Journal entry not found")
 	}
 
+	return toDetailedResponse(entry, details), nil
+}
+
+func toDetailedResponse(entry *domain.JournalEntry, details []JournalDetailRow) *JournalDetailedResponse {
 	detailResponses := make([]JournalDetailResponse, len(details))
 	for i, d := range details {
 		detailResponses[i] = JournalDetailResponse{
@@ -65,40 +148,100 @@ func (s *service) GetByID(id uuid.UUID) (*JournalDetailedResponse, error) {
 			Debit:       d.Debit,
 			Credit:      d.Credit,
 			Description: d.Description,
+			Dimensions:  d.Dimensions,
 		}
 	}
 
-	return &JournalDetailedResponse{
+	res := &JournalDetailedResponse{
 		ID:          entry.ID.String(),
 		Date:        entry.Date,
 		Reference:   entry.Reference,
 		Description: entry.Description,
 		Status:      string(entry.Status),
+		Type:        string(entry.Type),
 		CreatedBy:   entry.CreatedBy.String(),
+		ReverseOn:   entry.ReverseOn,
 		Details:     detailResponses,
-	}, nil
+	}
+	if entry.ReversalOfID != nil {
+		res.ReversalOf = entry.ReversalOfID.String()
+	}
+	return res
 }
 
-func (s *service) Create(req *CreateJournalRequest, createdBy uuid.UUID, tx *gorm.DB) (*JournalDetailedResponse, error) {
+func (s *service) Create(req *CreateJournalRequest, actor audit.Actor, tx *gorm.DB) (*JournalDetailedResponse, error) {
+	now := time.Now()
+
+	var reverseOn *time.Time
+	if req.ReverseOn != "" {
+		parsed, err := time.Parse("2006-01-02", req.ReverseOn)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "reverseOn must use the YYYY-MM-DD format")
+		}
+		if !parsed.After(utils.DateOf(now)) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "reverseOn must be after the journal date")
+		}
+		reverseOn = &parsed
+	}
+
+	return CreateDraft(tx, DraftInput{
+		Date:        now,
+		Prefix:      "JRN",
+		Description: req.Description,
+		Type:        domain.JournalTypeGeneral,
+		Details:     req.Details,
+		ReverseOn:   reverseOn,
+	}, actor)
+}
+
+// DraftInput describes a draft journal entry. Prefix starts the generated
+// reference, e.g. "JRN" gives JRN-20260131-1A2B. ReverseOn and ReversalOf
+// are only set for accruals and their automatic reversals.
+type DraftInput struct {
+	Date        time.Time
+	Prefix      string
+	Description string
+	Type        domain.JournalType
+	Details     []JournalDetailRequest
+	ReverseOn   *time.Time
+	ReversalOf  *uuid.UUID
+}
+
+// CreateDraft validates and stores a draft journal entry inside tx and
+// records it in the audit trail. Modules that generate journals use it so
+// their drafts obey the same account and dimension rules as manual ones.
+func CreateDraft(tx *gorm.DB, in DraftInput, actor audit.Actor) (*JournalDetailedResponse, error) {
 	txRepo := NewRepository(tx)
 
+	createdBy, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+
+	if err := ValidateDetails(txRepo, in.Details, in.Type); err != nil {
+		return nil, err
+	}
+
 	entryID := uuid.New()
 
-	now := time.Now()
 	refSuffix := strings.ToUpper(uuid.New().String()[0:4])
-	reference := fmt.Sprintf("JRN-%s-%s", now.Format("20060102"), refSuffix)
+	reference := fmt.Sprintf("%s-%s-%s", in.Prefix, in.Date.Format("20060102"), refSuffix)
 
 	entry := &domain.JournalEntry{
 		ID:          entryID,
-		Date:        now,
+		Date:        in.Date,
 		Reference:   reference,
-		Description: req.Description,
+		Description: in.Description,
 		Status:      domain.JournalStatusDraft,
+		Type:        in.Type,
 		CreatedBy:   createdBy,
+
+		ReverseOn:    in.ReverseOn,
+		ReversalOfID: in.ReversalOf,
 	}
 
-	details := make([]domain.JournalEntryDetail, len(req.Details))
-	for i, d := range req.Details {
+	details := make([]domain.JournalEntryDetail, len(in.Details))
+	for i, d := range in.Details {
 		details[i] = domain.JournalEntryDetail{
 			JournalEntryID: entryID.String(),
 			CoaCode:        d.CoaCode,
@@ -112,6 +255,23 @@ func (s *service) Create(req *CreateJournalRequest, createdBy uuid.UUID, tx *gor
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	var dims []domain.JournalLineDimension
+	for i, d := range in.Details {
+		for dim, value := range d.Dimensions {
+			if value == "" {
+				continue
+			}
+			dims = append(dims, domain.JournalLineDimension{
+				DetailID:      details[i].ID,
+				DimensionCode: dim,
+				ValueCode:     value,
+			})
+		}
+	}
+	if err := txRepo.CreateLineDimensions(dims); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
 	entryResult, detailsResult, err := txRepo.FindByID(entryID)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
@@ -120,51 +280,81 @@ func (s *service) Create(req *CreateJournalRequest, createdBy uuid.UUID, tx *gor
 		return nil, fiber.NewError(fiber.StatusNotFound, "Journal entry not found (transaction issue)")
 	}
 
-	detailResponses := make([]JournalDetailResponse, len(detailsResult))
-	for i, d := range detailsResult {
-		detailResponses[i] = JournalDetailResponse{
-			ID:          d.ID,
-			CoaCode:     d.CoaCode,
-			CoaName:     d.CoaName,
-			Debit:       d.Debit,
-			Credit:      d.Credit,
-			Description: d.Description,
-		}
-	}
+	result := toDetailedResponse(entryResult, detailsResult)
 
-	result := &JournalDetailedResponse{
-		ID:          entryResult.ID.String(),
-		Date:        entryResult.Date,
-		Reference:   entryResult.Reference,
-		Description: entryResult.Description,
-		Status:      string(entryResult.Status),
-		CreatedBy:   entryResult.CreatedBy.String(),
-		Details:     detailResponses,
+	if err := audit.Record(tx, actor, audit.EntityJournal, result.ID, audit.ActionCreate, nil, result); err != nil {
+		return nil, err
+	}
+	if err := event.Publish(tx, event.TypeJournalCreated, audit.EntityJournal, result.ID, result); err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
 
-func (s *service) PostJournal(id uuid.UUID) error {
-	entry, _, err := s.repo.FindByID(id)
+func (s *service) PostJournal(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	before, err := findDetailed(txRepo, id)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return err
 	}
-	if entry == nil {
-		return fiber.NewError(fiber.StatusNotFound, "Journal entry not found")
+
+	codes := make([]string, len(before.Details))
+	lines := make([]lineDimensions, len(before.Details))
+	for i, d := range before.Details {
+		codes[i] = d.CoaCode
+		lines[i] = lineDimensions{CoaCode: d.CoaCode, Dimensions: d.Dimensions}
+	}
+	if err := checkPostable(txRepo, codes, domain.JournalType(before.Type)); err != nil {
+		return err
+	}
+	if err := checkDimensions(txRepo, lines); err != nil {
+		return err
+	}
+
+	if err := txRepo.PostJournal(id); err != nil {
+		return err
+	}
+
+	if err := AppendToChain(txRepo, id); err != nil {
+		return err
+	}
+
+	after, err := findDetailed(txRepo, id)
+	if err != nil {
+		return err
 	}
 
-	return s.repo.PostJournal(id)
+	if err := audit.Record(tx, actor, audit.EntityJournal, id.String(), audit.ActionPost, before, after); err != nil {
+		return err
+	}
+	return event.Publish(tx, event.TypeJournalPosted, audit.EntityJournal, id.String(), after)
 }
 
-func (s *service) Delete(id uuid.UUID) error {
-	entry, _, err := s.repo.FindByID(id)
+func (s *service) Delete(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	before, err := findDetailed(txRepo, id)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return err
 	}
-	if entry == nil {
-		return fiber.NewError(fiber.StatusNotFound, "Journal entry not found")
+
+	if err := txRepo.Delete(id); err != nil {
+		return err
 	}
 
-	return s.repo.Delete(id)
+	return audit.Record(tx, actor, audit.EntityJournal, id.String(), audit.ActionDelete, before, nil)
+}
+
+func (s *service) VerifyChain() (*ChainVerifyResponse, error) {
+	res, err := VerifyChain(s.repo)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return res, nil
+}
+
+func (s *service) ExportCheckpoint(period string) (*ChainCheckpoint, error) {
+	return BuildCheckpoint(s.repo, period)
 }
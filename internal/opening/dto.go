@@ -0,0 +1,70 @@
+package opening
+
+import "time"
+
+type OpeningLineRequest struct {
+	CoaCode string  `json:"coaCode" example:"1-1001"`
+	Debit   float64 `json:"debit"   example:"15000000"`
+	Credit  float64 `json:"credit"  example:"0"`
+}
+
+// SaveOpeningRequest replaces the whole opening grid. Accounts left out of
+// Lines have a zero opening balance; a line for the suspense account is
+// ignored because it is always recomputed.
+type SaveOpeningRequest struct {
+	Date        string               `json:"date"        example:"2026-07-01"`
+	Description string               `json:"description" example:"Saldo awal go-live"`
+	Lines       []OpeningLineRequest `json:"lines"`
+}
+
+// OpeningAccountRow is one row of the opening grid: a postable balance-sheet
+// account and its opening debit and credit.
+type OpeningAccountRow struct {
+	CoaCode  string  `json:"coaCode"  gorm:"column:code"`
+	CoaName  string  `json:"coaName"  gorm:"column:name"`
+	Type     string  `json:"type"     gorm:"column:type"`
+	IsActive bool    `json:"isActive" gorm:"column:is_active"`
+	Debit    float64 `json:"debit"    gorm:"-"`
+	Credit   float64 `json:"credit"   gorm:"-"`
+	Suspense bool    `json:"suspense" gorm:"-"`
+}
+
+type OpeningLineRow struct {
+	CoaCode string  `gorm:"column:coa_code"`
+	Debit   float64 `gorm:"column:debit"`
+	Credit  float64 `gorm:"column:credit"`
+}
+
+type OpeningEntryRow struct {
+	ID          string    `gorm:"column:id"`
+	Date        time.Time `gorm:"column:date"`
+	Reference   string    `gorm:"column:reference"`
+	Description string    `gorm:"column:description"`
+	ChainSeq    *int64    `gorm:"column:chain_seq"`
+}
+
+// OpeningBalanceResponse is the opening grid. JournalID and Date are empty
+// until the opening balances are first saved; Locked is set once the opening
+// period has been closed and the entry can no longer be edited.
+// SuspenseAmount is the net debit routed to the suspense account to balance
+// the entry; a negative amount is a credit.
+type OpeningBalanceResponse struct {
+	JournalID      string              `json:"journalId,omitempty"`
+	Reference      string              `json:"reference,omitempty"`
+	Date           *time.Time          `json:"date,omitempty"`
+	Description    string              `json:"description"`
+	Locked         bool                `json:"locked"`
+	SuspenseCode   string              `json:"suspenseCode"`
+	SuspenseAmount float64             `json:"suspenseAmount"`
+	TotalDebit     float64             `json:"totalDebit"`
+	TotalCredit    float64             `json:"totalCredit"`
+	Accounts       []OpeningAccountRow `json:"accounts"`
+}
+
+// Swagger Responses
+
+type SwaggerOpeningBalanceResponse struct {
+	Code    int                    `json:"code"`
+	Message string                 `json:"message"`
+	Data    OpeningBalanceResponse `json:"data"`
+}
@@ -0,0 +1,98 @@
+package opening
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// Get godoc
+// @Summary      Get the opening balance grid
+// @Description  Returns every postable asset, liability and equity account with its opening debit and credit, the go-live date and whether the opening period is closed
+// @Tags         Opening Balance
+// @Produce      json
+// @Success      200  {object}  SwaggerOpeningBalanceResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /opening-balance [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	grid, err := h.service.Get()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get opening balances", grid)
+}
+
+// Save godoc
+// @Summary      Save opening balances
+// @Description  Creates or replaces the opening journal at the go-live date. Any difference between debits and credits is booked to the suspense equity account (OPENING_SUSPENSE_CODE), which is created when missing. Lines on accounts that require a dimension are rejected, since opening lines carry none. Rejected once the opening period is closed.
+// @Tags         Opening Balance
+// @Accept       json
+// @Produce      json
+// @Param        body body SaveOpeningRequest true "Opening balance grid"
+// @Success      200  {object}  SwaggerOpeningBalanceResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /opening-balance [put]
+func (h *Handler) Save(c *fiber.Ctx) error {
+	var req SaveOpeningRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if req.Date == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "date is required")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	grid, err := h.service.Save(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Opening balances saved successfully", grid)
+}
+
+// Lock godoc
+// @Summary      Close the opening period
+// @Description  Links the opening journal into the hash chain; after this the opening balances can no longer be edited (admin only)
+// @Tags         Opening Balance
+// @Produce      json
+// @Success      200  {object}  SwaggerOpeningBalanceResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /opening-balance/lock [post]
+func (h *Handler) Lock(c *fiber.Ctx) error {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	grid, err := h.service.Lock(audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Opening period closed", grid)
+}
@@ -0,0 +1,101 @@
+package opening
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAccounts() ([]OpeningAccountRow, error)
+	FindEntry() (*OpeningEntryRow, error)
+	FindLines(entryID string) ([]OpeningLineRow, error)
+	UpdateEntry(entryID string, date time.Time, reference, description string) error
+	ReplaceLines(entryID string, lines []OpeningLineRow) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// FindAccounts returns every live postable balance-sheet account, which are
+// the rows of the opening grid.
+func (r *repository) FindAccounts() ([]OpeningAccountRow, error) {
+	var rows []OpeningAccountRow
+	err := r.db.Raw(
+		`SELECT code, name, type, is_active
+		 FROM chart_of_accounts
+		 WHERE type IN ('asset', 'liability', 'equity')
+		 AND kind = 'postable'
+		 AND deleted_at IS NULL
+		 ORDER BY code ASC`,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repository) FindEntry() (*OpeningEntryRow, error) {
+	var row OpeningEntryRow
+	result := r.db.Raw(
+		`SELECT id, date, reference, COALESCE(description, '') AS description, chain_seq
+		 FROM journal_entries
+		 WHERE type = 'opening'
+		 AND deleted_at IS NULL
+		 LIMIT 1`,
+	).Scan(&row)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &row, nil
+}
+
+func (r *repository) FindLines(entryID string) ([]OpeningLineRow, error) {
+	var rows []OpeningLineRow
+	err := r.db.Raw(
+		`SELECT coa_code, debit, credit
+		 FROM journal_entry_details
+		 WHERE journal_entry_id = ?
+		 AND deleted_at IS NULL`,
+		entryID,
+	).Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repository) UpdateEntry(entryID string, date time.Time, reference, description string) error {
+	return r.db.Exec(
+		`UPDATE journal_entries SET date = ?, reference = ?, description = ?, updated_at = NOW()
+		 WHERE id = ? AND type = 'opening' AND chain_seq IS NULL AND deleted_at IS NULL`,
+		date, reference, description, entryID,
+	).Error
+}
+
+// ReplaceLines soft-deletes the current lines of the opening entry and
+// inserts lines in their place, so earlier versions stay in the database.
+func (r *repository) ReplaceLines(entryID string, lines []OpeningLineRow) error {
+	if err := r.db.Exec(
+		`UPDATE journal_entry_details SET deleted_at = NOW()
+		 WHERE journal_entry_id = ? AND deleted_at IS NULL`,
+		entryID,
+	).Error; err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if err := r.db.Exec(
+			`INSERT INTO journal_entry_details (id, journal_entry_id, coa_code, debit, credit, description)
+			 VALUES (gen_random_uuid(), ?, ?, ?, ?, ?)`,
+			entryID, line.CoaCode, line.Debit, line.Credit, "Opening balance",
+		).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
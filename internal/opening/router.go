@@ -0,0 +1,21 @@
+package opening
+
+import (
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	openingRoutes := router.Group("/opening-balance")
+	openingRoutes.Use(middleware.AuthMiddleware(), middleware.Idempotency(db))
+
+	read := middleware.RequireScope(apikey.ScopeJournalRead)
+	write := middleware.RequireScope(apikey.ScopeJournalWrite)
+
+	openingRoutes.Get("/", read, handler.Get)
+	openingRoutes.Put("/", write, middleware.DBTransaction(db), handler.Save)
+	openingRoutes.Post("/lock", middleware.RequireRole("admin"), middleware.DBTransaction(db), handler.Lock)
+}
@@ -0,0 +1,357 @@
+package opening
+
+import (
+	"fmt"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/coa"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/event"
+	"fiber.com/session-api/internal/journal"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const suspenseName = "Opening Balance Suspense"
+
+type Service interface {
+	Get() (*OpeningBalanceResponse, error)
+	Save(req *SaveOpeningRequest, actor audit.Actor, tx *gorm.DB) (*OpeningBalanceResponse, error)
+	Lock(actor audit.Actor, tx *gorm.DB) (*OpeningBalanceResponse, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) Get() (*OpeningBalanceResponse, error) {
+	return buildGrid(s.repo)
+}
+
+// buildGrid lists every balance-sheet account with its line on the opening
+// entry, if any.
+func buildGrid(repo Repository) (*OpeningBalanceResponse, error) {
+	accounts, err := repo.FindAccounts()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if accounts == nil {
+		accounts = []OpeningAccountRow{}
+	}
+
+	entry, err := repo.FindEntry()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	suspenseCode := config.AppConfig.OpeningSuspenseCode
+	res := &OpeningBalanceResponse{SuspenseCode: suspenseCode}
+
+	lines := map[string]OpeningLineRow{}
+	if entry != nil {
+		res.JournalID = entry.ID
+		res.Reference = entry.Reference
+		res.Date = &entry.Date
+		res.Description = entry.Description
+		res.Locked = entry.ChainSeq != nil
+
+		rows, err := repo.FindLines(entry.ID)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		for _, l := range rows {
+			line := lines[l.CoaCode]
+			line.Debit += l.Debit
+			line.Credit += l.Credit
+			lines[l.CoaCode] = line
+
+			res.TotalDebit += l.Debit
+			res.TotalCredit += l.Credit
+			if l.CoaCode == suspenseCode {
+				res.SuspenseAmount += l.Debit - l.Credit
+			}
+		}
+	}
+
+	for i := range accounts {
+		line := lines[accounts[i].CoaCode]
+		accounts[i].Debit = line.Debit
+		accounts[i].Credit = line.Credit
+		accounts[i].Suspense = accounts[i].CoaCode == suspenseCode
+	}
+	res.Accounts = accounts
+	res.TotalDebit = utils.Round2(res.TotalDebit)
+	res.TotalCredit = utils.Round2(res.TotalCredit)
+	res.SuspenseAmount = utils.Round2(res.SuspenseAmount)
+
+	return res, nil
+}
+
+// checkLines validates the submitted grid and returns the non-zero lines.
+// Every line must belong to an active postable balance-sheet account and
+// carry either a debit or a credit, not both.
+func checkLines(repo Repository, req []OpeningLineRequest, suspenseCode string) ([]OpeningLineRow, error) {
+	accounts, err := repo.FindAccounts()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	byCode := make(map[string]OpeningAccountRow, len(accounts))
+	for _, a := range accounts {
+		byCode[a.CoaCode] = a
+	}
+
+	seen := map[string]bool{}
+	var lines []OpeningLineRow
+	for _, l := range req {
+		if l.CoaCode == suspenseCode {
+			continue
+		}
+		if seen[l.CoaCode] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("COA %s appears more than once", l.CoaCode))
+		}
+		seen[l.CoaCode] = true
+
+		account, ok := byCode[l.CoaCode]
+		if !ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("COA %s is not a postable asset, liability or equity account", l.CoaCode))
+		}
+		if !account.IsActive {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("COA %s is inactive", l.CoaCode))
+		}
+
+		debit, credit := utils.Round2(l.Debit), utils.Round2(l.Credit)
+		if debit < 0 || credit < 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("COA %s: debit and credit cannot be negative", l.CoaCode))
+		}
+		if debit > 0 && credit > 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("COA %s: fill either debit or credit, not both", l.CoaCode))
+		}
+		if debit == 0 && credit == 0 {
+			continue
+		}
+
+		lines = append(lines, OpeningLineRow{CoaCode: l.CoaCode, Debit: debit, Credit: credit})
+	}
+
+	if len(lines) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Opening balances must have at least one non-zero line")
+	}
+	return lines, nil
+}
+
+// checkDimensions rejects lines on accounts that require a dimension. The
+// grid has one untagged line per account, so such a line could never be
+// posted through a journal; the control-account journal-type rule does not
+// apply here, since opening balances are how control accounts get theirs.
+func checkDimensions(tx *gorm.DB, lines []OpeningLineRow) error {
+	codes := make([]string, len(lines))
+	for i, l := range lines {
+		codes[i] = l.CoaCode
+	}
+	rules, err := journal.NewRepository(tx).FindDimensionRules(codes)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	for _, r := range rules {
+		if r.Required {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+				"COA %s requires dimension %s, which opening balance lines cannot carry; make the dimension optional on the account first",
+				r.CoaCode, r.DimensionCode,
+			))
+		}
+	}
+	return nil
+}
+
+// ensureSuspense makes sure the configured suspense account can take the
+// balancing line, creating it as a root equity account when it is missing.
+func ensureSuspense(tx *gorm.DB, code string, actor audit.Actor) error {
+	coaRepo := coa.NewRepository(tx)
+
+	account, err := coaRepo.FindByCode(code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if account != nil {
+		if account.Type != domain.AccountTypeEquity || account.Kind != domain.AccountKindPostable || !account.IsActive {
+			return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Suspense account %s must be an active postable equity account", code))
+		}
+		return nil
+	}
+
+	account = &domain.ChartOfAccount{
+		Code:     code,
+		Name:     suspenseName,
+		Type:     domain.AccountTypeEquity,
+		Kind:     domain.AccountKindPostable,
+		IsActive: true,
+	}
+	if err := coaRepo.Upsert(account); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := audit.Record(tx, actor, audit.EntityCOA, code, audit.ActionCreate, nil, account); err != nil {
+		return err
+	}
+	return event.PublishCOAChange(tx, code, audit.ActionCreate, nil, account)
+}
+
+func (s *service) Save(req *SaveOpeningRequest, actor audit.Actor, tx *gorm.DB) (*OpeningBalanceResponse, error) {
+	txRepo := NewRepository(tx)
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "date must use the YYYY-MM-DD format")
+	}
+
+	entry, err := txRepo.FindEntry()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if entry != nil && entry.ChainSeq != nil {
+		return nil, fiber.NewError(fiber.StatusConflict, "Opening balances are locked because the opening period is closed")
+	}
+
+	suspenseCode := config.AppConfig.OpeningSuspenseCode
+	lines, err := checkLines(txRepo, req.Lines, suspenseCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var totalDebit, totalCredit float64
+	for _, l := range lines {
+		totalDebit += l.Debit
+		totalCredit += l.Credit
+	}
+	if diff := utils.Round2(totalDebit - totalCredit); diff != 0 {
+		if err := ensureSuspense(tx, suspenseCode, actor); err != nil {
+			return nil, err
+		}
+		suspense := OpeningLineRow{CoaCode: suspenseCode}
+		if diff > 0 {
+			suspense.Credit = diff
+		} else {
+			suspense.Debit = -diff
+		}
+		lines = append(lines, suspense)
+	}
+	if err := checkDimensions(tx, lines); err != nil {
+		return nil, err
+	}
+
+	description := req.Description
+	if description == "" {
+		description = "Opening balance"
+	}
+	reference := fmt.Sprintf("OPEN-%s", date.Format("20060102"))
+
+	if entry == nil {
+		createdBy, err := uuid.Parse(actor.ID)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+		}
+
+		entryID := uuid.New()
+		details := make([]domain.JournalEntryDetail, len(lines))
+		for i, l := range lines {
+			details[i] = domain.JournalEntryDetail{
+				JournalEntryID: entryID.String(),
+				CoaCode:        l.CoaCode,
+				Debit:          l.Debit,
+				Credit:         l.Credit,
+				Description:    "Opening balance",
+			}
+		}
+
+		if err := journal.NewRepository(tx).Create(&domain.JournalEntry{
+			ID:          entryID,
+			Date:        date,
+			Reference:   reference,
+			Description: description,
+			Status:      domain.JournalStatusPosted,
+			Type:        domain.JournalTypeOpening,
+			CreatedBy:   createdBy,
+		}, details); err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		after, err := buildGrid(txRepo)
+		if err != nil {
+			return nil, err
+		}
+		if err := audit.Record(tx, actor, audit.EntityJournal, entryID.String(), audit.ActionCreate, nil, after); err != nil {
+			return nil, err
+		}
+		if err := event.Publish(tx, event.TypeJournalCreated, audit.EntityJournal, entryID.String(), after); err != nil {
+			return nil, err
+		}
+		return after, nil
+	}
+
+	before, err := buildGrid(txRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txRepo.UpdateEntry(entry.ID, date, reference, description); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := txRepo.ReplaceLines(entry.ID, lines); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := buildGrid(txRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityJournal, entry.ID, audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Lock closes the opening period: the opening entry joins the journal hash
+// chain and can no longer be edited.
+func (s *service) Lock(actor audit.Actor, tx *gorm.DB) (*OpeningBalanceResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := buildGrid(txRepo)
+	if err != nil {
+		return nil, err
+	}
+	if before.JournalID == "" {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Opening balances have not been entered")
+	}
+	if before.Locked {
+		return nil, fiber.NewError(fiber.StatusConflict, "Opening balances are already locked")
+	}
+
+	if err := journal.AppendToChain(journal.NewRepository(tx), uuid.MustParse(before.JournalID)); err != nil {
+		return nil, err
+	}
+
+	after, err := buildGrid(txRepo)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityJournal, before.JournalID, audit.ActionLock, before, after); err != nil {
+		return nil, err
+	}
+	if err := event.Publish(tx, event.TypePeriodClosed, "period", "opening", event.PeriodClosure{
+		Period:    "opening",
+		JournalID: before.JournalID,
+		Date:      after.Date.Format("2006-01-02"),
+	}); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
@@ -0,0 +1,251 @@
+package payable
+
+import "time"
+
+type VendorRequest struct {
+	Code            string `json:"code"            validate:"required,max=20"  example:"VND-001"`
+	Name            string `json:"name"            validate:"required,max=150" example:"PT Sumber Makmur"`
+	Email           string `json:"email"           validate:"omitempty,email"  example:"billing@sumbermakmur.co.id"`
+	PaymentTermDays *int   `json:"paymentTermDays" validate:"omitempty,min=0"  example:"30"`
+	IsActive        *bool  `json:"isActive"        validate:"omitempty"        example:"true"`
+}
+
+// VendorResponse carries the amount owed to the vendor over its open
+// bills.
+type VendorResponse struct {
+	ID              string    `json:"id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	Email           string    `json:"email"`
+	PaymentTermDays int       `json:"paymentTermDays"`
+	IsActive        bool      `json:"isActive"`
+	Balance         float64   `json:"balance"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// BillLineRequest debits an expense or asset account. Dimensions are
+// copied to the journal line.
+type BillLineRequest struct {
+	CoaCode     string            `json:"coaCode"     validate:"required" example:"6-1100"`
+	Description string            `json:"description"                     example:"Office rent, January"`
+	Amount      float64           `json:"amount"      validate:"gt=0"     example:"1500000"`
+	Dimensions  map[string]string `json:"dimensions"                      example:"DEPT:OPS"`
+}
+
+// BillRequest creates or replaces a draft bill. DueDate defaults to the
+// date plus the vendor's payment terms.
+type BillRequest struct {
+	VendorID        string            `json:"vendorId"        validate:"required"        example:"3f0c8a0e-2d7b-4a8e-9d1a-6f2b9c1e4a77"`
+	Date            string            `json:"date"            validate:"required"        example:"2026-01-31"`
+	DueDate         string            `json:"dueDate"                                    example:"2026-03-02"`
+	VendorReference string            `json:"vendorReference" validate:"max=50"          example:"INV/2026/01/0042"`
+	Description     string            `json:"description"                                example:"January rent"`
+	Lines           []BillLineRequest `json:"lines"           validate:"required,min=1"`
+}
+
+type BillQuery struct {
+	Page      int    `query:"page"`
+	Limit     int    `query:"limit"`
+	VendorID  string `query:"vendorId"`
+	Status    string `query:"status"`
+	StartDate string `query:"startDate"`
+	EndDate   string `query:"endDate"`
+}
+
+type BillLineResponse struct {
+	CoaCode     string            `json:"coaCode"`
+	Description string            `json:"description"`
+	Amount      float64           `json:"amount"`
+	Dimensions  map[string]string `json:"dimensions,omitempty"`
+}
+
+type BillResponse struct {
+	ID               string             `json:"id"`
+	Number           string             `json:"number"`
+	VendorID         string             `json:"vendorId"`
+	VendorCode       string             `json:"vendorCode"`
+	VendorName       string             `json:"vendorName"`
+	VendorReference  string             `json:"vendorReference"`
+	Date             time.Time          `json:"date"`
+	DueDate          time.Time          `json:"dueDate"`
+	Description      string             `json:"description"`
+	Status           string             `json:"status"`
+	Total            float64            `json:"total"`
+	AmountPaid       float64            `json:"amountPaid"`
+	Outstanding      float64            `json:"outstanding"`
+	JournalEntryID   string             `json:"journalEntryId,omitempty"`
+	JournalReference string             `json:"journalReference,omitempty"`
+	PostedAt         *time.Time         `json:"postedAt,omitempty"`
+	CreatedBy        string             `json:"createdBy"`
+	CreatedAt        time.Time          `json:"createdAt"`
+	UpdatedAt        time.Time          `json:"updatedAt"`
+	Lines            []BillLineResponse `json:"lines,omitempty" gorm:"-"`
+}
+
+type AllocationRequest struct {
+	BillID string  `json:"billId" validate:"required" example:"9b1d7e2a-1c3f-4e5d-8a6b-7c8d9e0f1a2b"`
+	Amount float64 `json:"amount" validate:"gt=0"     example:"1500000"`
+}
+
+// PaymentRequest creates a draft payment. Allocations must add up to the
+// amount and may settle bills partially.
+type PaymentRequest struct {
+	VendorID    string              `json:"vendorId"    validate:"required"       example:"3f0c8a0e-2d7b-4a8e-9d1a-6f2b9c1e4a77"`
+	Date        string              `json:"date"        validate:"required"       example:"2026-02-15"`
+	Amount      float64             `json:"amount"      validate:"gt=0"           example:"1500000"`
+	BankCoaCode string              `json:"bankCoaCode" validate:"required"       example:"1-1103"`
+	Description string              `json:"description"                           example:"Transfer BCA to PT Sumber Makmur"`
+	Allocations []AllocationRequest `json:"allocations" validate:"required,min=1"`
+}
+
+type PaymentQuery struct {
+	Page     int    `query:"page"`
+	Limit    int    `query:"limit"`
+	VendorID string `query:"vendorId"`
+	Status   string `query:"status"`
+}
+
+type AllocationResponse struct {
+	BillID     string  `json:"billId"`
+	BillNumber string  `json:"billNumber"`
+	Amount     float64 `json:"amount"`
+}
+
+type PaymentResponse struct {
+	ID               string               `json:"id"`
+	Number           string               `json:"number"`
+	VendorID         string               `json:"vendorId"`
+	VendorCode       string               `json:"vendorCode"`
+	VendorName       string               `json:"vendorName"`
+	Date             time.Time            `json:"date"`
+	Amount           float64              `json:"amount"`
+	BankCoaCode      string               `json:"bankCoaCode"`
+	Description      string               `json:"description"`
+	Status           string               `json:"status"`
+	JournalEntryID   string               `json:"journalEntryId,omitempty"`
+	JournalReference string               `json:"journalReference,omitempty"`
+	PostedAt         *time.Time           `json:"postedAt,omitempty"`
+	CreatedBy        string               `json:"createdBy"`
+	CreatedAt        time.Time            `json:"createdAt"`
+	UpdatedAt        time.Time            `json:"updatedAt"`
+	Allocations      []AllocationResponse `json:"allocations,omitempty" gorm:"-"`
+}
+
+// OpenItemQuery lists bills still owed as of a date (default today).
+type OpenItemQuery struct {
+	VendorID string `query:"vendorId"`
+	AsOf     string `query:"asOf"`
+}
+
+// OpenItem is a bill with the amount still owed as of a date: payments
+// dated later are not deducted.
+type OpenItem struct {
+	BillID      string    `json:"billId"`
+	Number      string    `json:"number"`
+	VendorID    string    `json:"vendorId"`
+	VendorCode  string    `json:"vendorCode"`
+	VendorName  string    `json:"vendorName"`
+	Date        time.Time `json:"date"`
+	DueDate     time.Time `json:"dueDate"`
+	Total       float64   `json:"total"`
+	Paid        float64   `json:"paid"`
+	Outstanding float64   `json:"outstanding"`
+}
+
+// OutstandingAmount makes OpenItem a subledger.OpenItem.
+func (o OpenItem) OutstandingAmount() float64 {
+	return o.Outstanding
+}
+
+// PaymentRunQuery proposes payment of the open bills due on or before
+// DueBy.
+type PaymentRunQuery struct {
+	DueBy    string `query:"dueBy"`
+	VendorID string `query:"vendorId"`
+}
+
+// DueBill is an open bill due by the payment run date. InDraftPayments is
+// the part already allocated on payments that are not posted yet.
+type DueBill struct {
+	BillID          string    `json:"billId"`
+	Number          string    `json:"number"`
+	VendorID        string    `json:"-"`
+	VendorCode      string    `json:"-"`
+	VendorName      string    `json:"-"`
+	VendorReference string    `json:"vendorReference"`
+	Date            time.Time `json:"date"`
+	DueDate         time.Time `json:"dueDate"`
+	DaysOverdue     int       `json:"daysOverdue"`
+	Outstanding     float64   `json:"outstanding"`
+	InDraftPayments float64   `json:"inDraftPayments"`
+	Proposed        float64   `json:"proposed"`
+}
+
+type PaymentRunVendor struct {
+	VendorID   string    `json:"vendorId"`
+	VendorCode string    `json:"vendorCode"`
+	VendorName string    `json:"vendorName"`
+	Total      float64   `json:"total"`
+	Bills      []DueBill `json:"bills"`
+}
+
+// PaymentRun groups the proposed amounts by vendor. Each vendor's bills can
+// be paid with one payment allocated as listed.
+type PaymentRun struct {
+	DueBy   time.Time          `json:"dueBy"`
+	Total   float64            `json:"total"`
+	Vendors []PaymentRunVendor `json:"vendors"`
+}
+
+// Swagger Responses
+
+type SwaggerVendorResponse struct {
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Data    VendorResponse `json:"data"`
+}
+
+type SwaggerVendorListResponse struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Data    []VendorResponse `json:"data"`
+}
+
+type SwaggerBillResponse struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Data    BillResponse `json:"data"`
+}
+
+type SwaggerBillListResponse struct {
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Data    []BillResponse `json:"data"`
+	Meta    any            `json:"meta,omitempty"`
+}
+
+type SwaggerPaymentResponse struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    PaymentResponse `json:"data"`
+}
+
+type SwaggerPaymentListResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    []PaymentResponse `json:"data"`
+	Meta    any               `json:"meta,omitempty"`
+}
+
+type SwaggerOpenItemListResponse struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    []OpenItem `json:"data"`
+}
+
+type SwaggerPaymentRunResponse struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    PaymentRun `json:"data"`
+}
@@ -0,0 +1,584 @@
+package payable
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/subledger"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetVendors godoc
+// @Summary      List vendors
+// @Description  Returns every vendor with its outstanding balance over posted bills
+// @Tags         Payable
+// @Produce      json
+// @Success      200  {object}  SwaggerVendorListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/vendors [get]
+func (h *Handler) GetVendors(c *fiber.Ctx) error {
+	vendors, err := h.service.GetVendors()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get all vendors", vendors)
+}
+
+// GetVendor godoc
+// @Summary      Get vendor by ID
+// @Description  Returns a single vendor with its outstanding balance
+// @Tags         Payable
+// @Produce      json
+// @Param        id   path  string  true  "Vendor ID (UUID)"
+// @Success      200  {object}  SwaggerVendorResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/vendors/{id} [get]
+func (h *Handler) GetVendor(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "vendor")
+	if err != nil {
+		return err
+	}
+
+	vendor, err := h.service.GetVendor(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get vendor", vendor)
+}
+
+// CreateVendor godoc
+// @Summary      Create a vendor
+// @Description  Creates a vendor. Payment terms default to 30 days and set the due date of its bills.
+// @Tags         Payable
+// @Accept       json
+// @Produce      json
+// @Param        body body VendorRequest true "Vendor payload"
+// @Success      201  {object}  SwaggerVendorResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/vendors [post]
+func (h *Handler) CreateVendor(c *fiber.Ctx) error {
+	var req VendorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	vendor, err := h.service.CreateVendor(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Vendor created successfully", vendor)
+}
+
+// UpdateVendor godoc
+// @Summary      Update a vendor
+// @Description  Updates a vendor. Omitted paymentTermDays and isActive keep their values.
+// @Tags         Payable
+// @Accept       json
+// @Produce      json
+// @Param        id    path  string         true  "Vendor ID (UUID)"
+// @Param        body  body  VendorRequest  true  "Vendor payload"
+// @Success      200  {object}  SwaggerVendorResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/vendors/{id} [put]
+func (h *Handler) UpdateVendor(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "vendor")
+	if err != nil {
+		return err
+	}
+
+	var req VendorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	vendor, err := h.service.UpdateVendor(id, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Vendor updated successfully", vendor)
+}
+
+// DeleteVendor godoc
+// @Summary      Delete a vendor
+// @Description  Soft-deletes a vendor with no draft or open documents
+// @Tags         Payable
+// @Produce      json
+// @Param        id   path  string  true  "Vendor ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/vendors/{id} [delete]
+func (h *Handler) DeleteVendor(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "vendor")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteVendor(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Vendor deleted successfully", nil)
+}
+
+// GetBills godoc
+// @Summary      List purchase bills
+// @Description  Returns a paginated list of purchase bills, newest first
+// @Tags         Payable
+// @Produce      json
+// @Param        page        query  int     false  "Page number"    minimum(1)
+// @Param        limit       query  int     false  "Items per page" minimum(1) maximum(100)
+// @Param        vendorId    query  string  false  "Vendor ID (UUID)"
+// @Param        status      query  string  false  "draft, open or paid"
+// @Param        startDate   query  string  false  "From bill date (YYYY-MM-DD)"
+// @Param        endDate     query  string  false  "To bill date (YYYY-MM-DD)"
+// @Success      200  {object}  SwaggerBillListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/bills [get]
+func (h *Handler) GetBills(c *fiber.Ctx) error {
+	var query BillQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	subledger.PageLimit(&query.Page, &query.Limit)
+
+	bills, meta, err := h.service.GetBills(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get all bills", bills, meta)
+}
+
+// GetBill godoc
+// @Summary      Get purchase bill by ID
+// @Description  Returns a single purchase bill with its lines
+// @Tags         Payable
+// @Produce      json
+// @Param        id   path  string  true  "Bill ID (UUID)"
+// @Success      200  {object}  SwaggerBillResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/bills/{id} [get]
+func (h *Handler) GetBill(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "bill")
+	if err != nil {
+		return err
+	}
+
+	bill, err := h.service.GetBill(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get bill", bill)
+}
+
+// CreateBill godoc
+// @Summary      Create a purchase bill
+// @Description  Creates a draft purchase bill for an active vendor. Each line debits a postable expense or asset account. vendorReference records the vendor's own invoice number.
+// @Tags         Payable
+// @Accept       json
+// @Produce      json
+// @Param        body body BillRequest true "Bill payload"
+// @Success      201  {object}  SwaggerBillResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/bills [post]
+func (h *Handler) CreateBill(c *fiber.Ctx) error {
+	var req BillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	bill, err := h.service.CreateBill(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Bill created as draft", bill)
+}
+
+// UpdateBill godoc
+// @Summary      Update a purchase bill
+// @Description  Replaces a draft purchase bill and its lines
+// @Tags         Payable
+// @Accept       json
+// @Produce      json
+// @Param        id    path  string       true  "Bill ID (UUID)"
+// @Param        body  body  BillRequest  true  "Bill payload"
+// @Success      200  {object}  SwaggerBillResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/bills/{id} [put]
+func (h *Handler) UpdateBill(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "bill")
+	if err != nil {
+		return err
+	}
+
+	var req BillRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	bill, err := h.service.UpdateBill(id, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Bill updated successfully", bill)
+}
+
+// DeleteBill godoc
+// @Summary      Delete a purchase bill
+// @Description  Deletes a draft purchase bill. Posted bills cannot be deleted.
+// @Tags         Payable
+// @Produce      json
+// @Param        id   path  string  true  "Bill ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/bills/{id} [delete]
+func (h *Handler) DeleteBill(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "bill")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteBill(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Bill deleted successfully", nil)
+}
+
+// PostBill godoc
+// @Summary      Post a purchase bill
+// @Description  Posts a journal dated on the bill date that debits each expense or asset line and credits the AP control account with the total, then opens the bill for payments
+// @Tags         Payable
+// @Produce      json
+// @Param        id   path  string  true  "Bill ID (UUID)"
+// @Success      200  {object}  SwaggerBillResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/bills/{id}/post [put]
+func (h *Handler) PostBill(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "bill")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	bill, err := h.service.PostBill(id, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Bill posted successfully", bill)
+}
+
+// GetPayments godoc
+// @Summary      List payments
+// @Description  Returns a paginated list of vendor payments, newest first
+// @Tags         Payable
+// @Produce      json
+// @Param        page        query  int     false  "Page number"    minimum(1)
+// @Param        limit       query  int     false  "Items per page" minimum(1) maximum(100)
+// @Param        vendorId    query  string  false  "Vendor ID (UUID)"
+// @Param        status      query  string  false  "draft or posted"
+// @Success      200  {object}  SwaggerPaymentListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/payments [get]
+func (h *Handler) GetPayments(c *fiber.Ctx) error {
+	var query PaymentQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	subledger.PageLimit(&query.Page, &query.Limit)
+
+	payments, meta, err := h.service.GetPayments(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get all payments", payments, meta)
+}
+
+// GetPayment godoc
+// @Summary      Get payment by ID
+// @Description  Returns a single payment with its bill allocations
+// @Tags         Payable
+// @Produce      json
+// @Param        id   path  string  true  "Payment ID (UUID)"
+// @Success      200  {object}  SwaggerPaymentResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/payments/{id} [get]
+func (h *Handler) GetPayment(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "payment")
+	if err != nil {
+		return err
+	}
+
+	payment, err := h.service.GetPayment(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get payment", payment)
+}
+
+// CreatePayment godoc
+// @Summary      Create a payment
+// @Description  Creates a draft payment out of a postable asset (bank or cash) account. The allocations must add up to the amount; each may settle part of an open bill of the same vendor.
+// @Tags         Payable
+// @Accept       json
+// @Produce      json
+// @Param        body body PaymentRequest true "Payment payload"
+// @Success      201  {object}  SwaggerPaymentResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/payments [post]
+func (h *Handler) CreatePayment(c *fiber.Ctx) error {
+	var req PaymentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	payment, err := h.service.CreatePayment(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Payment created as draft", payment)
+}
+
+// DeletePayment godoc
+// @Summary      Delete a payment
+// @Description  Deletes a draft payment and its allocations. Posted payments cannot be deleted.
+// @Tags         Payable
+// @Produce      json
+// @Param        id   path  string  true  "Payment ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/payments/{id} [delete]
+func (h *Handler) DeletePayment(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "payment")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeletePayment(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Payment deleted successfully", nil)
+}
+
+// PostPayment godoc
+// @Summary      Post a payment
+// @Description  Posts a journal that debits the AP control account and credits the bank account, and applies the allocations to the bills. Fails if a bill no longer has enough outstanding.
+// @Tags         Payable
+// @Produce      json
+// @Param        id   path  string  true  "Payment ID (UUID)"
+// @Success      200  {object}  SwaggerPaymentResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/payments/{id}/post [put]
+func (h *Handler) PostPayment(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "payment")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	payment, err := h.service.PostPayment(id, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Payment posted successfully", payment)
+}
+
+// GetPaymentRun godoc
+// @Summary      Propose a payment run
+// @Description  Lists the open bills due on or before a date (default today), grouped by vendor, with the amount to pay on each: the outstanding balance less what draft payments already allocate. Each vendor group can be paid with one payment allocated as listed.
+// @Tags         Payable
+// @Produce      json
+// @Param        dueBy     query  string  false  "Due on or before (YYYY-MM-DD)"
+// @Param        vendorId  query  string  false  "Vendor ID (UUID)"
+// @Success      200  {object}  SwaggerPaymentRunResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/payment-run [get]
+func (h *Handler) GetPaymentRun(c *fiber.Ctx) error {
+	var query PaymentRunQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	run, err := h.service.GetPaymentRun(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get payment run proposal", run)
+}
+
+// GetOpenItems godoc
+// @Summary      List open bills
+// @Description  Returns the posted bills with an amount still owed as of a date (default today). Payments dated after that date are not deducted.
+// @Tags         Payable
+// @Produce      json
+// @Param        vendorId  query  string  false  "Vendor ID (UUID)"
+// @Param        asOf      query  string  false  "As-of date (YYYY-MM-DD)"
+// @Success      200  {object}  SwaggerOpenItemListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/open-items [get]
+func (h *Handler) GetOpenItems(c *fiber.Ctx) error {
+	var query OpenItemQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	items, err := h.service.GetOpenItems(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get open items", items)
+}
+
+// Reconcile godoc
+// @Summary      Reconcile the AP control account
+// @Description  Compares the posted balance of the AP control account with the total of open items as of a date (default today). otherEntries is the net of journals on the control account that did not come from a bill or payment.
+// @Tags         Payable
+// @Produce      json
+// @Param        asOf  query  string  false  "As-of date (YYYY-MM-DD)"
+// @Success      200  {object}  subledger.SwaggerReconciliationResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ap/reconciliation [get]
+func (h *Handler) Reconcile(c *fiber.Ctx) error {
+	res, err := h.service.Reconcile(c.Query("asOf"))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success reconcile AP control account", res)
+}
@@ -0,0 +1,490 @@
+package payable
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/subledger"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindVendors() ([]VendorResponse, error)
+	FindVendor(id uuid.UUID) (*VendorResponse, error)
+	FindVendorByCode(code string) (*VendorResponse, error)
+	CreateVendor(v *domain.Vendor) error
+	UpdateVendor(v *domain.Vendor) error
+	DeleteVendor(id uuid.UUID) error
+	CountUnsettled(vendorID uuid.UUID) (int64, error)
+
+	FindBills(query *BillQuery) ([]BillResponse, int64, error)
+	FindBill(id uuid.UUID) (*BillResponse, error)
+	FindBillLines(id uuid.UUID) ([]domain.PurchaseBillLine, error)
+	LockBill(id uuid.UUID) (*domain.PurchaseBill, error)
+	CreateBill(bill *domain.PurchaseBill, lines []domain.PurchaseBillLine) error
+	ReplaceBill(bill *domain.PurchaseBill, lines []domain.PurchaseBillLine) error
+	DeleteBill(id uuid.UUID) error
+	MarkBillPosted(id, journalID uuid.UUID) error
+	ApplyPayment(id uuid.UUID, amount float64) error
+
+	FindPayments(query *PaymentQuery) ([]PaymentResponse, int64, error)
+	FindPayment(id uuid.UUID) (*PaymentResponse, error)
+	FindAllocations(paymentID uuid.UUID) ([]AllocationResponse, error)
+	LockPayment(id uuid.UUID) (*domain.Payment, error)
+	CreatePayment(pm *domain.Payment, allocations []domain.PaymentAllocation) error
+	DeletePayment(id uuid.UUID) error
+	MarkPaymentPosted(id, journalID uuid.UUID) error
+
+	FindOpenItems(asOf time.Time, vendorID string) ([]OpenItem, error)
+	FindDueBills(dueBy time.Time, vendorID string) ([]DueBill, error)
+	ControlBalance(asOf time.Time) (balance, other float64, err error)
+}
+
+type repository struct {
+	db *gorm.DB
+	subledger.Control
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db, Control: subledger.Control{Ledger: subledger.Payable, DB: db}}
+}
+
+const vendorColumns = `v.id, v.code, v.name, COALESCE(v.email, '') AS email, v.payment_term_days, v.is_active,
+	COALESCE((
+		SELECT SUM(b.total - b.amount_paid) FROM purchase_bills b
+		WHERE b.vendor_id = v.id AND b.status = 'open'
+	), 0) AS balance,
+	v.created_at, v.updated_at`
+
+func (r *repository) FindVendors() ([]VendorResponse, error) {
+	var vendors []VendorResponse
+	err := r.db.Raw(
+		`SELECT ` + vendorColumns + `
+		 FROM vendors v
+		 WHERE v.deleted_at IS NULL
+		 ORDER BY v.code ASC`,
+	).Scan(&vendors).Error
+	return vendors, err
+}
+
+func (r *repository) findVendor(where string, arg any) (*VendorResponse, error) {
+	var v VendorResponse
+	result := r.db.Raw(
+		`SELECT `+vendorColumns+`
+		 FROM vendors v
+		 WHERE `+where+` AND v.deleted_at IS NULL
+		 LIMIT 1`,
+		arg,
+	).Scan(&v)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &v, nil
+}
+
+func (r *repository) FindVendor(id uuid.UUID) (*VendorResponse, error) {
+	return r.findVendor("v.id = ?", id)
+}
+
+func (r *repository) FindVendorByCode(code string) (*VendorResponse, error) {
+	return r.findVendor("v.code = ?", code)
+}
+
+func (r *repository) CreateVendor(v *domain.Vendor) error {
+	return r.db.Exec(
+		`INSERT INTO vendors (id, code, name, email, payment_term_days, is_active, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		v.ID, v.Code, v.Name, v.Email, v.PaymentTermDays, v.IsActive,
+	).Error
+}
+
+func (r *repository) UpdateVendor(v *domain.Vendor) error {
+	return r.db.Exec(
+		`UPDATE vendors
+		 SET code = ?, name = ?, email = ?, payment_term_days = ?, is_active = ?, updated_at = NOW()
+		 WHERE id = ? AND deleted_at IS NULL`,
+		v.Code, v.Name, v.Email, v.PaymentTermDays, v.IsActive, v.ID,
+	).Error
+}
+
+func (r *repository) DeleteVendor(id uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE vendors SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`,
+		id,
+	).Error
+}
+
+// CountUnsettled counts the vendor's draft or open bills and draft
+// payments.
+func (r *repository) CountUnsettled(vendorID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Raw(
+		`SELECT
+			(SELECT COUNT(*) FROM purchase_bills WHERE vendor_id = ? AND status IN ('draft', 'open')) +
+			(SELECT COUNT(*) FROM payments WHERE vendor_id = ? AND status = 'draft')`,
+		vendorID, vendorID,
+	).Scan(&count).Error
+	return count, err
+}
+
+const billColumns = `b.id, b.number, b.vendor_id, v.code AS vendor_code, v.name AS vendor_name,
+	COALESCE(b.vendor_reference, '') AS vendor_reference, b.date, b.due_date, COALESCE(b.description, '') AS description, b.status, b.total, b.amount_paid,
+	b.total - b.amount_paid AS outstanding, COALESCE(b.journal_entry_id::text, '') AS journal_entry_id,
+	COALESCE(j.reference, '') AS journal_reference, b.posted_at, b.created_by, b.created_at, b.updated_at`
+
+const billFrom = `FROM purchase_bills b
+	JOIN vendors v ON v.id = b.vendor_id
+	LEFT JOIN journal_entries j ON j.id = b.journal_entry_id`
+
+func (r *repository) FindBills(query *BillQuery) ([]BillResponse, int64, error) {
+	where := "1 = 1"
+	var args []interface{}
+	if query.VendorID != "" {
+		where += " AND b.vendor_id = ?"
+		args = append(args, query.VendorID)
+	}
+	if query.Status != "" {
+		where += " AND b.status = ?"
+		args = append(args, query.Status)
+	}
+	if query.StartDate != "" {
+		where += " AND b.date >= ?"
+		args = append(args, query.StartDate)
+	}
+	if query.EndDate != "" {
+		where += " AND b.date <= ?"
+		args = append(args, query.EndDate)
+	}
+
+	var total int64
+	if err := r.db.Raw(`SELECT COUNT(*) FROM purchase_bills b WHERE `+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []BillResponse
+	err := r.db.Raw(
+		`SELECT `+billColumns+`
+		 `+billFrom+`
+		 WHERE `+where+`
+		 ORDER BY b.date DESC, b.number DESC
+		 LIMIT ? OFFSET ?`,
+		append(args, query.Limit, (query.Page-1)*query.Limit)...,
+	).Scan(&rows).Error
+	return rows, total, err
+}
+
+func (r *repository) FindBill(id uuid.UUID) (*BillResponse, error) {
+	var bill BillResponse
+	result := r.db.Raw(
+		`SELECT `+billColumns+`
+		 `+billFrom+`
+		 WHERE b.id = ?
+		 LIMIT 1`,
+		id,
+	).Scan(&bill)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &bill, nil
+}
+
+func (r *repository) FindBillLines(id uuid.UUID) ([]domain.PurchaseBillLine, error) {
+	var lines []domain.PurchaseBillLine
+	err := r.db.Raw(
+		`SELECT id, bill_id, seq, coa_code, COALESCE(description, '') AS description, amount, dimensions
+		 FROM purchase_bill_lines
+		 WHERE bill_id = ?
+		 ORDER BY seq ASC`,
+		id,
+	).Scan(&lines).Error
+	return lines, err
+}
+
+// LockBill loads a bill and locks its row until the transaction
+// ends, so concurrent payments cannot over-settle it.
+func (r *repository) LockBill(id uuid.UUID) (*domain.PurchaseBill, error) {
+	var bill domain.PurchaseBill
+	result := r.db.Raw(
+		`SELECT id, number, vendor_id, COALESCE(vendor_reference, '') AS vendor_reference, date, due_date, COALESCE(description, '') AS description, status,
+			total, amount_paid, journal_entry_id, created_by, posted_at, created_at, updated_at
+		 FROM purchase_bills
+		 WHERE id = ?
+		 FOR UPDATE`,
+		id,
+	).Scan(&bill)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &bill, nil
+}
+
+func (r *repository) createLines(lines []domain.PurchaseBillLine) error {
+	for _, l := range lines {
+		if err := r.db.Exec(
+			`INSERT INTO purchase_bill_lines (id, bill_id, seq, coa_code, description, amount, dimensions)
+			 VALUES (gen_random_uuid(), ?, ?, ?, ?, ?, ?)`,
+			l.BillID, l.Seq, l.CoaCode, l.Description, l.Amount, l.Dimensions,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) CreateBill(bill *domain.PurchaseBill, lines []domain.PurchaseBillLine) error {
+	if err := r.db.Exec(
+		`INSERT INTO purchase_bills (id, number, vendor_id, vendor_reference, date, due_date, description, status, total, amount_paid, created_by, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, NOW(), NOW())`,
+		bill.ID, bill.Number, bill.VendorID, bill.VendorReference, bill.Date, bill.DueDate, bill.Description, bill.Status, bill.Total, bill.CreatedBy,
+	).Error; err != nil {
+		return err
+	}
+	return r.createLines(lines)
+}
+
+func (r *repository) ReplaceBill(bill *domain.PurchaseBill, lines []domain.PurchaseBillLine) error {
+	if err := r.db.Exec(
+		`UPDATE purchase_bills
+		 SET vendor_id = ?, vendor_reference = ?, date = ?, due_date = ?, description = ?, total = ?, updated_at = NOW()
+		 WHERE id = ?`,
+		bill.VendorID, bill.VendorReference, bill.Date, bill.DueDate, bill.Description, bill.Total, bill.ID,
+	).Error; err != nil {
+		return err
+	}
+	if err := r.db.Exec(`DELETE FROM purchase_bill_lines WHERE bill_id = ?`, bill.ID).Error; err != nil {
+		return err
+	}
+	return r.createLines(lines)
+}
+
+// DeleteBill removes a draft bill. Drafts never reached the ledger,
+// so they are deleted outright.
+func (r *repository) DeleteBill(id uuid.UUID) error {
+	if err := r.db.Exec(`DELETE FROM purchase_bill_lines WHERE bill_id = ?`, id).Error; err != nil {
+		return err
+	}
+	return r.db.Exec(`DELETE FROM purchase_bills WHERE id = ? AND status = 'draft'`, id).Error
+}
+
+func (r *repository) MarkBillPosted(id, journalID uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE purchase_bills
+		 SET status = 'open', journal_entry_id = ?, posted_at = NOW(), updated_at = NOW()
+		 WHERE id = ?`,
+		journalID, id,
+	).Error
+}
+
+// ApplyPayment adds a settled amount to a bill and marks it paid once
+// nothing is left.
+func (r *repository) ApplyPayment(id uuid.UUID, amount float64) error {
+	return r.db.Exec(
+		`UPDATE purchase_bills
+		 SET amount_paid = amount_paid + ?,
+			status = CASE WHEN total - (amount_paid + ?) <= 0 THEN 'paid' ELSE status END,
+			updated_at = NOW()
+		 WHERE id = ?`,
+		amount, amount, id,
+	).Error
+}
+
+const paymentColumns = `pm.id, pm.number, pm.vendor_id, v.code AS vendor_code, v.name AS vendor_name,
+	pm.date, pm.amount, pm.bank_coa_code, COALESCE(pm.description, '') AS description, pm.status,
+	COALESCE(pm.journal_entry_id::text, '') AS journal_entry_id, COALESCE(j.reference, '') AS journal_reference,
+	pm.posted_at, pm.created_by, pm.created_at, pm.updated_at`
+
+const paymentFrom = `FROM payments pm
+	JOIN vendors v ON v.id = pm.vendor_id
+	LEFT JOIN journal_entries j ON j.id = pm.journal_entry_id`
+
+func (r *repository) FindPayments(query *PaymentQuery) ([]PaymentResponse, int64, error) {
+	where := "1 = 1"
+	var args []interface{}
+	if query.VendorID != "" {
+		where += " AND pm.vendor_id = ?"
+		args = append(args, query.VendorID)
+	}
+	if query.Status != "" {
+		where += " AND pm.status = ?"
+		args = append(args, query.Status)
+	}
+
+	var total int64
+	if err := r.db.Raw(`SELECT COUNT(*) FROM payments pm WHERE `+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []PaymentResponse
+	err := r.db.Raw(
+		`SELECT `+paymentColumns+`
+		 `+paymentFrom+`
+		 WHERE `+where+`
+		 ORDER BY pm.date DESC, pm.number DESC
+		 LIMIT ? OFFSET ?`,
+		append(args, query.Limit, (query.Page-1)*query.Limit)...,
+	).Scan(&rows).Error
+	return rows, total, err
+}
+
+func (r *repository) FindPayment(id uuid.UUID) (*PaymentResponse, error) {
+	var pm PaymentResponse
+	result := r.db.Raw(
+		`SELECT `+paymentColumns+`
+		 `+paymentFrom+`
+		 WHERE pm.id = ?
+		 LIMIT 1`,
+		id,
+	).Scan(&pm)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &pm, nil
+}
+
+func (r *repository) FindAllocations(paymentID uuid.UUID) ([]AllocationResponse, error) {
+	var allocations []AllocationResponse
+	err := r.db.Raw(
+		`SELECT a.bill_id, b.number AS bill_number, a.amount
+		 FROM payment_allocations a
+		 JOIN purchase_bills b ON b.id = a.bill_id
+		 WHERE a.payment_id = ?
+		 ORDER BY b.due_date ASC, b.number ASC`,
+		paymentID,
+	).Scan(&allocations).Error
+	return allocations, err
+}
+
+func (r *repository) LockPayment(id uuid.UUID) (*domain.Payment, error) {
+	var pm domain.Payment
+	result := r.db.Raw(
+		`SELECT id, number, vendor_id, date, amount, bank_coa_code, COALESCE(description, '') AS description,
+			status, journal_entry_id, created_by, posted_at, created_at, updated_at
+		 FROM payments
+		 WHERE id = ?
+		 FOR UPDATE`,
+		id,
+	).Scan(&pm)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &pm, nil
+}
+
+func (r *repository) CreatePayment(pm *domain.Payment, allocations []domain.PaymentAllocation) error {
+	if err := r.db.Exec(
+		`INSERT INTO payments (id, number, vendor_id, date, amount, bank_coa_code, description, status, created_by, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		pm.ID, pm.Number, pm.VendorID, pm.Date, pm.Amount, pm.BankCoaCode, pm.Description, pm.Status, pm.CreatedBy,
+	).Error; err != nil {
+		return err
+	}
+	for _, a := range allocations {
+		if err := r.db.Exec(
+			`INSERT INTO payment_allocations (id, payment_id, bill_id, amount) VALUES (gen_random_uuid(), ?, ?, ?)`,
+			a.PaymentID, a.BillID, a.Amount,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) DeletePayment(id uuid.UUID) error {
+	if err := r.db.Exec(`DELETE FROM payment_allocations WHERE payment_id = ?`, id).Error; err != nil {
+		return err
+	}
+	return r.db.Exec(`DELETE FROM payments WHERE id = ? AND status = 'draft'`, id).Error
+}
+
+func (r *repository) MarkPaymentPosted(id, journalID uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE payments
+		 SET status = 'posted', journal_entry_id = ?, posted_at = NOW(), updated_at = NOW()
+		 WHERE id = ?`,
+		journalID, id,
+	).Error
+}
+
+// FindOpenItems lists posted bills dated on or before asOf that still
+// had an amount owed on that date, counting only payments dated up to it.
+func (r *repository) FindOpenItems(asOf time.Time, vendorID string) ([]OpenItem, error) {
+	where := "b.status <> 'draft' AND b.date <= ?"
+	args := []interface{}{asOf, asOf}
+	if vendorID != "" {
+		where += " AND b.vendor_id = ?"
+		args = append(args, vendorID)
+	}
+
+	var items []OpenItem
+	err := r.db.Raw(
+		`SELECT b.id AS bill_id, b.number, b.vendor_id, v.code AS vendor_code, v.name AS vendor_name,
+			b.date, b.due_date, b.total, COALESCE(p.paid, 0) AS paid, b.total - COALESCE(p.paid, 0) AS outstanding
+		 FROM purchase_bills b
+		 JOIN vendors v ON v.id = b.vendor_id
+		 LEFT JOIN (
+			SELECT a.bill_id, SUM(a.amount) AS paid
+			FROM payment_allocations a
+			JOIN payments pm ON pm.id = a.payment_id
+			WHERE pm.status = 'posted' AND pm.date <= ?
+			GROUP BY a.bill_id
+		 ) p ON p.bill_id = b.id
+		 WHERE `+where+`
+		 AND b.total - COALESCE(p.paid, 0) > 0
+		 ORDER BY v.code ASC, b.due_date ASC, b.number ASC`,
+		args...,
+	).Scan(&items).Error
+	return items, err
+}
+
+// FindDueBills lists open bills due on or before dueBy with their current
+// outstanding amount and the part already allocated on draft payments.
+func (r *repository) FindDueBills(dueBy time.Time, vendorID string) ([]DueBill, error) {
+	where := "b.status = 'open' AND b.due_date <= ?"
+	args := []interface{}{dueBy}
+	if vendorID != "" {
+		where += " AND b.vendor_id = ?"
+		args = append(args, vendorID)
+	}
+
+	var bills []DueBill
+	err := r.db.Raw(
+		`SELECT b.id AS bill_id, b.number, b.vendor_id, v.code AS vendor_code, v.name AS vendor_name,
+			COALESCE(b.vendor_reference, '') AS vendor_reference, b.date, b.due_date,
+			b.total - b.amount_paid AS outstanding, COALESCE(d.amount, 0) AS in_draft_payments
+		 FROM purchase_bills b
+		 JOIN vendors v ON v.id = b.vendor_id
+		 LEFT JOIN (
+			SELECT a.bill_id, SUM(a.amount) AS amount
+			FROM payment_allocations a
+			JOIN payments pm ON pm.id = a.payment_id
+			WHERE pm.status = 'draft'
+			GROUP BY a.bill_id
+		 ) d ON d.bill_id = b.id
+		 WHERE `+where+`
+		 ORDER BY v.code ASC, v.id ASC, b.due_date ASC, b.number ASC`,
+		args...,
+	).Scan(&bills).Error
+	return bills, err
+}
@@ -0,0 +1,40 @@
+package payable
+
+import (
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	apRoutes := router.Group("/ap")
+	apRoutes.Use(middleware.AuthMiddleware(), middleware.Idempotency(db))
+
+	read := middleware.RequireScope(apikey.ScopeAPRead)
+	write := middleware.RequireScope(apikey.ScopeAPWrite)
+
+	apRoutes.Get("/vendors", read, handler.GetVendors)
+	apRoutes.Get("/vendors/:id", read, handler.GetVendor)
+	apRoutes.Post("/vendors", write, middleware.DBTransaction(db), handler.CreateVendor)
+	apRoutes.Put("/vendors/:id", write, middleware.DBTransaction(db), handler.UpdateVendor)
+	apRoutes.Delete("/vendors/:id", write, middleware.DBTransaction(db), handler.DeleteVendor)
+
+	apRoutes.Get("/bills", read, handler.GetBills)
+	apRoutes.Get("/bills/:id", read, handler.GetBill)
+	apRoutes.Post("/bills", write, middleware.DBTransaction(db), handler.CreateBill)
+	apRoutes.Put("/bills/:id", write, middleware.DBTransaction(db), handler.UpdateBill)
+	apRoutes.Delete("/bills/:id", write, middleware.DBTransaction(db), handler.DeleteBill)
+	apRoutes.Put("/bills/:id/post", write, middleware.DBTransaction(db), handler.PostBill)
+
+	apRoutes.Get("/payments", read, handler.GetPayments)
+	apRoutes.Get("/payments/:id", read, handler.GetPayment)
+	apRoutes.Post("/payments", write, middleware.DBTransaction(db), handler.CreatePayment)
+	apRoutes.Delete("/payments/:id", write, middleware.DBTransaction(db), handler.DeletePayment)
+	apRoutes.Put("/payments/:id/post", write, middleware.DBTransaction(db), handler.PostPayment)
+
+	apRoutes.Get("/payment-run", read, handler.GetPaymentRun)
+	apRoutes.Get("/open-items", read, handler.GetOpenItems)
+	apRoutes.Get("/reconciliation", read, handler.Reconcile)
+}
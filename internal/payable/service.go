@@ -0,0 +1,830 @@
+package payable
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/journal"
+	"fiber.com/session-api/internal/subledger"
+	"fiber.com/session-api/pkg/model"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetVendors() ([]VendorResponse, error)
+	GetVendor(id uuid.UUID) (*VendorResponse, error)
+	CreateVendor(req *VendorRequest, actor audit.Actor, tx *gorm.DB) (*VendorResponse, error)
+	UpdateVendor(id uuid.UUID, req *VendorRequest, actor audit.Actor, tx *gorm.DB) (*VendorResponse, error)
+	DeleteVendor(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+
+	GetBills(query *BillQuery) ([]BillResponse, *model.MetaPagination, error)
+	GetBill(id uuid.UUID) (*BillResponse, error)
+	CreateBill(req *BillRequest, actor audit.Actor, tx *gorm.DB) (*BillResponse, error)
+	UpdateBill(id uuid.UUID, req *BillRequest, actor audit.Actor, tx *gorm.DB) (*BillResponse, error)
+	DeleteBill(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	PostBill(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*BillResponse, error)
+
+	GetPayments(query *PaymentQuery) ([]PaymentResponse, *model.MetaPagination, error)
+	GetPayment(id uuid.UUID) (*PaymentResponse, error)
+	CreatePayment(req *PaymentRequest, actor audit.Actor, tx *gorm.DB) (*PaymentResponse, error)
+	DeletePayment(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	PostPayment(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*PaymentResponse, error)
+
+	GetPaymentRun(query *PaymentRunQuery) (*PaymentRun, error)
+	GetOpenItems(query *OpenItemQuery) ([]OpenItem, error)
+	Reconcile(asOf string) (*subledger.Reconciliation, error)
+}
+
+type service struct {
+	repo           Repository
+	journalService journal.Service
+}
+
+func NewService(repo Repository, journalService journal.Service) Service {
+	return &service{repo: repo, journalService: journalService}
+}
+
+// Vendors
+
+func findVendor(repo Repository, id uuid.UUID) (*VendorResponse, error) {
+	v, err := repo.FindVendor(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if v == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Vendor not found")
+	}
+	return v, nil
+}
+
+func checkVendorRequest(repo Repository, req *VendorRequest, id uuid.UUID) error {
+	req.Code = strings.TrimSpace(req.Code)
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Code == "" || req.Name == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "code and name are required")
+	}
+	if len(req.Code) > 20 {
+		return fiber.NewError(fiber.StatusBadRequest, "code must be at most 20 characters")
+	}
+	if len(req.Name) > 150 {
+		return fiber.NewError(fiber.StatusBadRequest, "name must be at most 150 characters")
+	}
+	if req.PaymentTermDays != nil && *req.PaymentTermDays < 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "paymentTermDays cannot be negative")
+	}
+
+	existing, err := repo.FindVendorByCode(req.Code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if existing != nil && existing.ID != id.String() {
+		return fiber.NewError(fiber.StatusConflict, "Vendor code already exists")
+	}
+	return nil
+}
+
+func (s *service) GetVendors() ([]VendorResponse, error) {
+	vendors, err := s.repo.FindVendors()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if vendors == nil {
+		vendors = []VendorResponse{}
+	}
+	return vendors, nil
+}
+
+func (s *service) GetVendor(id uuid.UUID) (*VendorResponse, error) {
+	return findVendor(s.repo, id)
+}
+
+func (s *service) CreateVendor(req *VendorRequest, actor audit.Actor, tx *gorm.DB) (*VendorResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if err := checkVendorRequest(txRepo, req, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	v := &domain.Vendor{
+		ID:              uuid.New(),
+		Code:            req.Code,
+		Name:            req.Name,
+		Email:           req.Email,
+		PaymentTermDays: 30,
+		IsActive:        req.IsActive == nil || *req.IsActive,
+	}
+	if req.PaymentTermDays != nil {
+		v.PaymentTermDays = *req.PaymentTermDays
+	}
+	if err := txRepo.CreateVendor(v); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findVendor(txRepo, v.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityVendor, after.ID, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) UpdateVendor(id uuid.UUID, req *VendorRequest, actor audit.Actor, tx *gorm.DB) (*VendorResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := findVendor(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVendorRequest(txRepo, req, id); err != nil {
+		return nil, err
+	}
+
+	v := &domain.Vendor{
+		ID:              id,
+		Code:            req.Code,
+		Name:            req.Name,
+		Email:           req.Email,
+		PaymentTermDays: before.PaymentTermDays,
+		IsActive:        before.IsActive,
+	}
+	if req.PaymentTermDays != nil {
+		v.PaymentTermDays = *req.PaymentTermDays
+	}
+	if req.IsActive != nil {
+		v.IsActive = *req.IsActive
+	}
+	if err := txRepo.UpdateVendor(v); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findVendor(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityVendor, id.String(), audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// DeleteVendor removes a vendor with nothing left to settle. Posted
+// history stays attached to the deleted record.
+func (s *service) DeleteVendor(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	before, err := findVendor(txRepo, id)
+	if err != nil {
+		return err
+	}
+
+	unsettled, err := txRepo.CountUnsettled(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if unsettled > 0 {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Vendor %s cannot be deleted: it has %d draft or open documents", before.Code, unsettled,
+		))
+	}
+
+	if err := txRepo.DeleteVendor(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return audit.Record(tx, actor, audit.EntityVendor, id.String(), audit.ActionDelete, before, nil)
+}
+
+// Bills
+
+func findBill(repo Repository, id uuid.UUID) (*BillResponse, error) {
+	bill, err := repo.FindBill(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if bill == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Bill not found")
+	}
+
+	lines, err := repo.FindBillLines(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	bill.Lines = make([]BillLineResponse, len(lines))
+	for i, l := range lines {
+		var dims map[string]string
+		if len(l.Dimensions) > 0 {
+			_ = json.Unmarshal(l.Dimensions, &dims)
+		}
+		bill.Lines[i] = BillLineResponse{
+			CoaCode:     l.CoaCode,
+			Description: l.Description,
+			Amount:      l.Amount,
+			Dimensions:  dims,
+		}
+	}
+	return bill, nil
+}
+
+// buildBill validates a draft bill request against an active vendor
+// and expense or asset accounts.
+func buildBill(tx *gorm.DB, req *BillRequest) (*domain.PurchaseBill, []domain.PurchaseBillLine, error) {
+	vendorID, err := subledger.ParseID("vendorId", req.VendorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	vendor, err := findVendor(NewRepository(tx), vendorID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !vendor.IsActive {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Vendor %s is inactive", vendor.Code))
+	}
+
+	date, err := subledger.ParseDate("date", req.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+	dueDate := date.AddDate(0, 0, vendor.PaymentTermDays)
+	if req.DueDate != "" {
+		if dueDate, err = subledger.ParseDate("dueDate", req.DueDate); err != nil {
+			return nil, nil, err
+		}
+		if dueDate.Before(date) {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "dueDate cannot be before date")
+		}
+	}
+
+	if len(req.Lines) == 0 {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "A bill needs at least one line")
+	}
+	req.VendorReference = strings.TrimSpace(req.VendorReference)
+	if len(req.VendorReference) > 50 {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "vendorReference must be at most 50 characters")
+	}
+
+	bill := &domain.PurchaseBill{
+		VendorID:        vendorID,
+		VendorReference: req.VendorReference,
+		Date:            date,
+		DueDate:         dueDate,
+		Description:     req.Description,
+	}
+	lines := make([]domain.PurchaseBillLine, len(req.Lines))
+	for i, l := range req.Lines {
+		amount := utils.Round2(l.Amount)
+		if amount <= 0 {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Line %d: amount must be greater than zero", i+1))
+		}
+		if err := subledger.CheckAccount(tx, l.CoaCode, "Bill line account", domain.AccountTypeExpense, domain.AccountTypeAsset); err != nil {
+			return nil, nil, err
+		}
+
+		dims, err := json.Marshal(l.Dimensions)
+		if err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Invalid dimensions")
+		}
+		lines[i] = domain.PurchaseBillLine{
+			Seq:         i + 1,
+			CoaCode:     l.CoaCode,
+			Description: l.Description,
+			Amount:      amount,
+			Dimensions:  dims,
+		}
+		bill.Total += amount
+	}
+	bill.Total = utils.Round2(bill.Total)
+
+	return bill, lines, nil
+}
+
+func setBillID(lines []domain.PurchaseBillLine, id uuid.UUID) {
+	for i := range lines {
+		lines[i].BillID = id
+	}
+}
+
+func (s *service) GetBills(query *BillQuery) ([]BillResponse, *model.MetaPagination, error) {
+	if query.VendorID != "" {
+		if _, err := subledger.ParseID("vendorId", query.VendorID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rows, total, err := s.repo.FindBills(query)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rows == nil {
+		rows = []BillResponse{}
+	}
+	return rows, subledger.Paginate(query.Page, query.Limit, total), nil
+}
+
+func (s *service) GetBill(id uuid.UUID) (*BillResponse, error) {
+	return findBill(s.repo, id)
+}
+
+func (s *service) CreateBill(req *BillRequest, actor audit.Actor, tx *gorm.DB) (*BillResponse, error) {
+	txRepo := NewRepository(tx)
+
+	createdBy, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+
+	bill, lines, err := buildBill(tx, req)
+	if err != nil {
+		return nil, err
+	}
+	bill.ID = uuid.New()
+	bill.Number = subledger.DocumentNumber("PB", bill.Date)
+	bill.Status = domain.PurchaseBillStatusDraft
+	bill.CreatedBy = createdBy
+	setBillID(lines, bill.ID)
+
+	if err := txRepo.CreateBill(bill, lines); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findBill(txRepo, bill.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityPurchaseBill, after.ID, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// lockDraftBill locks a bill that must still be a draft.
+func lockDraftBill(repo Repository, id uuid.UUID) (*domain.PurchaseBill, error) {
+	bill, err := repo.LockBill(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if bill == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Bill not found")
+	}
+	if bill.Status != domain.PurchaseBillStatusDraft {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Bill %s is already posted", bill.Number))
+	}
+	return bill, nil
+}
+
+func (s *service) UpdateBill(id uuid.UUID, req *BillRequest, actor audit.Actor, tx *gorm.DB) (*BillResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if _, err := lockDraftBill(txRepo, id); err != nil {
+		return nil, err
+	}
+	before, err := findBill(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	bill, lines, err := buildBill(tx, req)
+	if err != nil {
+		return nil, err
+	}
+	bill.ID = id
+	setBillID(lines, id)
+
+	if err := txRepo.ReplaceBill(bill, lines); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findBill(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityPurchaseBill, id.String(), audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) DeleteBill(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	if _, err := lockDraftBill(txRepo, id); err != nil {
+		return err
+	}
+	before, err := findBill(txRepo, id)
+	if err != nil {
+		return err
+	}
+
+	if err := txRepo.DeleteBill(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return audit.Record(tx, actor, audit.EntityPurchaseBill, id.String(), audit.ActionDelete, before, nil)
+}
+
+// PostBill books the bill: each expense or asset line is debited and the AP
+// control account credited with the total, through a posted journal dated
+// on the bill date. The bill then becomes an open item.
+func (s *service) PostBill(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*BillResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if _, err := lockDraftBill(txRepo, id); err != nil {
+		return nil, err
+	}
+	before, err := findBill(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	control, err := subledger.Payable.CheckControl(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Purchase bill %s - %s", before.Number, before.VendorName)
+	if before.VendorReference != "" {
+		description += " (" + before.VendorReference + ")"
+	}
+	var details []journal.JournalDetailRequest
+	for _, l := range before.Lines {
+		lineDescription := l.Description
+		if lineDescription == "" {
+			lineDescription = description
+		}
+		details = append(details, journal.JournalDetailRequest{
+			CoaCode:     l.CoaCode,
+			Debit:       l.Amount,
+			Description: lineDescription,
+			Dimensions:  l.Dimensions,
+		})
+	}
+	details = append(details, journal.JournalDetailRequest{
+		CoaCode:     control,
+		Credit:      before.Total,
+		Description: description,
+	})
+
+	entry, err := journal.CreateDraft(tx, journal.DraftInput{
+		Date:        before.Date,
+		Prefix:      "BILL",
+		Description: description,
+		Type:        domain.JournalTypePurchaseBill,
+		Details:     details,
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+	entryID := uuid.MustParse(entry.ID)
+	if err := s.journalService.PostJournal(entryID, actor, tx); err != nil {
+		return nil, err
+	}
+
+	if err := txRepo.MarkBillPosted(id, entryID); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findBill(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityPurchaseBill, id.String(), audit.ActionPost, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Payments
+
+func findPayment(repo Repository, id uuid.UUID) (*PaymentResponse, error) {
+	pm, err := repo.FindPayment(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if pm == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Payment not found")
+	}
+
+	allocations, err := repo.FindAllocations(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if allocations == nil {
+		allocations = []AllocationResponse{}
+	}
+	pm.Allocations = allocations
+	return pm, nil
+}
+
+// checkAllocation verifies that a bill can take amount from a payment
+// of the vendor dated on date.
+func checkAllocation(bill *domain.PurchaseBill, vendorID uuid.UUID, date time.Time, amount float64) error {
+	if bill.VendorID != vendorID {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Bill %s belongs to another vendor", bill.Number))
+	}
+	if bill.Status != domain.PurchaseBillStatusOpen {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Bill %s is %s, not open", bill.Number, bill.Status))
+	}
+	if utils.DateOf(date).Before(utils.DateOf(bill.Date)) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Payment date is before bill %s", bill.Number))
+	}
+	if outstanding := utils.Round2(bill.Total - bill.AmountPaid); amount > outstanding {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Allocation of %.2f exceeds the %.2f outstanding on bill %s", amount, outstanding, bill.Number,
+		))
+	}
+	return nil
+}
+
+func (s *service) GetPayments(query *PaymentQuery) ([]PaymentResponse, *model.MetaPagination, error) {
+	if query.VendorID != "" {
+		if _, err := subledger.ParseID("vendorId", query.VendorID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rows, total, err := s.repo.FindPayments(query)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rows == nil {
+		rows = []PaymentResponse{}
+	}
+	return rows, subledger.Paginate(query.Page, query.Limit, total), nil
+}
+
+func (s *service) GetPayment(id uuid.UUID) (*PaymentResponse, error) {
+	return findPayment(s.repo, id)
+}
+
+func (s *service) CreatePayment(req *PaymentRequest, actor audit.Actor, tx *gorm.DB) (*PaymentResponse, error) {
+	txRepo := NewRepository(tx)
+
+	createdBy, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+
+	vendorID, err := subledger.ParseID("vendorId", req.VendorID)
+	if err != nil {
+		return nil, err
+	}
+	vendor, err := findVendor(txRepo, vendorID)
+	if err != nil {
+		return nil, err
+	}
+	if !vendor.IsActive {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Vendor %s is inactive", vendor.Code))
+	}
+
+	date, err := subledger.ParseDate("date", req.Date)
+	if err != nil {
+		return nil, err
+	}
+	amount := utils.Round2(req.Amount)
+	if amount <= 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "amount must be greater than zero")
+	}
+	if err := subledger.CheckAccount(tx, req.BankCoaCode, "Bank account", domain.AccountTypeAsset); err != nil {
+		return nil, err
+	}
+	if len(req.Allocations) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "A payment needs at least one allocation")
+	}
+
+	pm := &domain.Payment{
+		ID:          uuid.New(),
+		Number:      subledger.DocumentNumber("PAY", date),
+		VendorID:    vendorID,
+		Date:        date,
+		Amount:      amount,
+		BankCoaCode: req.BankCoaCode,
+		Description: req.Description,
+		Status:      domain.PaymentStatusDraft,
+		CreatedBy:   createdBy,
+	}
+
+	seen := map[uuid.UUID]bool{}
+	var allocated float64
+	allocations := make([]domain.PaymentAllocation, len(req.Allocations))
+	for i, a := range req.Allocations {
+		billID, err := subledger.ParseID("billId", a.BillID)
+		if err != nil {
+			return nil, err
+		}
+		if seen[billID] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "A bill appears more than once in allocations")
+		}
+		seen[billID] = true
+
+		allocation := utils.Round2(a.Amount)
+		if allocation <= 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Allocation amounts must be greater than zero")
+		}
+		bill, err := txRepo.LockBill(billID)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if bill == nil {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Bill not found")
+		}
+		if err := checkAllocation(bill, vendorID, date, allocation); err != nil {
+			return nil, err
+		}
+
+		allocations[i] = domain.PaymentAllocation{PaymentID: pm.ID, BillID: billID, Amount: allocation}
+		allocated += allocation
+	}
+	if diff := utils.Round2(allocated - amount); diff != 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"Allocations total %.2f but the payment amount is %.2f", utils.Round2(allocated), amount,
+		))
+	}
+
+	if err := txRepo.CreatePayment(pm, allocations); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findPayment(txRepo, pm.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityPayment, after.ID, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func lockDraftPayment(repo Repository, id uuid.UUID) (*domain.Payment, error) {
+	pm, err := repo.LockPayment(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if pm == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Payment not found")
+	}
+	if pm.Status != domain.PaymentStatusDraft {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Payment %s is already posted", pm.Number))
+	}
+	return pm, nil
+}
+
+func (s *service) DeletePayment(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	if _, err := lockDraftPayment(txRepo, id); err != nil {
+		return err
+	}
+	before, err := findPayment(txRepo, id)
+	if err != nil {
+		return err
+	}
+
+	if err := txRepo.DeletePayment(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return audit.Record(tx, actor, audit.EntityPayment, id.String(), audit.ActionDelete, before, nil)
+}
+
+// PostPayment books the payment, debiting the AP control account and
+// crediting the bank, and settles its allocations. The bills are locked
+// and re-checked first, since other payments may have settled them since
+// the draft was saved.
+func (s *service) PostPayment(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*PaymentResponse, error) {
+	txRepo := NewRepository(tx)
+
+	pm, err := lockDraftPayment(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	before, err := findPayment(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lock in a fixed order so concurrent payments cannot deadlock.
+	allocations := before.Allocations
+	sort.Slice(allocations, func(i, j int) bool { return allocations[i].BillID < allocations[j].BillID })
+	for _, a := range allocations {
+		bill, err := txRepo.LockBill(uuid.MustParse(a.BillID))
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if bill == nil {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Bill not found")
+		}
+		if err := checkAllocation(bill, pm.VendorID, pm.Date, a.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	control, err := subledger.Payable.CheckControl(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := subledger.CheckAccount(tx, pm.BankCoaCode, "Bank account", domain.AccountTypeAsset); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Payment %s - %s", before.Number, before.VendorName)
+	entry, err := journal.CreateDraft(tx, journal.DraftInput{
+		Date:        pm.Date,
+		Prefix:      "PAY",
+		Description: description,
+		Type:        domain.JournalTypePayment,
+		Details: []journal.JournalDetailRequest{
+			{CoaCode: control, Debit: pm.Amount, Description: description},
+			{CoaCode: pm.BankCoaCode, Credit: pm.Amount, Description: description},
+		},
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+	entryID := uuid.MustParse(entry.ID)
+	if err := s.journalService.PostJournal(entryID, actor, tx); err != nil {
+		return nil, err
+	}
+
+	for _, a := range allocations {
+		if err := txRepo.ApplyPayment(uuid.MustParse(a.BillID), a.Amount); err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	if err := txRepo.MarkPaymentPosted(id, entryID); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findPayment(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityPayment, id.String(), audit.ActionPost, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// GetPaymentRun proposes paying every open bill due by the given date
+// (default today), less what draft payments already cover, grouped by
+// vendor.
+func (s *service) GetPaymentRun(query *PaymentRunQuery) (*PaymentRun, error) {
+	dueBy := utils.DateOf(time.Now())
+	if query.DueBy != "" {
+		var err error
+		if dueBy, err = subledger.ParseDate("dueBy", query.DueBy); err != nil {
+			return nil, err
+		}
+	}
+	if query.VendorID != "" {
+		if _, err := subledger.ParseID("vendorId", query.VendorID); err != nil {
+			return nil, err
+		}
+	}
+
+	bills, err := s.repo.FindDueBills(dueBy, query.VendorID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	run := &PaymentRun{DueBy: dueBy, Vendors: []PaymentRunVendor{}}
+	for _, b := range bills {
+		b.Proposed = utils.Round2(b.Outstanding - b.InDraftPayments)
+		if b.Proposed <= 0 {
+			continue
+		}
+		b.DaysOverdue = max(int(dueBy.Sub(utils.DateOf(b.DueDate)).Hours()/24), 0)
+
+		// Bills come ordered by vendor, so a new vendor starts a new group.
+		if n := len(run.Vendors); n == 0 || run.Vendors[n-1].VendorID != b.VendorID {
+			run.Vendors = append(run.Vendors, PaymentRunVendor{
+				VendorID:   b.VendorID,
+				VendorCode: b.VendorCode,
+				VendorName: b.VendorName,
+			})
+		}
+		v := &run.Vendors[len(run.Vendors)-1]
+		v.Bills = append(v.Bills, b)
+		v.Total = utils.Round2(v.Total + b.Proposed)
+		run.Total = utils.Round2(run.Total + b.Proposed)
+	}
+	return run, nil
+}
+
+// Open items
+
+func (s *service) GetOpenItems(query *OpenItemQuery) ([]OpenItem, error) {
+	return subledger.FindOpenItems[OpenItem](s.repo, query.AsOf, "vendorId", query.VendorID)
+}
+
+// Reconcile compares the AP control account balance with the total of the
+// open items on the same date.
+func (s *service) Reconcile(asOf string) (*subledger.Reconciliation, error) {
+	return subledger.Reconcile[OpenItem](subledger.Payable, s.repo, asOf)
+}
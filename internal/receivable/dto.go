@@ -0,0 +1,203 @@
+package receivable
+
+import "time"
+
+type CustomerRequest struct {
+	Code            string `json:"code"            validate:"required,max=20"  example:"CUST-001"`
+	Name            string `json:"name"            validate:"required,max=150" example:"PT Maju Jaya"`
+	Email           string `json:"email"           validate:"omitempty,email"  example:"finance@majujaya.co.id"`
+	PaymentTermDays *int   `json:"paymentTermDays" validate:"omitempty,min=0"  example:"30"`
+	IsActive        *bool  `json:"isActive"        validate:"omitempty"        example:"true"`
+}
+
+// CustomerResponse carries the customer's outstanding balance over its
+// open invoices.
+type CustomerResponse struct {
+	ID              string    `json:"id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	Email           string    `json:"email"`
+	PaymentTermDays int       `json:"paymentTermDays"`
+	IsActive        bool      `json:"isActive"`
+	Balance         float64   `json:"balance"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+// InvoiceLineRequest credits a revenue account. Dimensions are copied to
+// the journal line.
+type InvoiceLineRequest struct {
+	CoaCode     string            `json:"coaCode"     validate:"required" example:"4-1100"`
+	Description string            `json:"description"                     example:"Consulting, January"`
+	Amount      float64           `json:"amount"      validate:"gt=0"     example:"1500000"`
+	Dimensions  map[string]string `json:"dimensions"                      example:"DEPT:SALES"`
+}
+
+// InvoiceRequest creates or replaces a draft invoice. DueDate defaults to
+// the date plus the customer's payment terms.
+type InvoiceRequest struct {
+	CustomerID  string               `json:"customerId"  validate:"required"        example:"3f0c8a0e-2d7b-4a8e-9d1a-6f2b9c1e4a77"`
+	Date        string               `json:"date"        validate:"required"        example:"2026-01-31"`
+	DueDate     string               `json:"dueDate"                                example:"2026-03-02"`
+	Description string               `json:"description"                            example:"January services"`
+	Lines       []InvoiceLineRequest `json:"lines"       validate:"required,min=1"`
+}
+
+type InvoiceQuery struct {
+	Page       int    `query:"page"`
+	Limit      int    `query:"limit"`
+	CustomerID string `query:"customerId"`
+	Status     string `query:"status"`
+	StartDate  string `query:"startDate"`
+	EndDate    string `query:"endDate"`
+}
+
+type InvoiceLineResponse struct {
+	CoaCode     string            `json:"coaCode"`
+	Description string            `json:"description"`
+	Amount      float64           `json:"amount"`
+	Dimensions  map[string]string `json:"dimensions,omitempty"`
+}
+
+type InvoiceResponse struct {
+	ID               string                `json:"id"`
+	Number           string                `json:"number"`
+	CustomerID       string                `json:"customerId"`
+	CustomerCode     string                `json:"customerCode"`
+	CustomerName     string                `json:"customerName"`
+	Date             time.Time             `json:"date"`
+	DueDate          time.Time             `json:"dueDate"`
+	Description      string                `json:"description"`
+	Status           string                `json:"status"`
+	Total            float64               `json:"total"`
+	AmountPaid       float64               `json:"amountPaid"`
+	Outstanding      float64               `json:"outstanding"`
+	JournalEntryID   string                `json:"journalEntryId,omitempty"`
+	JournalReference string                `json:"journalReference,omitempty"`
+	PostedAt         *time.Time            `json:"postedAt,omitempty"`
+	CreatedBy        string                `json:"createdBy"`
+	CreatedAt        time.Time             `json:"createdAt"`
+	UpdatedAt        time.Time             `json:"updatedAt"`
+	Lines            []InvoiceLineResponse `json:"lines,omitempty" gorm:"-"`
+}
+
+type AllocationRequest struct {
+	InvoiceID string  `json:"invoiceId" validate:"required" example:"9b1d7e2a-1c3f-4e5d-8a6b-7c8d9e0f1a2b"`
+	Amount    float64 `json:"amount"    validate:"gt=0"     example:"1500000"`
+}
+
+// ReceiptRequest creates a draft receipt. Allocations must add up to the
+// amount and may settle invoices partially.
+type ReceiptRequest struct {
+	CustomerID  string              `json:"customerId"  validate:"required"       example:"3f0c8a0e-2d7b-4a8e-9d1a-6f2b9c1e4a77"`
+	Date        string              `json:"date"        validate:"required"       example:"2026-02-15"`
+	Amount      float64             `json:"amount"      validate:"gt=0"           example:"1500000"`
+	BankCoaCode string              `json:"bankCoaCode" validate:"required"       example:"1-1103"`
+	Description string              `json:"description"                           example:"Transfer BCA"`
+	Allocations []AllocationRequest `json:"allocations" validate:"required,min=1"`
+}
+
+type ReceiptQuery struct {
+	Page       int    `query:"page"`
+	Limit      int    `query:"limit"`
+	CustomerID string `query:"customerId"`
+	Status     string `query:"status"`
+}
+
+type AllocationResponse struct {
+	InvoiceID     string  `json:"invoiceId"`
+	InvoiceNumber string  `json:"invoiceNumber"`
+	Amount        float64 `json:"amount"`
+}
+
+type ReceiptResponse struct {
+	ID               string               `json:"id"`
+	Number           string               `json:"number"`
+	CustomerID       string               `json:"customerId"`
+	CustomerCode     string               `json:"customerCode"`
+	CustomerName     string               `json:"customerName"`
+	Date             time.Time            `json:"date"`
+	Amount           float64              `json:"amount"`
+	BankCoaCode      string               `json:"bankCoaCode"`
+	Description      string               `json:"description"`
+	Status           string               `json:"status"`
+	JournalEntryID   string               `json:"journalEntryId,omitempty"`
+	JournalReference string               `json:"journalReference,omitempty"`
+	PostedAt         *time.Time           `json:"postedAt,omitempty"`
+	CreatedBy        string               `json:"createdBy"`
+	CreatedAt        time.Time            `json:"createdAt"`
+	UpdatedAt        time.Time            `json:"updatedAt"`
+	Allocations      []AllocationResponse `json:"allocations,omitempty" gorm:"-"`
+}
+
+// OpenItemQuery lists invoices still owed as of a date (default today).
+type OpenItemQuery struct {
+	CustomerID string `query:"customerId"`
+	AsOf       string `query:"asOf"`
+}
+
+// OpenItem is an invoice with the amount still owed as of a date: receipts
+// dated later are not deducted.
+type OpenItem struct {
+	InvoiceID    string    `json:"invoiceId"`
+	Number       string    `json:"number"`
+	CustomerID   string    `json:"customerId"`
+	CustomerCode string    `json:"customerCode"`
+	CustomerName string    `json:"customerName"`
+	Date         time.Time `json:"date"`
+	DueDate      time.Time `json:"dueDate"`
+	Total        float64   `json:"total"`
+	Paid         float64   `json:"paid"`
+	Outstanding  float64   `json:"outstanding"`
+}
+
+// OutstandingAmount makes OpenItem a subledger.OpenItem.
+func (o OpenItem) OutstandingAmount() float64 {
+	return o.Outstanding
+}
+
+// Swagger Responses
+
+type SwaggerCustomerResponse struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Data    CustomerResponse `json:"data"`
+}
+
+type SwaggerCustomerListResponse struct {
+	Code    int                `json:"code"`
+	Message string             `json:"message"`
+	Data    []CustomerResponse `json:"data"`
+}
+
+type SwaggerInvoiceResponse struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    InvoiceResponse `json:"data"`
+}
+
+type SwaggerInvoiceListResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    []InvoiceResponse `json:"data"`
+	Meta    any               `json:"meta,omitempty"`
+}
+
+type SwaggerReceiptResponse struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    ReceiptResponse `json:"data"`
+}
+
+type SwaggerReceiptListResponse struct {
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Data    []ReceiptResponse `json:"data"`
+	Meta    any               `json:"meta,omitempty"`
+}
+
+type SwaggerOpenItemListResponse struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    []OpenItem `json:"data"`
+}
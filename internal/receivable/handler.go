@@ -0,0 +1,557 @@
+package receivable
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/subledger"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetCustomers godoc
+// @Summary      List customers
+// @Description  Returns every customer with its outstanding balance over posted invoices
+// @Tags         Receivable
+// @Produce      json
+// @Success      200  {object}  SwaggerCustomerListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/customers [get]
+func (h *Handler) GetCustomers(c *fiber.Ctx) error {
+	customers, err := h.service.GetCustomers()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get all customers", customers)
+}
+
+// GetCustomer godoc
+// @Summary      Get customer by ID
+// @Description  Returns a single customer with its outstanding balance
+// @Tags         Receivable
+// @Produce      json
+// @Param        id   path  string  true  "Customer ID (UUID)"
+// @Success      200  {object}  SwaggerCustomerResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/customers/{id} [get]
+func (h *Handler) GetCustomer(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "customer")
+	if err != nil {
+		return err
+	}
+
+	customer, err := h.service.GetCustomer(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get customer", customer)
+}
+
+// CreateCustomer godoc
+// @Summary      Create a customer
+// @Description  Creates a customer. Payment terms default to 30 days and set the due date of its invoices.
+// @Tags         Receivable
+// @Accept       json
+// @Produce      json
+// @Param        body body CustomerRequest true "Customer payload"
+// @Success      201  {object}  SwaggerCustomerResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/customers [post]
+func (h *Handler) CreateCustomer(c *fiber.Ctx) error {
+	var req CustomerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	customer, err := h.service.CreateCustomer(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Customer created successfully", customer)
+}
+
+// UpdateCustomer godoc
+// @Summary      Update a customer
+// @Description  Updates a customer. Omitted paymentTermDays and isActive keep their values.
+// @Tags         Receivable
+// @Accept       json
+// @Produce      json
+// @Param        id    path  string           true  "Customer ID (UUID)"
+// @Param        body  body  CustomerRequest  true  "Customer payload"
+// @Success      200  {object}  SwaggerCustomerResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/customers/{id} [put]
+func (h *Handler) UpdateCustomer(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "customer")
+	if err != nil {
+		return err
+	}
+
+	var req CustomerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	customer, err := h.service.UpdateCustomer(id, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Customer updated successfully", customer)
+}
+
+// DeleteCustomer godoc
+// @Summary      Delete a customer
+// @Description  Soft-deletes a customer with no draft or open documents
+// @Tags         Receivable
+// @Produce      json
+// @Param        id   path  string  true  "Customer ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/customers/{id} [delete]
+func (h *Handler) DeleteCustomer(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "customer")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteCustomer(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Customer deleted successfully", nil)
+}
+
+// GetInvoices godoc
+// @Summary      List sales invoices
+// @Description  Returns a paginated list of sales invoices, newest first
+// @Tags         Receivable
+// @Produce      json
+// @Param        page        query  int     false  "Page number"    minimum(1)
+// @Param        limit       query  int     false  "Items per page" minimum(1) maximum(100)
+// @Param        customerId  query  string  false  "Customer ID (UUID)"
+// @Param        status      query  string  false  "draft, open or paid"
+// @Param        startDate   query  string  false  "From invoice date (YYYY-MM-DD)"
+// @Param        endDate     query  string  false  "To invoice date (YYYY-MM-DD)"
+// @Success      200  {object}  SwaggerInvoiceListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/invoices [get]
+func (h *Handler) GetInvoices(c *fiber.Ctx) error {
+	var query InvoiceQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	subledger.PageLimit(&query.Page, &query.Limit)
+
+	invoices, meta, err := h.service.GetInvoices(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get all invoices", invoices, meta)
+}
+
+// GetInvoice godoc
+// @Summary      Get sales invoice by ID
+// @Description  Returns a single sales invoice with its lines
+// @Tags         Receivable
+// @Produce      json
+// @Param        id   path  string  true  "Invoice ID (UUID)"
+// @Success      200  {object}  SwaggerInvoiceResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/invoices/{id} [get]
+func (h *Handler) GetInvoice(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "invoice")
+	if err != nil {
+		return err
+	}
+
+	invoice, err := h.service.GetInvoice(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get invoice", invoice)
+}
+
+// CreateInvoice godoc
+// @Summary      Create a sales invoice
+// @Description  Creates a draft sales invoice for an active customer. Each line credits a postable revenue account.
+// @Tags         Receivable
+// @Accept       json
+// @Produce      json
+// @Param        body body InvoiceRequest true "Invoice payload"
+// @Success      201  {object}  SwaggerInvoiceResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/invoices [post]
+func (h *Handler) CreateInvoice(c *fiber.Ctx) error {
+	var req InvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	invoice, err := h.service.CreateInvoice(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Invoice created as draft", invoice)
+}
+
+// UpdateInvoice godoc
+// @Summary      Update a sales invoice
+// @Description  Replaces a draft sales invoice and its lines
+// @Tags         Receivable
+// @Accept       json
+// @Produce      json
+// @Param        id    path  string          true  "Invoice ID (UUID)"
+// @Param        body  body  InvoiceRequest  true  "Invoice payload"
+// @Success      200  {object}  SwaggerInvoiceResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/invoices/{id} [put]
+func (h *Handler) UpdateInvoice(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "invoice")
+	if err != nil {
+		return err
+	}
+
+	var req InvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	invoice, err := h.service.UpdateInvoice(id, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Invoice updated successfully", invoice)
+}
+
+// DeleteInvoice godoc
+// @Summary      Delete a sales invoice
+// @Description  Deletes a draft sales invoice. Posted invoices cannot be deleted.
+// @Tags         Receivable
+// @Produce      json
+// @Param        id   path  string  true  "Invoice ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/invoices/{id} [delete]
+func (h *Handler) DeleteInvoice(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "invoice")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteInvoice(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Invoice deleted successfully", nil)
+}
+
+// PostInvoice godoc
+// @Summary      Post a sales invoice
+// @Description  Posts a journal dated on the invoice date that debits the AR control account with the total and credits each revenue line, then opens the invoice for receipts
+// @Tags         Receivable
+// @Produce      json
+// @Param        id   path  string  true  "Invoice ID (UUID)"
+// @Success      200  {object}  SwaggerInvoiceResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/invoices/{id}/post [put]
+func (h *Handler) PostInvoice(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "invoice")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	invoice, err := h.service.PostInvoice(id, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Invoice posted successfully", invoice)
+}
+
+// GetReceipts godoc
+// @Summary      List receipts
+// @Description  Returns a paginated list of customer receipts, newest first
+// @Tags         Receivable
+// @Produce      json
+// @Param        page        query  int     false  "Page number"    minimum(1)
+// @Param        limit       query  int     false  "Items per page" minimum(1) maximum(100)
+// @Param        customerId  query  string  false  "Customer ID (UUID)"
+// @Param        status      query  string  false  "draft or posted"
+// @Success      200  {object}  SwaggerReceiptListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/receipts [get]
+func (h *Handler) GetReceipts(c *fiber.Ctx) error {
+	var query ReceiptQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	subledger.PageLimit(&query.Page, &query.Limit)
+
+	receipts, meta, err := h.service.GetReceipts(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get all receipts", receipts, meta)
+}
+
+// GetReceipt godoc
+// @Summary      Get receipt by ID
+// @Description  Returns a single receipt with its invoice allocations
+// @Tags         Receivable
+// @Produce      json
+// @Param        id   path  string  true  "Receipt ID (UUID)"
+// @Success      200  {object}  SwaggerReceiptResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/receipts/{id} [get]
+func (h *Handler) GetReceipt(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "receipt")
+	if err != nil {
+		return err
+	}
+
+	receipt, err := h.service.GetReceipt(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get receipt", receipt)
+}
+
+// CreateReceipt godoc
+// @Summary      Create a receipt
+// @Description  Creates a draft receipt into a postable asset (bank or cash) account. The allocations must add up to the amount; each may settle part of an open invoice of the same customer.
+// @Tags         Receivable
+// @Accept       json
+// @Produce      json
+// @Param        body body ReceiptRequest true "Receipt payload"
+// @Success      201  {object}  SwaggerReceiptResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/receipts [post]
+func (h *Handler) CreateReceipt(c *fiber.Ctx) error {
+	var req ReceiptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := h.service.CreateReceipt(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Receipt created as draft", receipt)
+}
+
+// DeleteReceipt godoc
+// @Summary      Delete a receipt
+// @Description  Deletes a draft receipt and its allocations. Posted receipts cannot be deleted.
+// @Tags         Receivable
+// @Produce      json
+// @Param        id   path  string  true  "Receipt ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/receipts/{id} [delete]
+func (h *Handler) DeleteReceipt(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "receipt")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteReceipt(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Receipt deleted successfully", nil)
+}
+
+// PostReceipt godoc
+// @Summary      Post a receipt
+// @Description  Posts a journal that debits the bank account and credits the AR control account, and applies the allocations to the invoices. Fails if an invoice no longer has enough outstanding.
+// @Tags         Receivable
+// @Produce      json
+// @Param        id   path  string  true  "Receipt ID (UUID)"
+// @Success      200  {object}  SwaggerReceiptResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/receipts/{id}/post [put]
+func (h *Handler) PostReceipt(c *fiber.Ctx) error {
+	id, err := subledger.PathID(c, "receipt")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := h.service.PostReceipt(id, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Receipt posted successfully", receipt)
+}
+
+// GetOpenItems godoc
+// @Summary      List open invoices
+// @Description  Returns the posted invoices with an amount still owed as of a date (default today). Receipts dated after that date are not deducted.
+// @Tags         Receivable
+// @Produce      json
+// @Param        customerId  query  string  false  "Customer ID (UUID)"
+// @Param        asOf        query  string  false  "As-of date (YYYY-MM-DD)"
+// @Success      200  {object}  SwaggerOpenItemListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/open-items [get]
+func (h *Handler) GetOpenItems(c *fiber.Ctx) error {
+	var query OpenItemQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	items, err := h.service.GetOpenItems(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get open items", items)
+}
+
+// Reconcile godoc
+// @Summary      Reconcile the AR control account
+// @Description  Compares the posted balance of the AR control account with the total of open items as of a date (default today). otherEntries is the net of journals on the control account that did not come from an invoice or receipt.
+// @Tags         Receivable
+// @Produce      json
+// @Param        asOf  query  string  false  "As-of date (YYYY-MM-DD)"
+// @Success      200  {object}  subledger.SwaggerReconciliationResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /ar/reconciliation [get]
+func (h *Handler) Reconcile(c *fiber.Ctx) error {
+	res, err := h.service.Reconcile(c.Query("asOf"))
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success reconcile AR control account", res)
+}
@@ -0,0 +1,458 @@
+package receivable
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/subledger"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindCustomers() ([]CustomerResponse, error)
+	FindCustomer(id uuid.UUID) (*CustomerResponse, error)
+	FindCustomerByCode(code string) (*CustomerResponse, error)
+	CreateCustomer(c *domain.Customer) error
+	UpdateCustomer(c *domain.Customer) error
+	DeleteCustomer(id uuid.UUID) error
+	CountUnsettled(customerID uuid.UUID) (int64, error)
+
+	FindInvoices(query *InvoiceQuery) ([]InvoiceResponse, int64, error)
+	FindInvoice(id uuid.UUID) (*InvoiceResponse, error)
+	FindInvoiceLines(id uuid.UUID) ([]domain.SalesInvoiceLine, error)
+	LockInvoice(id uuid.UUID) (*domain.SalesInvoice, error)
+	CreateInvoice(inv *domain.SalesInvoice, lines []domain.SalesInvoiceLine) error
+	ReplaceInvoice(inv *domain.SalesInvoice, lines []domain.SalesInvoiceLine) error
+	DeleteInvoice(id uuid.UUID) error
+	MarkInvoicePosted(id, journalID uuid.UUID) error
+	ApplyPayment(id uuid.UUID, amount float64) error
+
+	FindReceipts(query *ReceiptQuery) ([]ReceiptResponse, int64, error)
+	FindReceipt(id uuid.UUID) (*ReceiptResponse, error)
+	FindAllocations(receiptID uuid.UUID) ([]AllocationResponse, error)
+	LockReceipt(id uuid.UUID) (*domain.Receipt, error)
+	CreateReceipt(r *domain.Receipt, allocations []domain.ReceiptAllocation) error
+	DeleteReceipt(id uuid.UUID) error
+	MarkReceiptPosted(id, journalID uuid.UUID) error
+
+	FindOpenItems(asOf time.Time, customerID string) ([]OpenItem, error)
+	ControlBalance(asOf time.Time) (balance, other float64, err error)
+}
+
+type repository struct {
+	db *gorm.DB
+	subledger.Control
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db, Control: subledger.Control{Ledger: subledger.Receivable, DB: db}}
+}
+
+const customerColumns = `c.id, c.code, c.name, COALESCE(c.email, '') AS email, c.payment_term_days, c.is_active,
+	COALESCE((
+		SELECT SUM(i.total - i.amount_paid) FROM sales_invoices i
+		WHERE i.customer_id = c.id AND i.status = 'open'
+	), 0) AS balance,
+	c.created_at, c.updated_at`
+
+func (r *repository) FindCustomers() ([]CustomerResponse, error) {
+	var customers []CustomerResponse
+	err := r.db.Raw(
+		`SELECT ` + customerColumns + `
+		 FROM customers c
+		 WHERE c.deleted_at IS NULL
+		 ORDER BY c.code ASC`,
+	).Scan(&customers).Error
+	return customers, err
+}
+
+func (r *repository) findCustomer(where string, arg any) (*CustomerResponse, error) {
+	var c CustomerResponse
+	result := r.db.Raw(
+		`SELECT `+customerColumns+`
+		 FROM customers c
+		 WHERE `+where+` AND c.deleted_at IS NULL
+		 LIMIT 1`,
+		arg,
+	).Scan(&c)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &c, nil
+}
+
+func (r *repository) FindCustomer(id uuid.UUID) (*CustomerResponse, error) {
+	return r.findCustomer("c.id = ?", id)
+}
+
+func (r *repository) FindCustomerByCode(code string) (*CustomerResponse, error) {
+	return r.findCustomer("c.code = ?", code)
+}
+
+func (r *repository) CreateCustomer(c *domain.Customer) error {
+	return r.db.Exec(
+		`INSERT INTO customers (id, code, name, email, payment_term_days, is_active, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		c.ID, c.Code, c.Name, c.Email, c.PaymentTermDays, c.IsActive,
+	).Error
+}
+
+func (r *repository) UpdateCustomer(c *domain.Customer) error {
+	return r.db.Exec(
+		`UPDATE customers
+		 SET code = ?, name = ?, email = ?, payment_term_days = ?, is_active = ?, updated_at = NOW()
+		 WHERE id = ? AND deleted_at IS NULL`,
+		c.Code, c.Name, c.Email, c.PaymentTermDays, c.IsActive, c.ID,
+	).Error
+}
+
+func (r *repository) DeleteCustomer(id uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE customers SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`,
+		id,
+	).Error
+}
+
+// CountUnsettled counts the customer's draft or open invoices and draft
+// receipts.
+func (r *repository) CountUnsettled(customerID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Raw(
+		`SELECT
+			(SELECT COUNT(*) FROM sales_invoices WHERE customer_id = ? AND status IN ('draft', 'open')) +
+			(SELECT COUNT(*) FROM receipts WHERE customer_id = ? AND status = 'draft')`,
+		customerID, customerID,
+	).Scan(&count).Error
+	return count, err
+}
+
+const invoiceColumns = `i.id, i.number, i.customer_id, c.code AS customer_code, c.name AS customer_name,
+	i.date, i.due_date, COALESCE(i.description, '') AS description, i.status, i.total, i.amount_paid,
+	i.total - i.amount_paid AS outstanding, COALESCE(i.journal_entry_id::text, '') AS journal_entry_id,
+	COALESCE(j.reference, '') AS journal_reference, i.posted_at, i.created_by, i.created_at, i.updated_at`
+
+const invoiceFrom = `FROM sales_invoices i
+	JOIN customers c ON c.id = i.customer_id
+	LEFT JOIN journal_entries j ON j.id = i.journal_entry_id`
+
+func (r *repository) FindInvoices(query *InvoiceQuery) ([]InvoiceResponse, int64, error) {
+	where := "1 = 1"
+	var args []interface{}
+	if query.CustomerID != "" {
+		where += " AND i.customer_id = ?"
+		args = append(args, query.CustomerID)
+	}
+	if query.Status != "" {
+		where += " AND i.status = ?"
+		args = append(args, query.Status)
+	}
+	if query.StartDate != "" {
+		where += " AND i.date >= ?"
+		args = append(args, query.StartDate)
+	}
+	if query.EndDate != "" {
+		where += " AND i.date <= ?"
+		args = append(args, query.EndDate)
+	}
+
+	var total int64
+	if err := r.db.Raw(`SELECT COUNT(*) FROM sales_invoices i WHERE `+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []InvoiceResponse
+	err := r.db.Raw(
+		`SELECT `+invoiceColumns+`
+		 `+invoiceFrom+`
+		 WHERE `+where+`
+		 ORDER BY i.date DESC, i.number DESC
+		 LIMIT ? OFFSET ?`,
+		append(args, query.Limit, (query.Page-1)*query.Limit)...,
+	).Scan(&rows).Error
+	return rows, total, err
+}
+
+func (r *repository) FindInvoice(id uuid.UUID) (*InvoiceResponse, error) {
+	var inv InvoiceResponse
+	result := r.db.Raw(
+		`SELECT `+invoiceColumns+`
+		 `+invoiceFrom+`
+		 WHERE i.id = ?
+		 LIMIT 1`,
+		id,
+	).Scan(&inv)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &inv, nil
+}
+
+func (r *repository) FindInvoiceLines(id uuid.UUID) ([]domain.SalesInvoiceLine, error) {
+	var lines []domain.SalesInvoiceLine
+	err := r.db.Raw(
+		`SELECT id, invoice_id, seq, coa_code, COALESCE(description, '') AS description, amount, dimensions
+		 FROM sales_invoice_lines
+		 WHERE invoice_id = ?
+		 ORDER BY seq ASC`,
+		id,
+	).Scan(&lines).Error
+	return lines, err
+}
+
+// LockInvoice loads an invoice and locks its row until the transaction
+// ends, so concurrent receipts cannot over-settle it.
+func (r *repository) LockInvoice(id uuid.UUID) (*domain.SalesInvoice, error) {
+	var inv domain.SalesInvoice
+	result := r.db.Raw(
+		`SELECT id, number, customer_id, date, due_date, COALESCE(description, '') AS description, status,
+			total, amount_paid, journal_entry_id, created_by, posted_at, created_at, updated_at
+		 FROM sales_invoices
+		 WHERE id = ?
+		 FOR UPDATE`,
+		id,
+	).Scan(&inv)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &inv, nil
+}
+
+func (r *repository) createLines(lines []domain.SalesInvoiceLine) error {
+	for _, l := range lines {
+		if err := r.db.Exec(
+			`INSERT INTO sales_invoice_lines (id, invoice_id, seq, coa_code, description, amount, dimensions)
+			 VALUES (gen_random_uuid(), ?, ?, ?, ?, ?, ?)`,
+			l.InvoiceID, l.Seq, l.CoaCode, l.Description, l.Amount, l.Dimensions,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) CreateInvoice(inv *domain.SalesInvoice, lines []domain.SalesInvoiceLine) error {
+	if err := r.db.Exec(
+		`INSERT INTO sales_invoices (id, number, customer_id, date, due_date, description, status, total, amount_paid, created_by, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, 0, ?, NOW(), NOW())`,
+		inv.ID, inv.Number, inv.CustomerID, inv.Date, inv.DueDate, inv.Description, inv.Status, inv.Total, inv.CreatedBy,
+	).Error; err != nil {
+		return err
+	}
+	return r.createLines(lines)
+}
+
+func (r *repository) ReplaceInvoice(inv *domain.SalesInvoice, lines []domain.SalesInvoiceLine) error {
+	if err := r.db.Exec(
+		`UPDATE sales_invoices
+		 SET customer_id = ?, date = ?, due_date = ?, description = ?, total = ?, updated_at = NOW()
+		 WHERE id = ?`,
+		inv.CustomerID, inv.Date, inv.DueDate, inv.Description, inv.Total, inv.ID,
+	).Error; err != nil {
+		return err
+	}
+	if err := r.db.Exec(`DELETE FROM sales_invoice_lines WHERE invoice_id = ?`, inv.ID).Error; err != nil {
+		return err
+	}
+	return r.createLines(lines)
+}
+
+// DeleteInvoice removes a draft invoice. Drafts never reached the ledger,
+// so they are deleted outright.
+func (r *repository) DeleteInvoice(id uuid.UUID) error {
+	if err := r.db.Exec(`DELETE FROM sales_invoice_lines WHERE invoice_id = ?`, id).Error; err != nil {
+		return err
+	}
+	return r.db.Exec(`DELETE FROM sales_invoices WHERE id = ? AND status = 'draft'`, id).Error
+}
+
+func (r *repository) MarkInvoicePosted(id, journalID uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE sales_invoices
+		 SET status = 'open', journal_entry_id = ?, posted_at = NOW(), updated_at = NOW()
+		 WHERE id = ?`,
+		journalID, id,
+	).Error
+}
+
+// ApplyPayment adds a settled amount to an invoice and marks it paid once
+// nothing is left.
+func (r *repository) ApplyPayment(id uuid.UUID, amount float64) error {
+	return r.db.Exec(
+		`UPDATE sales_invoices
+		 SET amount_paid = amount_paid + ?,
+			status = CASE WHEN total - (amount_paid + ?) <= 0 THEN 'paid' ELSE status END,
+			updated_at = NOW()
+		 WHERE id = ?`,
+		amount, amount, id,
+	).Error
+}
+
+const receiptColumns = `r.id, r.number, r.customer_id, c.code AS customer_code, c.name AS customer_name,
+	r.date, r.amount, r.bank_coa_code, COALESCE(r.description, '') AS description, r.status,
+	COALESCE(r.journal_entry_id::text, '') AS journal_entry_id, COALESCE(j.reference, '') AS journal_reference,
+	r.posted_at, r.created_by, r.created_at, r.updated_at`
+
+const receiptFrom = `FROM receipts r
+	JOIN customers c ON c.id = r.customer_id
+	LEFT JOIN journal_entries j ON j.id = r.journal_entry_id`
+
+func (r *repository) FindReceipts(query *ReceiptQuery) ([]ReceiptResponse, int64, error) {
+	where := "1 = 1"
+	var args []interface{}
+	if query.CustomerID != "" {
+		where += " AND r.customer_id = ?"
+		args = append(args, query.CustomerID)
+	}
+	if query.Status != "" {
+		where += " AND r.status = ?"
+		args = append(args, query.Status)
+	}
+
+	var total int64
+	if err := r.db.Raw(`SELECT COUNT(*) FROM receipts r WHERE `+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []ReceiptResponse
+	err := r.db.Raw(
+		`SELECT `+receiptColumns+`
+		 `+receiptFrom+`
+		 WHERE `+where+`
+		 ORDER BY r.date DESC, r.number DESC
+		 LIMIT ? OFFSET ?`,
+		append(args, query.Limit, (query.Page-1)*query.Limit)...,
+	).Scan(&rows).Error
+	return rows, total, err
+}
+
+func (r *repository) FindReceipt(id uuid.UUID) (*ReceiptResponse, error) {
+	var rc ReceiptResponse
+	result := r.db.Raw(
+		`SELECT `+receiptColumns+`
+		 `+receiptFrom+`
+		 WHERE r.id = ?
+		 LIMIT 1`,
+		id,
+	).Scan(&rc)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &rc, nil
+}
+
+func (r *repository) FindAllocations(receiptID uuid.UUID) ([]AllocationResponse, error) {
+	var allocations []AllocationResponse
+	err := r.db.Raw(
+		`SELECT a.invoice_id, i.number AS invoice_number, a.amount
+		 FROM receipt_allocations a
+		 JOIN sales_invoices i ON i.id = a.invoice_id
+		 WHERE a.receipt_id = ?
+		 ORDER BY i.due_date ASC, i.number ASC`,
+		receiptID,
+	).Scan(&allocations).Error
+	return allocations, err
+}
+
+func (r *repository) LockReceipt(id uuid.UUID) (*domain.Receipt, error) {
+	var rc domain.Receipt
+	result := r.db.Raw(
+		`SELECT id, number, customer_id, date, amount, bank_coa_code, COALESCE(description, '') AS description,
+			status, journal_entry_id, created_by, posted_at, created_at, updated_at
+		 FROM receipts
+		 WHERE id = ?
+		 FOR UPDATE`,
+		id,
+	).Scan(&rc)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &rc, nil
+}
+
+func (r *repository) CreateReceipt(rc *domain.Receipt, allocations []domain.ReceiptAllocation) error {
+	if err := r.db.Exec(
+		`INSERT INTO receipts (id, number, customer_id, date, amount, bank_coa_code, description, status, created_by, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		rc.ID, rc.Number, rc.CustomerID, rc.Date, rc.Amount, rc.BankCoaCode, rc.Description, rc.Status, rc.CreatedBy,
+	).Error; err != nil {
+		return err
+	}
+	for _, a := range allocations {
+		if err := r.db.Exec(
+			`INSERT INTO receipt_allocations (id, receipt_id, invoice_id, amount) VALUES (gen_random_uuid(), ?, ?, ?)`,
+			a.ReceiptID, a.InvoiceID, a.Amount,
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *repository) DeleteReceipt(id uuid.UUID) error {
+	if err := r.db.Exec(`DELETE FROM receipt_allocations WHERE receipt_id = ?`, id).Error; err != nil {
+		return err
+	}
+	return r.db.Exec(`DELETE FROM receipts WHERE id = ? AND status = 'draft'`, id).Error
+}
+
+func (r *repository) MarkReceiptPosted(id, journalID uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE receipts
+		 SET status = 'posted', journal_entry_id = ?, posted_at = NOW(), updated_at = NOW()
+		 WHERE id = ?`,
+		journalID, id,
+	).Error
+}
+
+// FindOpenItems lists posted invoices dated on or before asOf that still
+// had an amount owed on that date, counting only receipts dated up to it.
+func (r *repository) FindOpenItems(asOf time.Time, customerID string) ([]OpenItem, error) {
+	where := "i.status <> 'draft' AND i.date <= ?"
+	args := []interface{}{asOf, asOf}
+	if customerID != "" {
+		where += " AND i.customer_id = ?"
+		args = append(args, customerID)
+	}
+
+	var items []OpenItem
+	err := r.db.Raw(
+		`SELECT i.id AS invoice_id, i.number, i.customer_id, c.code AS customer_code, c.name AS customer_name,
+			i.date, i.due_date, i.total, COALESCE(p.paid, 0) AS paid, i.total - COALESCE(p.paid, 0) AS outstanding
+		 FROM sales_invoices i
+		 JOIN customers c ON c.id = i.customer_id
+		 LEFT JOIN (
+			SELECT a.invoice_id, SUM(a.amount) AS paid
+			FROM receipt_allocations a
+			JOIN receipts rc ON rc.id = a.receipt_id
+			WHERE rc.status = 'posted' AND rc.date <= ?
+			GROUP BY a.invoice_id
+		 ) p ON p.invoice_id = i.id
+		 WHERE `+where+`
+		 AND i.total - COALESCE(p.paid, 0) > 0
+		 ORDER BY c.code ASC, i.due_date ASC, i.number ASC`,
+		args...,
+	).Scan(&items).Error
+	return items, err
+}
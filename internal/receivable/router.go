@@ -0,0 +1,39 @@
+package receivable
+
+import (
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	arRoutes := router.Group("/ar")
+	arRoutes.Use(middleware.AuthMiddleware(), middleware.Idempotency(db))
+
+	read := middleware.RequireScope(apikey.ScopeARRead)
+	write := middleware.RequireScope(apikey.ScopeARWrite)
+
+	arRoutes.Get("/customers", read, handler.GetCustomers)
+	arRoutes.Get("/customers/:id", read, handler.GetCustomer)
+	arRoutes.Post("/customers", write, middleware.DBTransaction(db), handler.CreateCustomer)
+	arRoutes.Put("/customers/:id", write, middleware.DBTransaction(db), handler.UpdateCustomer)
+	arRoutes.Delete("/customers/:id", write, middleware.DBTransaction(db), handler.DeleteCustomer)
+
+	arRoutes.Get("/invoices", read, handler.GetInvoices)
+	arRoutes.Get("/invoices/:id", read, handler.GetInvoice)
+	arRoutes.Post("/invoices", write, middleware.DBTransaction(db), handler.CreateInvoice)
+	arRoutes.Put("/invoices/:id", write, middleware.DBTransaction(db), handler.UpdateInvoice)
+	arRoutes.Delete("/invoices/:id", write, middleware.DBTransaction(db), handler.DeleteInvoice)
+	arRoutes.Put("/invoices/:id/post", write, middleware.DBTransaction(db), handler.PostInvoice)
+
+	arRoutes.Get("/receipts", read, handler.GetReceipts)
+	arRoutes.Get("/receipts/:id", read, handler.GetReceipt)
+	arRoutes.Post("/receipts", write, middleware.DBTransaction(db), handler.CreateReceipt)
+	arRoutes.Delete("/receipts/:id", write, middleware.DBTransaction(db), handler.DeleteReceipt)
+	arRoutes.Put("/receipts/:id/post", write, middleware.DBTransaction(db), handler.PostReceipt)
+
+	arRoutes.Get("/open-items", read, handler.GetOpenItems)
+	arRoutes.Get("/reconciliation", read, handler.Reconcile)
+}
@@ -0,0 +1,774 @@
+package receivable
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/journal"
+	"fiber.com/session-api/internal/subledger"
+	"fiber.com/session-api/pkg/model"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetCustomers() ([]CustomerResponse, error)
+	GetCustomer(id uuid.UUID) (*CustomerResponse, error)
+	CreateCustomer(req *CustomerRequest, actor audit.Actor, tx *gorm.DB) (*CustomerResponse, error)
+	UpdateCustomer(id uuid.UUID, req *CustomerRequest, actor audit.Actor, tx *gorm.DB) (*CustomerResponse, error)
+	DeleteCustomer(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+
+	GetInvoices(query *InvoiceQuery) ([]InvoiceResponse, *model.MetaPagination, error)
+	GetInvoice(id uuid.UUID) (*InvoiceResponse, error)
+	CreateInvoice(req *InvoiceRequest, actor audit.Actor, tx *gorm.DB) (*InvoiceResponse, error)
+	UpdateInvoice(id uuid.UUID, req *InvoiceRequest, actor audit.Actor, tx *gorm.DB) (*InvoiceResponse, error)
+	DeleteInvoice(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	PostInvoice(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*InvoiceResponse, error)
+
+	GetReceipts(query *ReceiptQuery) ([]ReceiptResponse, *model.MetaPagination, error)
+	GetReceipt(id uuid.UUID) (*ReceiptResponse, error)
+	CreateReceipt(req *ReceiptRequest, actor audit.Actor, tx *gorm.DB) (*ReceiptResponse, error)
+	DeleteReceipt(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	PostReceipt(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*ReceiptResponse, error)
+
+	GetOpenItems(query *OpenItemQuery) ([]OpenItem, error)
+	Reconcile(asOf string) (*subledger.Reconciliation, error)
+}
+
+type service struct {
+	repo           Repository
+	journalService journal.Service
+}
+
+func NewService(repo Repository, journalService journal.Service) Service {
+	return &service{repo: repo, journalService: journalService}
+}
+
+// Customers
+
+func findCustomer(repo Repository, id uuid.UUID) (*CustomerResponse, error) {
+	c, err := repo.FindCustomer(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if c == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Customer not found")
+	}
+	return c, nil
+}
+
+func checkCustomerRequest(repo Repository, req *CustomerRequest, id uuid.UUID) error {
+	req.Code = strings.TrimSpace(req.Code)
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Code == "" || req.Name == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "code and name are required")
+	}
+	if len(req.Code) > 20 {
+		return fiber.NewError(fiber.StatusBadRequest, "code must be at most 20 characters")
+	}
+	if len(req.Name) > 150 {
+		return fiber.NewError(fiber.StatusBadRequest, "name must be at most 150 characters")
+	}
+	if req.PaymentTermDays != nil && *req.PaymentTermDays < 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "paymentTermDays cannot be negative")
+	}
+
+	existing, err := repo.FindCustomerByCode(req.Code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if existing != nil && existing.ID != id.String() {
+		return fiber.NewError(fiber.StatusConflict, "Customer code already exists")
+	}
+	return nil
+}
+
+func (s *service) GetCustomers() ([]CustomerResponse, error) {
+	customers, err := s.repo.FindCustomers()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if customers == nil {
+		customers = []CustomerResponse{}
+	}
+	return customers, nil
+}
+
+func (s *service) GetCustomer(id uuid.UUID) (*CustomerResponse, error) {
+	return findCustomer(s.repo, id)
+}
+
+func (s *service) CreateCustomer(req *CustomerRequest, actor audit.Actor, tx *gorm.DB) (*CustomerResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if err := checkCustomerRequest(txRepo, req, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	c := &domain.Customer{
+		ID:              uuid.New(),
+		Code:            req.Code,
+		Name:            req.Name,
+		Email:           req.Email,
+		PaymentTermDays: 30,
+		IsActive:        req.IsActive == nil || *req.IsActive,
+	}
+	if req.PaymentTermDays != nil {
+		c.PaymentTermDays = *req.PaymentTermDays
+	}
+	if err := txRepo.CreateCustomer(c); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findCustomer(txRepo, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityCustomer, after.ID, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) UpdateCustomer(id uuid.UUID, req *CustomerRequest, actor audit.Actor, tx *gorm.DB) (*CustomerResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := findCustomer(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCustomerRequest(txRepo, req, id); err != nil {
+		return nil, err
+	}
+
+	c := &domain.Customer{
+		ID:              id,
+		Code:            req.Code,
+		Name:            req.Name,
+		Email:           req.Email,
+		PaymentTermDays: before.PaymentTermDays,
+		IsActive:        before.IsActive,
+	}
+	if req.PaymentTermDays != nil {
+		c.PaymentTermDays = *req.PaymentTermDays
+	}
+	if req.IsActive != nil {
+		c.IsActive = *req.IsActive
+	}
+	if err := txRepo.UpdateCustomer(c); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findCustomer(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityCustomer, id.String(), audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// DeleteCustomer removes a customer with nothing left to settle. Posted
+// history stays attached to the deleted record.
+func (s *service) DeleteCustomer(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	before, err := findCustomer(txRepo, id)
+	if err != nil {
+		return err
+	}
+
+	unsettled, err := txRepo.CountUnsettled(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if unsettled > 0 {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Customer %s cannot be deleted: it has %d draft or open documents", before.Code, unsettled,
+		))
+	}
+
+	if err := txRepo.DeleteCustomer(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return audit.Record(tx, actor, audit.EntityCustomer, id.String(), audit.ActionDelete, before, nil)
+}
+
+// Invoices
+
+func findInvoice(repo Repository, id uuid.UUID) (*InvoiceResponse, error) {
+	inv, err := repo.FindInvoice(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if inv == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Invoice not found")
+	}
+
+	lines, err := repo.FindInvoiceLines(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	inv.Lines = make([]InvoiceLineResponse, len(lines))
+	for i, l := range lines {
+		var dims map[string]string
+		if len(l.Dimensions) > 0 {
+			_ = json.Unmarshal(l.Dimensions, &dims)
+		}
+		inv.Lines[i] = InvoiceLineResponse{
+			CoaCode:     l.CoaCode,
+			Description: l.Description,
+			Amount:      l.Amount,
+			Dimensions:  dims,
+		}
+	}
+	return inv, nil
+}
+
+// buildInvoice validates a draft invoice request against an active customer
+// and revenue accounts.
+func buildInvoice(tx *gorm.DB, req *InvoiceRequest) (*domain.SalesInvoice, []domain.SalesInvoiceLine, error) {
+	customerID, err := subledger.ParseID("customerId", req.CustomerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	customer, err := findCustomer(NewRepository(tx), customerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !customer.IsActive {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Customer %s is inactive", customer.Code))
+	}
+
+	date, err := subledger.ParseDate("date", req.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+	dueDate := date.AddDate(0, 0, customer.PaymentTermDays)
+	if req.DueDate != "" {
+		if dueDate, err = subledger.ParseDate("dueDate", req.DueDate); err != nil {
+			return nil, nil, err
+		}
+		if dueDate.Before(date) {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "dueDate cannot be before date")
+		}
+	}
+
+	if len(req.Lines) == 0 {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "An invoice needs at least one line")
+	}
+
+	inv := &domain.SalesInvoice{
+		CustomerID:  customerID,
+		Date:        date,
+		DueDate:     dueDate,
+		Description: req.Description,
+	}
+	lines := make([]domain.SalesInvoiceLine, len(req.Lines))
+	for i, l := range req.Lines {
+		amount := utils.Round2(l.Amount)
+		if amount <= 0 {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Line %d: amount must be greater than zero", i+1))
+		}
+		if err := subledger.CheckAccount(tx, l.CoaCode, "Revenue account", domain.AccountTypeRevenue); err != nil {
+			return nil, nil, err
+		}
+
+		dims, err := json.Marshal(l.Dimensions)
+		if err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Invalid dimensions")
+		}
+		lines[i] = domain.SalesInvoiceLine{
+			Seq:         i + 1,
+			CoaCode:     l.CoaCode,
+			Description: l.Description,
+			Amount:      amount,
+			Dimensions:  dims,
+		}
+		inv.Total += amount
+	}
+	inv.Total = utils.Round2(inv.Total)
+
+	return inv, lines, nil
+}
+
+func setInvoiceID(lines []domain.SalesInvoiceLine, id uuid.UUID) {
+	for i := range lines {
+		lines[i].InvoiceID = id
+	}
+}
+
+func (s *service) GetInvoices(query *InvoiceQuery) ([]InvoiceResponse, *model.MetaPagination, error) {
+	if query.CustomerID != "" {
+		if _, err := subledger.ParseID("customerId", query.CustomerID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rows, total, err := s.repo.FindInvoices(query)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rows == nil {
+		rows = []InvoiceResponse{}
+	}
+	return rows, subledger.Paginate(query.Page, query.Limit, total), nil
+}
+
+func (s *service) GetInvoice(id uuid.UUID) (*InvoiceResponse, error) {
+	return findInvoice(s.repo, id)
+}
+
+func (s *service) CreateInvoice(req *InvoiceRequest, actor audit.Actor, tx *gorm.DB) (*InvoiceResponse, error) {
+	txRepo := NewRepository(tx)
+
+	createdBy, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+
+	inv, lines, err := buildInvoice(tx, req)
+	if err != nil {
+		return nil, err
+	}
+	inv.ID = uuid.New()
+	inv.Number = subledger.DocumentNumber("SI", inv.Date)
+	inv.Status = domain.SalesInvoiceStatusDraft
+	inv.CreatedBy = createdBy
+	setInvoiceID(lines, inv.ID)
+
+	if err := txRepo.CreateInvoice(inv, lines); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findInvoice(txRepo, inv.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntitySalesInvoice, after.ID, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// lockDraftInvoice locks an invoice that must still be a draft.
+func lockDraftInvoice(repo Repository, id uuid.UUID) (*domain.SalesInvoice, error) {
+	inv, err := repo.LockInvoice(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if inv == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Invoice not found")
+	}
+	if inv.Status != domain.SalesInvoiceStatusDraft {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Invoice %s is already posted", inv.Number))
+	}
+	return inv, nil
+}
+
+func (s *service) UpdateInvoice(id uuid.UUID, req *InvoiceRequest, actor audit.Actor, tx *gorm.DB) (*InvoiceResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if _, err := lockDraftInvoice(txRepo, id); err != nil {
+		return nil, err
+	}
+	before, err := findInvoice(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, lines, err := buildInvoice(tx, req)
+	if err != nil {
+		return nil, err
+	}
+	inv.ID = id
+	setInvoiceID(lines, id)
+
+	if err := txRepo.ReplaceInvoice(inv, lines); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findInvoice(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntitySalesInvoice, id.String(), audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) DeleteInvoice(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	if _, err := lockDraftInvoice(txRepo, id); err != nil {
+		return err
+	}
+	before, err := findInvoice(txRepo, id)
+	if err != nil {
+		return err
+	}
+
+	if err := txRepo.DeleteInvoice(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return audit.Record(tx, actor, audit.EntitySalesInvoice, id.String(), audit.ActionDelete, before, nil)
+}
+
+// PostInvoice books the invoice: the AR control account is debited with the
+// total and each revenue line credited, through a posted journal dated on
+// the invoice date. The invoice then becomes an open item.
+func (s *service) PostInvoice(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*InvoiceResponse, error) {
+	txRepo := NewRepository(tx)
+
+	if _, err := lockDraftInvoice(txRepo, id); err != nil {
+		return nil, err
+	}
+	before, err := findInvoice(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	control, err := subledger.Receivable.CheckControl(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Sales invoice %s - %s", before.Number, before.CustomerName)
+	details := []journal.JournalDetailRequest{{
+		CoaCode:     control,
+		Debit:       before.Total,
+		Description: description,
+	}}
+	for _, l := range before.Lines {
+		lineDescription := l.Description
+		if lineDescription == "" {
+			lineDescription = description
+		}
+		details = append(details, journal.JournalDetailRequest{
+			CoaCode:     l.CoaCode,
+			Credit:      l.Amount,
+			Description: lineDescription,
+			Dimensions:  l.Dimensions,
+		})
+	}
+
+	entry, err := journal.CreateDraft(tx, journal.DraftInput{
+		Date:        before.Date,
+		Prefix:      "INV",
+		Description: description,
+		Type:        domain.JournalTypeSalesInvoice,
+		Details:     details,
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+	entryID := uuid.MustParse(entry.ID)
+	if err := s.journalService.PostJournal(entryID, actor, tx); err != nil {
+		return nil, err
+	}
+
+	if err := txRepo.MarkInvoicePosted(id, entryID); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findInvoice(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntitySalesInvoice, id.String(), audit.ActionPost, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Receipts
+
+func findReceipt(repo Repository, id uuid.UUID) (*ReceiptResponse, error) {
+	rc, err := repo.FindReceipt(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rc == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Receipt not found")
+	}
+
+	allocations, err := repo.FindAllocations(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if allocations == nil {
+		allocations = []AllocationResponse{}
+	}
+	rc.Allocations = allocations
+	return rc, nil
+}
+
+// checkAllocation verifies that an invoice can take amount from a receipt
+// of the customer dated on date.
+func checkAllocation(inv *domain.SalesInvoice, customerID uuid.UUID, date time.Time, amount float64) error {
+	if inv.CustomerID != customerID {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invoice %s belongs to another customer", inv.Number))
+	}
+	if inv.Status != domain.SalesInvoiceStatusOpen {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Invoice %s is %s, not open", inv.Number, inv.Status))
+	}
+	if utils.DateOf(date).Before(utils.DateOf(inv.Date)) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Receipt date is before invoice %s", inv.Number))
+	}
+	if outstanding := utils.Round2(inv.Total - inv.AmountPaid); amount > outstanding {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Allocation of %.2f exceeds the %.2f outstanding on invoice %s", amount, outstanding, inv.Number,
+		))
+	}
+	return nil
+}
+
+func (s *service) GetReceipts(query *ReceiptQuery) ([]ReceiptResponse, *model.MetaPagination, error) {
+	if query.CustomerID != "" {
+		if _, err := subledger.ParseID("customerId", query.CustomerID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rows, total, err := s.repo.FindReceipts(query)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rows == nil {
+		rows = []ReceiptResponse{}
+	}
+	return rows, subledger.Paginate(query.Page, query.Limit, total), nil
+}
+
+func (s *service) GetReceipt(id uuid.UUID) (*ReceiptResponse, error) {
+	return findReceipt(s.repo, id)
+}
+
+func (s *service) CreateReceipt(req *ReceiptRequest, actor audit.Actor, tx *gorm.DB) (*ReceiptResponse, error) {
+	txRepo := NewRepository(tx)
+
+	createdBy, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+
+	customerID, err := subledger.ParseID("customerId", req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := findCustomer(txRepo, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if !customer.IsActive {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Customer %s is inactive", customer.Code))
+	}
+
+	date, err := subledger.ParseDate("date", req.Date)
+	if err != nil {
+		return nil, err
+	}
+	amount := utils.Round2(req.Amount)
+	if amount <= 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "amount must be greater than zero")
+	}
+	if err := subledger.CheckAccount(tx, req.BankCoaCode, "Bank account", domain.AccountTypeAsset); err != nil {
+		return nil, err
+	}
+	if len(req.Allocations) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "A receipt needs at least one allocation")
+	}
+
+	rc := &domain.Receipt{
+		ID:          uuid.New(),
+		Number:      subledger.DocumentNumber("RCT", date),
+		CustomerID:  customerID,
+		Date:        date,
+		Amount:      amount,
+		BankCoaCode: req.BankCoaCode,
+		Description: req.Description,
+		Status:      domain.ReceiptStatusDraft,
+		CreatedBy:   createdBy,
+	}
+
+	seen := map[uuid.UUID]bool{}
+	var allocated float64
+	allocations := make([]domain.ReceiptAllocation, len(req.Allocations))
+	for i, a := range req.Allocations {
+		invoiceID, err := subledger.ParseID("invoiceId", a.InvoiceID)
+		if err != nil {
+			return nil, err
+		}
+		if seen[invoiceID] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "An invoice appears more than once in allocations")
+		}
+		seen[invoiceID] = true
+
+		allocation := utils.Round2(a.Amount)
+		if allocation <= 0 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Allocation amounts must be greater than zero")
+		}
+		inv, err := txRepo.LockInvoice(invoiceID)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if inv == nil {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Invoice not found")
+		}
+		if err := checkAllocation(inv, customerID, date, allocation); err != nil {
+			return nil, err
+		}
+
+		allocations[i] = domain.ReceiptAllocation{ReceiptID: rc.ID, InvoiceID: invoiceID, Amount: allocation}
+		allocated += allocation
+	}
+	if diff := utils.Round2(allocated - amount); diff != 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"Allocations total %.2f but the receipt amount is %.2f", utils.Round2(allocated), amount,
+		))
+	}
+
+	if err := txRepo.CreateReceipt(rc, allocations); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findReceipt(txRepo, rc.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityReceipt, after.ID, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func lockDraftReceipt(repo Repository, id uuid.UUID) (*domain.Receipt, error) {
+	rc, err := repo.LockReceipt(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rc == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Receipt not found")
+	}
+	if rc.Status != domain.ReceiptStatusDraft {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Receipt %s is already posted", rc.Number))
+	}
+	return rc, nil
+}
+
+func (s *service) DeleteReceipt(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	if _, err := lockDraftReceipt(txRepo, id); err != nil {
+		return err
+	}
+	before, err := findReceipt(txRepo, id)
+	if err != nil {
+		return err
+	}
+
+	if err := txRepo.DeleteReceipt(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return audit.Record(tx, actor, audit.EntityReceipt, id.String(), audit.ActionDelete, before, nil)
+}
+
+// PostReceipt books the receipt, debiting the bank and crediting the AR
+// control account, and settles its allocations. The invoices are locked
+// and re-checked first, since other receipts may have settled them since
+// the draft was saved.
+func (s *service) PostReceipt(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*ReceiptResponse, error) {
+	txRepo := NewRepository(tx)
+
+	rc, err := lockDraftReceipt(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	before, err := findReceipt(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lock in a fixed order so concurrent receipts cannot deadlock.
+	allocations := before.Allocations
+	sort.Slice(allocations, func(i, j int) bool { return allocations[i].InvoiceID < allocations[j].InvoiceID })
+	for _, a := range allocations {
+		inv, err := txRepo.LockInvoice(uuid.MustParse(a.InvoiceID))
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if inv == nil {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Invoice not found")
+		}
+		if err := checkAllocation(inv, rc.CustomerID, rc.Date, a.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	control, err := subledger.Receivable.CheckControl(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := subledger.CheckAccount(tx, rc.BankCoaCode, "Bank account", domain.AccountTypeAsset); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("Receipt %s - %s", before.Number, before.CustomerName)
+	entry, err := journal.CreateDraft(tx, journal.DraftInput{
+		Date:        rc.Date,
+		Prefix:      "RCT",
+		Description: description,
+		Type:        domain.JournalTypeReceipt,
+		Details: []journal.JournalDetailRequest{
+			{CoaCode: rc.BankCoaCode, Debit: rc.Amount, Description: description},
+			{CoaCode: control, Credit: rc.Amount, Description: description},
+		},
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+	entryID := uuid.MustParse(entry.ID)
+	if err := s.journalService.PostJournal(entryID, actor, tx); err != nil {
+		return nil, err
+	}
+
+	for _, a := range allocations {
+		if err := txRepo.ApplyPayment(uuid.MustParse(a.InvoiceID), a.Amount); err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+	}
+	if err := txRepo.MarkReceiptPosted(id, entryID); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findReceipt(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityReceipt, id.String(), audit.ActionPost, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Open items
+
+func (s *service) GetOpenItems(query *OpenItemQuery) ([]OpenItem, error) {
+	return subledger.FindOpenItems[OpenItem](s.repo, query.AsOf, "customerId", query.CustomerID)
+}
+
+// Reconcile compares the AR control account balance with the total of the
+// open items on the same date.
+func (s *service) Reconcile(asOf string) (*subledger.Reconciliation, error) {
+	return subledger.Reconcile[OpenItem](subledger.Receivable, s.repo, asOf)
+}
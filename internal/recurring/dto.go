@@ -0,0 +1,102 @@
+package recurring
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/journal"
+)
+
+// JobTypeRunDue is the background job booking due recurring journals.
+const JobTypeRunDue = "recurring.run_due"
+
+// TemplateRequest creates or replaces a journal template. DayOfMonth is
+// required for monthly and quarterly recurrences and is moved back to the
+// last day in shorter months.
+type TemplateRequest struct {
+	Name        string                         `json:"name"        example:"Depreciation - office equipment"`
+	Description string                         `json:"description" example:"Penyusutan peralatan kantor"`
+	Details     []journal.JournalDetailRequest `json:"details"`
+	Recurrence  string                         `json:"recurrence"  example:"monthly" enums:"monthly,quarterly,end_of_month"`
+	DayOfMonth  int                            `json:"dayOfMonth"  example:"25"`
+	StartDate   string                         `json:"startDate"   example:"2026-01-01"`
+	EndDate     string                         `json:"endDate"     example:"2026-12-31"`
+	AutoPost    bool                           `json:"autoPost"    example:"false"`
+}
+
+type TemplateResponse struct {
+	ID          string                         `json:"id"`
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Details     []journal.JournalDetailRequest `json:"details"`
+	Recurrence  string                         `json:"recurrence"`
+	DayOfMonth  int                            `json:"dayOfMonth"`
+	StartDate   time.Time                      `json:"startDate"`
+	EndDate     *time.Time                     `json:"endDate,omitempty"`
+	AutoPost    bool                           `json:"autoPost"`
+	IsPaused    bool                           `json:"isPaused"`
+	ResumedAt   *time.Time                     `json:"resumedAt,omitempty"`
+	LastError   string                         `json:"lastError,omitempty"`
+	NextRunDate *time.Time                     `json:"nextRunDate,omitempty"`
+	CreatedBy   string                         `json:"createdBy"`
+	CreatedAt   time.Time                      `json:"createdAt"`
+	UpdatedAt   time.Time                      `json:"updatedAt"`
+}
+
+type UpcomingQuery struct {
+	Until string `query:"until" example:"2026-03-31"`
+}
+
+// UpcomingRun is a future occurrence the scheduler will book.
+type UpcomingRun struct {
+	TemplateID   string    `json:"templateId"`
+	TemplateName string    `json:"templateName"`
+	RunDate      time.Time `json:"runDate"`
+	AutoPost     bool      `json:"autoPost"`
+}
+
+type RunItem struct {
+	RunDate        time.Time `json:"runDate"        gorm:"column:run_date"`
+	JournalEntryID string    `json:"journalEntryId" gorm:"column:journal_entry_id"`
+	Reference      string    `json:"reference"      gorm:"column:reference"`
+	JournalStatus  string    `json:"journalStatus"  gorm:"column:journal_status"`
+	CreatedAt      time.Time `json:"createdAt"      gorm:"column:created_at"`
+}
+
+// RunDueResult summarises one scheduler pass. A template that fails stops
+// its catch-up at the failing date and is retried on the next pass.
+type RunDueResult struct {
+	Created int          `json:"created"`
+	Failed  []RunFailure `json:"failed,omitempty"`
+}
+
+type RunFailure struct {
+	TemplateID string    `json:"templateId"`
+	RunDate    time.Time `json:"runDate"`
+	Reason     string    `json:"reason"`
+}
+
+// Swagger Responses
+
+type SwaggerTemplateResponse struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Data    TemplateResponse `json:"data"`
+}
+
+type SwaggerTemplateListResponse struct {
+	Code    int                `json:"code"`
+	Message string             `json:"message"`
+	Data    []TemplateResponse `json:"data"`
+}
+
+type SwaggerUpcomingResponse struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Data    []UpcomingRun `json:"data"`
+}
+
+type SwaggerRunListResponse struct {
+	Code    int       `json:"code"`
+	Message string    `json:"message"`
+	Data    []RunItem `json:"data"`
+}
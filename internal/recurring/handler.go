@@ -0,0 +1,311 @@
+package recurring
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/job"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func templateID(c *fiber.Ctx) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "Invalid journal template ID")
+	}
+	return id, nil
+}
+
+// GetAll godoc
+// @Summary      List journal templates
+// @Description  Returns every recurring journal template with its lines and next run date
+// @Tags         Recurring Journal
+// @Produce      json
+// @Success      200  {object}  SwaggerTemplateListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates [get]
+func (h *Handler) GetAll(c *fiber.Ctx) error {
+	templates, err := h.service.GetAll()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get all journal templates", templates)
+}
+
+// GetUpcoming godoc
+// @Summary      List upcoming recurring runs
+// @Description  Returns the occurrences of active templates from today up to the until date (default 30 days ahead) that have not been booked yet
+// @Tags         Recurring Journal
+// @Produce      json
+// @Param        until  query  string  false  "Last date to include (YYYY-MM-DD)"
+// @Success      200  {object}  SwaggerUpcomingResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates/upcoming [get]
+func (h *Handler) GetUpcoming(c *fiber.Ctx) error {
+	var query UpcomingQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	runs, err := h.service.GetUpcoming(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get upcoming recurring runs", runs)
+}
+
+// GetByID godoc
+// @Summary      Get journal template by ID
+// @Description  Returns a single recurring journal template
+// @Tags         Recurring Journal
+// @Produce      json
+// @Param        id   path  string  true  "Journal Template ID (UUID)"
+// @Success      200  {object}  SwaggerTemplateResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates/{id} [get]
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	id, err := templateID(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.service.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get journal template", template)
+}
+
+// GetRuns godoc
+// @Summary      List template runs
+// @Description  Returns the occurrences a template has booked and the journals they produced (newest first)
+// @Tags         Recurring Journal
+// @Produce      json
+// @Param        id   path  string  true  "Journal Template ID (UUID)"
+// @Success      200  {object}  SwaggerRunListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates/{id}/runs [get]
+func (h *Handler) GetRuns(c *fiber.Ctx) error {
+	id, err := templateID(c)
+	if err != nil {
+		return err
+	}
+
+	runs, err := h.service.GetRuns(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get journal template runs", runs)
+}
+
+// Create godoc
+// @Summary      Create a journal template
+// @Description  Saves balanced journal lines that the scheduler books on every occurrence: monthly or quarterly on dayOfMonth (moved to the last day in shorter months), or at end_of_month. With autoPost the generated journals are posted, otherwise they stay draft.
+// @Tags         Recurring Journal
+// @Accept       json
+// @Produce      json
+// @Param        body body TemplateRequest true "Journal template payload"
+// @Success      201  {object}  SwaggerTemplateResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req TemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.service.Create(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Journal template created successfully", template)
+}
+
+// Update godoc
+// @Summary      Update a journal template
+// @Description  Replaces a journal template. Journals already booked are not changed.
+// @Tags         Recurring Journal
+// @Accept       json
+// @Produce      json
+// @Param        id    path  string           true  "Journal Template ID (UUID)"
+// @Param        body  body  TemplateRequest  true  "Journal template payload"
+// @Success      200  {object}  SwaggerTemplateResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := templateID(c)
+	if err != nil {
+		return err
+	}
+
+	var req TemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.service.Update(id, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Journal template updated successfully", template)
+}
+
+// Delete godoc
+// @Summary      Delete a journal template
+// @Description  Soft-deletes a journal template. Journals already booked are kept.
+// @Tags         Recurring Journal
+// @Produce      json
+// @Param        id   path  string  true  "Journal Template ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := templateID(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Delete(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Journal template deleted successfully", nil)
+}
+
+// Pause godoc
+// @Summary      Pause a journal template
+// @Description  Stops the scheduler from booking the template until it is resumed
+// @Tags         Recurring Journal
+// @Produce      json
+// @Param        id   path  string  true  "Journal Template ID (UUID)"
+// @Success      200  {object}  SwaggerTemplateResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates/{id}/pause [post]
+func (h *Handler) Pause(c *fiber.Ctx) error {
+	id, err := templateID(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.service.Pause(id, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Journal template paused", template)
+}
+
+// Resume godoc
+// @Summary      Resume a journal template
+// @Description  Reactivates a paused template from today; occurrences that fell due while it was paused are skipped
+// @Tags         Recurring Journal
+// @Produce      json
+// @Param        id   path  string  true  "Journal Template ID (UUID)"
+// @Success      200  {object}  SwaggerTemplateResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates/{id}/resume [post]
+func (h *Handler) Resume(c *fiber.Ctx) error {
+	id, err := templateID(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.service.Resume(id, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Journal template resumed", template)
+}
+
+// RunDue godoc
+// @Summary      Book due recurring journals now
+// @Description  Queues a scheduler pass as a background job: it books every due occurrence that has no journal yet. Safe to repeat. Follow the job on GET /jobs/{id}.
+// @Tags         Recurring Journal
+// @Produce      json
+// @Success      202  {object}  job.SwaggerJobResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      500  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /journal-templates/run-due [post]
+func (h *Handler) RunDue(c *fiber.Ctx) error {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	queued, err := job.Enqueue(tx, JobTypeRunDue, nil, audit.ActorFromCtx(c))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusAccepted, "Recurring journal run queued", queued)
+}
@@ -0,0 +1,54 @@
+package recurring
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/utils"
+)
+
+// dayIn returns day of the given month, moved back to the month's last day
+// when the month is shorter (e.g. day 31 in February).
+func dayIn(year int, month time.Month, day int) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// occurrences lists the template's run dates within [from, to], bounded by
+// its start and end dates.
+func occurrences(t *domain.JournalTemplate, from, to time.Time) []time.Time {
+	start := utils.DateOf(t.StartDate)
+	if t.EndDate != nil && utils.DateOf(*t.EndDate).Before(to) {
+		to = utils.DateOf(*t.EndDate)
+	}
+	if from.Before(start) {
+		from = start
+	}
+
+	step := 1
+	if t.Recurrence == domain.RecurrenceQuarterly {
+		step = 3
+	}
+
+	var dates []time.Time
+	for m := 0; ; m += step {
+		month := time.Date(start.Year(), start.Month()+time.Month(m), 1, 0, 0, 0, 0, time.UTC)
+
+		day := t.DayOfMonth
+		if t.Recurrence == domain.RecurrenceEndOfMonth {
+			day = 31
+		}
+		d := dayIn(month.Year(), month.Month(), day)
+
+		if d.After(to) {
+			break
+		}
+		if !d.Before(from) {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
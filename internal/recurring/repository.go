@@ -0,0 +1,187 @@
+package recurring
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAll() ([]domain.JournalTemplate, error)
+	FindActive() ([]domain.JournalTemplate, error)
+	FindByID(id uuid.UUID) (*domain.JournalTemplate, error)
+	Create(t *domain.JournalTemplate) error
+	Update(t *domain.JournalTemplate) error
+	SetPaused(id uuid.UUID, paused bool, resumedAt *time.Time) error
+	SetLastError(id uuid.UUID, message string) error
+	Delete(id uuid.UUID) error
+	FindRunDates(templateIDs []uuid.UUID) (map[uuid.UUID]map[time.Time]bool, error)
+	FindRuns(templateID uuid.UUID) ([]RunItem, error)
+	ClaimRun(templateID uuid.UUID, runDate time.Time) (bool, error)
+	SetRunJournal(templateID uuid.UUID, runDate time.Time, journalID uuid.UUID) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+const templateColumns = `id, name, description, lines, recurrence, day_of_month, start_date, end_date,
+	auto_post, is_paused, resumed_at, COALESCE(last_error, '') AS last_error, created_by, created_at, updated_at`
+
+func (r *repository) FindAll() ([]domain.JournalTemplate, error) {
+	var templates []domain.JournalTemplate
+	err := r.db.Raw(
+		`SELECT ` + templateColumns + `
+		 FROM journal_templates
+		 WHERE deleted_at IS NULL
+		 ORDER BY name ASC`,
+	).Scan(&templates).Error
+	return templates, err
+}
+
+func (r *repository) FindActive() ([]domain.JournalTemplate, error) {
+	var templates []domain.JournalTemplate
+	err := r.db.Raw(
+		`SELECT ` + templateColumns + `
+		 FROM journal_templates
+		 WHERE deleted_at IS NULL
+		 AND is_paused = false
+		 ORDER BY name ASC`,
+	).Scan(&templates).Error
+	return templates, err
+}
+
+func (r *repository) FindByID(id uuid.UUID) (*domain.JournalTemplate, error) {
+	var t domain.JournalTemplate
+	result := r.db.Raw(
+		`SELECT `+templateColumns+`
+		 FROM journal_templates
+		 WHERE id = ? AND deleted_at IS NULL
+		 LIMIT 1`,
+		id,
+	).Scan(&t)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+func (r *repository) Create(t *domain.JournalTemplate) error {
+	return r.db.Exec(
+		`INSERT INTO journal_templates (id, name, description, lines, recurrence, day_of_month, start_date, end_date,
+			auto_post, is_paused, created_by, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, false, ?, NOW(), NOW())`,
+		t.ID, t.Name, t.Description, t.Lines, t.Recurrence, t.DayOfMonth, t.StartDate, t.EndDate,
+		t.AutoPost, t.CreatedBy,
+	).Error
+}
+
+func (r *repository) Update(t *domain.JournalTemplate) error {
+	return r.db.Exec(
+		`UPDATE journal_templates
+		 SET name = ?, description = ?, lines = ?, recurrence = ?, day_of_month = ?, start_date = ?, end_date = ?,
+			auto_post = ?, updated_at = NOW()
+		 WHERE id = ? AND deleted_at IS NULL`,
+		t.Name, t.Description, t.Lines, t.Recurrence, t.DayOfMonth, t.StartDate, t.EndDate,
+		t.AutoPost, t.ID,
+	).Error
+}
+
+func (r *repository) SetPaused(id uuid.UUID, paused bool, resumedAt *time.Time) error {
+	return r.db.Exec(
+		`UPDATE journal_templates
+		 SET is_paused = ?, resumed_at = COALESCE(?, resumed_at), updated_at = NOW()
+		 WHERE id = ? AND deleted_at IS NULL`,
+		paused, resumedAt, id,
+	).Error
+}
+
+func (r *repository) SetLastError(id uuid.UUID, message string) error {
+	return r.db.Exec(
+		`UPDATE journal_templates SET last_error = ? WHERE id = ?`,
+		message, id,
+	).Error
+}
+
+func (r *repository) Delete(id uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE journal_templates SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`,
+		id,
+	).Error
+}
+
+// FindRunDates returns the occurrences already booked, per template.
+func (r *repository) FindRunDates(templateIDs []uuid.UUID) (map[uuid.UUID]map[time.Time]bool, error) {
+	done := make(map[uuid.UUID]map[time.Time]bool)
+	if len(templateIDs) == 0 {
+		return done, nil
+	}
+
+	var rows []domain.JournalTemplateRun
+	if err := r.db.Raw(
+		`SELECT template_id, run_date FROM journal_template_runs WHERE template_id IN ?`,
+		templateIDs,
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if done[row.TemplateID] == nil {
+			done[row.TemplateID] = make(map[time.Time]bool)
+		}
+		done[row.TemplateID][utils.DateOf(row.RunDate)] = true
+	}
+	return done, nil
+}
+
+func (r *repository) FindRuns(templateID uuid.UUID) ([]RunItem, error) {
+	var runs []RunItem
+	err := r.db.Raw(
+		`SELECT
+			tr.run_date,
+			tr.journal_entry_id,
+			COALESCE(je.reference, '') AS reference,
+			CASE WHEN je.deleted_at IS NOT NULL THEN 'deleted' ELSE COALESCE(je.status, '') END AS journal_status,
+			tr.created_at
+		 FROM journal_template_runs tr
+		 LEFT JOIN journal_entries je ON je.id = tr.journal_entry_id
+		 WHERE tr.template_id = ?
+		 ORDER BY tr.run_date DESC`,
+		templateID,
+	).Scan(&runs).Error
+	return runs, err
+}
+
+// ClaimRun reserves one occurrence. It reports false when the occurrence
+// was already booked, e.g. by another instance of the API.
+func (r *repository) ClaimRun(templateID uuid.UUID, runDate time.Time) (bool, error) {
+	result := r.db.Exec(
+		`INSERT INTO journal_template_runs (id, template_id, run_date, created_at)
+		 VALUES (gen_random_uuid(), ?, ?, NOW())
+		 ON CONFLICT (template_id, run_date) DO NOTHING`,
+		templateID, runDate,
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repository) SetRunJournal(templateID uuid.UUID, runDate time.Time, journalID uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE journal_template_runs SET journal_entry_id = ? WHERE template_id = ? AND run_date = ?`,
+		journalID, templateID, runDate,
+	).Error
+}
@@ -0,0 +1,28 @@
+package recurring
+
+import (
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	templateRoutes := router.Group("/journal-templates")
+	templateRoutes.Use(middleware.AuthMiddleware(), middleware.Idempotency(db))
+
+	read := middleware.RequireScope(apikey.ScopeJournalRead)
+	write := middleware.RequireScope(apikey.ScopeJournalWrite)
+
+	templateRoutes.Get("/", read, handler.GetAll)
+	templateRoutes.Get("/upcoming", read, handler.GetUpcoming)
+	templateRoutes.Post("/run-due", middleware.RequireRole("admin"), middleware.DBTransaction(db), handler.RunDue)
+	templateRoutes.Get("/:id", read, handler.GetByID)
+	templateRoutes.Get("/:id/runs", read, handler.GetRuns)
+	templateRoutes.Post("/", write, middleware.DBTransaction(db), handler.Create)
+	templateRoutes.Put("/:id", write, middleware.DBTransaction(db), handler.Update)
+	templateRoutes.Delete("/:id", write, middleware.DBTransaction(db), handler.Delete)
+	templateRoutes.Post("/:id/pause", write, middleware.DBTransaction(db), handler.Pause)
+	templateRoutes.Post("/:id/resume", write, middleware.DBTransaction(db), handler.Resume)
+}
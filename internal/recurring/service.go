@@ -0,0 +1,512 @@
+package recurring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/job"
+	"fiber.com/session-api/internal/journal"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	GetAll() ([]TemplateResponse, error)
+	GetByID(id uuid.UUID) (*TemplateResponse, error)
+	GetRuns(id uuid.UUID) ([]RunItem, error)
+	GetUpcoming(query *UpcomingQuery) ([]UpcomingRun, error)
+	Create(req *TemplateRequest, actor audit.Actor, tx *gorm.DB) (*TemplateResponse, error)
+	Update(id uuid.UUID, req *TemplateRequest, actor audit.Actor, tx *gorm.DB) (*TemplateResponse, error)
+	Delete(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	Pause(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*TemplateResponse, error)
+	Resume(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*TemplateResponse, error)
+	RunDue(db *gorm.DB, now time.Time) (*RunDueResult, error)
+}
+
+type service struct {
+	repo           Repository
+	journalService journal.Service
+}
+
+func NewService(repo Repository, journalService journal.Service) Service {
+	return &service{repo: repo, journalService: journalService}
+}
+
+// firstDate is the earliest occurrence the scheduler may still book.
+func firstDate(t *domain.JournalTemplate) time.Time {
+	from := utils.DateOf(t.StartDate)
+	if t.ResumedAt != nil && utils.DateOf(*t.ResumedAt).After(from) {
+		from = utils.DateOf(*t.ResumedAt)
+	}
+	return from
+}
+
+func toResponse(t *domain.JournalTemplate, today time.Time) (*TemplateResponse, error) {
+	var details []journal.JournalDetailRequest
+	if err := json.Unmarshal(t.Lines, &details); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	res := &TemplateResponse{
+		ID:          t.ID.String(),
+		Name:        t.Name,
+		Description: t.Description,
+		Details:     details,
+		Recurrence:  string(t.Recurrence),
+		DayOfMonth:  t.DayOfMonth,
+		StartDate:   t.StartDate,
+		EndDate:     t.EndDate,
+		AutoPost:    t.AutoPost,
+		IsPaused:    t.IsPaused,
+		ResumedAt:   t.ResumedAt,
+		LastError:   t.LastError,
+		CreatedBy:   t.CreatedBy.String(),
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+
+	if !t.IsPaused {
+		from := firstDate(t)
+		if today.After(from) {
+			from = today
+		}
+		// One recurrence step always falls within a year and a quarter.
+		if next := occurrences(t, from, from.AddDate(1, 3, 0)); len(next) > 0 {
+			res.NextRunDate = &next[0]
+		}
+	}
+	return res, nil
+}
+
+func findTemplate(repo Repository, id uuid.UUID) (*domain.JournalTemplate, error) {
+	t, err := repo.FindByID(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if t == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Journal template not found")
+	}
+	return t, nil
+}
+
+func findResponse(repo Repository, id uuid.UUID) (*TemplateResponse, error) {
+	t, err := findTemplate(repo, id)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(t, utils.DateOf(time.Now()))
+}
+
+func (s *service) GetAll() ([]TemplateResponse, error) {
+	templates, err := s.repo.FindAll()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	today := utils.DateOf(time.Now())
+	res := make([]TemplateResponse, 0, len(templates))
+	for i := range templates {
+		r, err := toResponse(&templates[i], today)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *r)
+	}
+	return res, nil
+}
+
+func (s *service) GetByID(id uuid.UUID) (*TemplateResponse, error) {
+	return findResponse(s.repo, id)
+}
+
+func (s *service) GetRuns(id uuid.UUID) ([]RunItem, error) {
+	if _, err := findTemplate(s.repo, id); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.repo.FindRuns(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if runs == nil {
+		runs = []RunItem{}
+	}
+	return runs, nil
+}
+
+// GetUpcoming lists the occurrences of active templates from today up to
+// the until date (default: 30 days ahead) that are not booked yet.
+func (s *service) GetUpcoming(query *UpcomingQuery) ([]UpcomingRun, error) {
+	today := utils.DateOf(time.Now())
+	until := today.AddDate(0, 0, 30)
+	if query.Until != "" {
+		parsed, err := time.Parse("2006-01-02", query.Until)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "until must use the YYYY-MM-DD format")
+		}
+		if parsed.Before(today) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "until cannot be in the past")
+		}
+		until = parsed
+	}
+
+	templates, err := s.repo.FindActive()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	ids := make([]uuid.UUID, len(templates))
+	for i, t := range templates {
+		ids[i] = t.ID
+	}
+	done, err := s.repo.FindRunDates(ids)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	runs := []UpcomingRun{}
+	for i := range templates {
+		t := &templates[i]
+		from := firstDate(t)
+		if today.After(from) {
+			from = today
+		}
+		for _, d := range occurrences(t, from, until) {
+			if done[t.ID][d] {
+				continue
+			}
+			runs = append(runs, UpcomingRun{
+				TemplateID:   t.ID.String(),
+				TemplateName: t.Name,
+				RunDate:      d,
+				AutoPost:     t.AutoPost,
+			})
+		}
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].RunDate.Before(runs[j].RunDate)
+	})
+	return runs, nil
+}
+
+// buildTemplate validates the request into t. The lines must balance and
+// pass the same account and dimension checks as a manual journal.
+func buildTemplate(tx *gorm.DB, req *TemplateRequest, t *domain.JournalTemplate) error {
+	if req.Name == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "name is required")
+	}
+	if len(req.Name) > 100 {
+		return fiber.NewError(fiber.StatusBadRequest, "name must be at most 100 characters")
+	}
+
+	recurrence := domain.Recurrence(req.Recurrence)
+	switch recurrence {
+	case domain.RecurrenceMonthly, domain.RecurrenceQuarterly:
+		if req.DayOfMonth < 1 || req.DayOfMonth > 31 {
+			return fiber.NewError(fiber.StatusBadRequest, "dayOfMonth must be between 1 and 31")
+		}
+	case domain.RecurrenceEndOfMonth:
+		req.DayOfMonth = 31
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "recurrence must be monthly, quarterly or end_of_month")
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "startDate must use the YYYY-MM-DD format")
+	}
+	var endDate *time.Time
+	if req.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", req.EndDate)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "endDate must use the YYYY-MM-DD format")
+		}
+		if parsed.Before(startDate) {
+			return fiber.NewError(fiber.StatusBadRequest, "endDate cannot be before startDate")
+		}
+		endDate = &parsed
+	}
+
+	if len(req.Details) < 2 {
+		return fiber.NewError(fiber.StatusBadRequest, "A journal template needs at least two lines")
+	}
+	var totalDebit, totalCredit float64
+	for i, d := range req.Details {
+		if d.Debit < 0 || d.Credit < 0 {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Line %d: debit and credit cannot be negative", i+1))
+		}
+		totalDebit += d.Debit
+		totalCredit += d.Credit
+	}
+	if utils.Round2(totalDebit) == 0 || utils.Round2(totalDebit) != utils.Round2(totalCredit) {
+		return fiber.NewError(fiber.StatusBadRequest, "Template lines must balance: total debit must equal total credit")
+	}
+	if err := journal.ValidateDetails(journal.NewRepository(tx), req.Details, domain.JournalTypeRecurring); err != nil {
+		return err
+	}
+
+	lines, err := json.Marshal(req.Details)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	t.Name = req.Name
+	t.Description = req.Description
+	t.Lines = lines
+	t.Recurrence = recurrence
+	t.DayOfMonth = req.DayOfMonth
+	t.StartDate = startDate
+	t.EndDate = endDate
+	t.AutoPost = req.AutoPost
+	return nil
+}
+
+func (s *service) Create(req *TemplateRequest, actor audit.Actor, tx *gorm.DB) (*TemplateResponse, error) {
+	txRepo := NewRepository(tx)
+
+	createdBy, err := uuid.Parse(actor.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID in token")
+	}
+
+	t := &domain.JournalTemplate{ID: uuid.New(), CreatedBy: createdBy}
+	if err := buildTemplate(tx, req, t); err != nil {
+		return nil, err
+	}
+	if err := txRepo.Create(t); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findResponse(txRepo, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityJournalTemplate, after.ID, audit.ActionCreate, nil, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Update replaces a template. Occurrences already booked keep their
+// journals; the new lines apply from the next occurrence.
+func (s *service) Update(id uuid.UUID, req *TemplateRequest, actor audit.Actor, tx *gorm.DB) (*TemplateResponse, error) {
+	txRepo := NewRepository(tx)
+
+	t, err := findTemplate(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	before, err := toResponse(t, utils.DateOf(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := buildTemplate(tx, req, t); err != nil {
+		return nil, err
+	}
+	if err := txRepo.Update(t); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findResponse(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityJournalTemplate, id.String(), audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func (s *service) Delete(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	before, err := findResponse(txRepo, id)
+	if err != nil {
+		return err
+	}
+
+	if err := txRepo.Delete(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return audit.Record(tx, actor, audit.EntityJournalTemplate, id.String(), audit.ActionDelete, before, nil)
+}
+
+func (s *service) Pause(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*TemplateResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := findResponse(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if before.IsPaused {
+		return nil, fiber.NewError(fiber.StatusConflict, "Journal template is already paused")
+	}
+
+	if err := txRepo.SetPaused(id, true, nil); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findResponse(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityJournalTemplate, id.String(), audit.ActionPause, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Resume reactivates a paused template from today: occurrences that fell
+// due while it was paused are not booked.
+func (s *service) Resume(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*TemplateResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := findResponse(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if !before.IsPaused {
+		return nil, fiber.NewError(fiber.StatusConflict, "Journal template is not paused")
+	}
+
+	today := utils.DateOf(time.Now())
+	if err := txRepo.SetPaused(id, false, &today); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	after, err := findResponse(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := audit.Record(tx, actor, audit.EntityJournalTemplate, id.String(), audit.ActionResume, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// RunDue books every occurrence of the active templates up to now that has
+// no run yet, oldest first, so a missed pass catches up. Each occurrence
+// runs in its own transaction; a failing one is recorded on the template
+// and retried on the next pass.
+func (s *service) RunDue(db *gorm.DB, now time.Time) (*RunDueResult, error) {
+	today := utils.DateOf(now)
+
+	templates, err := s.repo.FindActive()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(templates))
+	for i, t := range templates {
+		ids[i] = t.ID
+	}
+	done, err := s.repo.FindRunDates(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &RunDueResult{}
+	for i := range templates {
+		t := &templates[i]
+
+		var failure error
+		for _, d := range occurrences(t, firstDate(t), today) {
+			if done[t.ID][d] {
+				continue
+			}
+
+			var booked bool
+			err := db.Transaction(func(tx *gorm.DB) error {
+				var err error
+				booked, err = s.book(tx, t, d)
+				return err
+			})
+			if err != nil {
+				failure = err
+				res.Failed = append(res.Failed, RunFailure{TemplateID: t.ID.String(), RunDate: d, Reason: err.Error()})
+				log.Printf("recurring: template %s on %s: %v", t.ID, d.Format("2006-01-02"), err)
+				break
+			}
+			if booked {
+				res.Created++
+			}
+		}
+
+		message := ""
+		if failure != nil {
+			message = failure.Error()
+		}
+		if message != t.LastError {
+			if err := s.repo.SetLastError(t.ID, message); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// RunDueJobRunner runs the scheduler passes queued from POST
+// /journal-templates/run-due.
+func RunDueJobRunner(svc Service, db *gorm.DB) job.Runner {
+	return func(ctx context.Context, run *job.Run) (*job.Result, error) {
+		res, err := svc.RunDue(db, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		return job.JSONResult("recurring-run.json", res)
+	}
+}
+
+// book creates the journal for one occurrence. It reports false when the
+// occurrence was booked concurrently.
+func (s *service) book(tx *gorm.DB, t *domain.JournalTemplate, runDate time.Time) (bool, error) {
+	txRepo := NewRepository(tx)
+
+	claimed, err := txRepo.ClaimRun(t.ID, runDate)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	var details []journal.JournalDetailRequest
+	if err := json.Unmarshal(t.Lines, &details); err != nil {
+		return false, err
+	}
+
+	description := t.Description
+	if description == "" {
+		description = t.Name
+	}
+
+	actor := audit.Actor{ID: t.CreatedBy.String(), Name: "scheduler", Type: audit.ActorTypeSystem}
+	entry, err := journal.CreateDraft(tx, journal.DraftInput{
+		Date:        runDate,
+		Prefix:      "REC",
+		Description: description,
+		Type:        domain.JournalTypeRecurring,
+		Details:     details,
+	}, actor)
+	if err != nil {
+		return false, err
+	}
+
+	entryID := uuid.MustParse(entry.ID)
+	if t.AutoPost {
+		if err := s.journalService.PostJournal(entryID, actor, tx); err != nil {
+			return false, err
+		}
+	}
+
+	return true, txRepo.SetRunJournal(t.ID, runDate, entryID)
+}
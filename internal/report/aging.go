@@ -0,0 +1,299 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/pkg/export"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	LedgerReceivable = "receivable"
+	LedgerPayable    = "payable"
+)
+
+// maxAgingBuckets caps the overdue boundaries a request may define.
+const maxAgingBuckets = 12
+
+// agingItem is an open invoice or bill as of the report date.
+type agingItem struct {
+	DocumentID  string
+	Number      string
+	PartyID     string
+	PartyCode   string
+	PartyName   string
+	Date        time.Time
+	DueDate     time.Time
+	Outstanding float64
+}
+
+// aging is a validated aging request with its open items.
+type aging struct {
+	ledger string
+	asOf   time.Time
+	bounds []int
+	labels []string
+	items  []agingItem
+}
+
+// parseBuckets reads ascending positive day boundaries such as "30,60,90".
+func parseBuckets(s string) ([]int, error) {
+	var bounds []int
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 1 {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "buckets must be positive day counts, e.g. 30,60,90")
+		}
+		if len(bounds) > 0 && n <= bounds[len(bounds)-1] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "buckets must be in ascending order")
+		}
+		bounds = append(bounds, n)
+	}
+	if len(bounds) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "buckets needs at least one boundary")
+	}
+	if len(bounds) > maxAgingBuckets {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("buckets allows at most %d boundaries", maxAgingBuckets))
+	}
+	return bounds, nil
+}
+
+// bucketLabels names the buckets: current, then one range per boundary and
+// an open-ended last one.
+func bucketLabels(bounds []int) []string {
+	labels := []string{"current"}
+	from := 1
+	for _, b := range bounds {
+		labels = append(labels, fmt.Sprintf("%d-%d", from, b))
+		from = b + 1
+	}
+	return append(labels, fmt.Sprintf(">%d", bounds[len(bounds)-1]))
+}
+
+// bucketIndex places a document by its days past due. Documents not yet due
+// are current.
+func bucketIndex(bounds []int, daysOverdue int) int {
+	if daysOverdue <= 0 {
+		return 0
+	}
+	for i, b := range bounds {
+		if daysOverdue <= b {
+			return i + 1
+		}
+	}
+	return len(bounds) + 1
+}
+
+func (s *service) loadAging(ledger string, req *AgingQuery) (*aging, error) {
+	asOf := utils.DateOf(time.Now())
+	if req.AsOf != "" {
+		t, err := time.Parse("2006-01-02", req.AsOf)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "asOf must use the YYYY-MM-DD format")
+		}
+		asOf = t
+	}
+
+	buckets := req.Buckets
+	if buckets == "" {
+		buckets = config.AppConfig.AgingBuckets
+	}
+	bounds, err := parseBuckets(buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &aging{ledger: ledger, asOf: asOf, bounds: bounds, labels: bucketLabels(bounds)}
+
+	switch ledger {
+	case LedgerReceivable:
+		if req.CustomerID != "" {
+			if _, err := uuid.Parse(req.CustomerID); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid customerId")
+			}
+		}
+		items, err := s.receivableRepo.FindOpenItems(asOf, req.CustomerID)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		for _, it := range items {
+			a.items = append(a.items, agingItem{
+				DocumentID: it.InvoiceID, Number: it.Number,
+				PartyID: it.CustomerID, PartyCode: it.CustomerCode, PartyName: it.CustomerName,
+				Date: it.Date, DueDate: it.DueDate, Outstanding: it.Outstanding,
+			})
+		}
+
+	case LedgerPayable:
+		if req.VendorID != "" {
+			if _, err := uuid.Parse(req.VendorID); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid vendorId")
+			}
+		}
+		items, err := s.payableRepo.FindOpenItems(asOf, req.VendorID)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		for _, it := range items {
+			a.items = append(a.items, agingItem{
+				DocumentID: it.BillID, Number: it.Number,
+				PartyID: it.VendorID, PartyCode: it.VendorCode, PartyName: it.VendorName,
+				Date: it.Date, DueDate: it.DueDate, Outstanding: it.Outstanding,
+			})
+		}
+
+	default:
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Unknown ledger: "+ledger)
+	}
+
+	return a, nil
+}
+
+// GetAgingDetail ages every open document of the ledger as of the report
+// date. The open items come ordered by party and due date.
+func (s *service) GetAgingDetail(ledger string, req *AgingQuery) (*AgingDetailResponse, error) {
+	a, err := s.loadAging(ledger, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AgingDetailResponse{
+		Ledger:  ledger,
+		AsOf:    a.asOf,
+		Buckets: a.labels,
+		Rows:    []AgingDetailRow{},
+		Totals:  make([]float64, len(a.labels)),
+	}
+	for _, it := range a.items {
+		days := int(a.asOf.Sub(utils.DateOf(it.DueDate)).Hours() / 24)
+		idx := bucketIndex(a.bounds, days)
+
+		row := AgingDetailRow{
+			DocumentID:  it.DocumentID,
+			Number:      it.Number,
+			PartyID:     it.PartyID,
+			PartyCode:   it.PartyCode,
+			PartyName:   it.PartyName,
+			Date:        it.Date,
+			DueDate:     it.DueDate,
+			DaysOverdue: max(days, 0),
+			Bucket:      a.labels[idx],
+			Amounts:     make([]float64, len(a.labels)),
+			Outstanding: it.Outstanding,
+		}
+		row.Amounts[idx] = it.Outstanding
+		res.Rows = append(res.Rows, row)
+
+		res.Totals[idx] = utils.Round2(res.Totals[idx] + it.Outstanding)
+		res.Total = utils.Round2(res.Total + it.Outstanding)
+	}
+	return res, nil
+}
+
+// GetAgingSummary totals the aging detail per customer or vendor.
+func (s *service) GetAgingSummary(ledger string, req *AgingQuery) (*AgingSummaryResponse, error) {
+	detail, err := s.GetAgingDetail(ledger, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AgingSummaryResponse{
+		Ledger:  ledger,
+		AsOf:    detail.AsOf,
+		Buckets: detail.Buckets,
+		Rows:    []AgingSummaryRow{},
+		Totals:  detail.Totals,
+		Total:   detail.Total,
+	}
+	for _, d := range detail.Rows {
+		if n := len(res.Rows); n == 0 || res.Rows[n-1].PartyID != d.PartyID {
+			res.Rows = append(res.Rows, AgingSummaryRow{
+				PartyID:   d.PartyID,
+				PartyCode: d.PartyCode,
+				PartyName: d.PartyName,
+				Amounts:   make([]float64, len(detail.Buckets)),
+			})
+		}
+		row := &res.Rows[len(res.Rows)-1]
+		for i, amount := range d.Amounts {
+			row.Amounts[i] = utils.Round2(row.Amounts[i] + amount)
+		}
+		row.Total = utils.Round2(row.Total + d.Outstanding)
+	}
+	return res, nil
+}
+
+// ExportAging renders the aging summary, or the detail when detail is set,
+// as a CSV or XLSX table with a totals row.
+func (s *service) ExportAging(ledger string, detail bool, req *AgingQuery, format string) ([]byte, error) {
+	if format != FormatCSV && format != FormatXLSX {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "format must be json, csv or xlsx")
+	}
+
+	party := "customer"
+	if ledger == LedgerPayable {
+		party = "vendor"
+	}
+
+	var records [][]any
+	if detail {
+		res, err := s.GetAgingDetail(ledger, req)
+		if err != nil {
+			return nil, err
+		}
+
+		header := []any{"number", party + " code", party + " name", "date", "due date", "days overdue"}
+		records = append(records, appendLabels(header, res.Buckets, "total"))
+		for _, r := range res.Rows {
+			record := []any{
+				r.Number, r.PartyCode, r.PartyName,
+				r.Date.Format("2006-01-02"), r.DueDate.Format("2006-01-02"), r.DaysOverdue,
+			}
+			records = append(records, appendAmounts(record, r.Amounts, r.Outstanding))
+		}
+		records = append(records, appendAmounts([]any{"Total", nil, nil, nil, nil, nil}, res.Totals, res.Total))
+	} else {
+		res, err := s.GetAgingSummary(ledger, req)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, appendLabels([]any{party + " code", party + " name"}, res.Buckets, "total"))
+		for _, r := range res.Rows {
+			records = append(records, appendAmounts([]any{r.PartyCode, r.PartyName}, r.Amounts, r.Total))
+		}
+		records = append(records, appendAmounts([]any{"Total", nil}, res.Totals, res.Total))
+	}
+
+	return export.Table("Aging", records, format)
+}
+
+func appendLabels(record []any, labels []string, last string) []any {
+	for _, l := range labels {
+		record = append(record, l)
+	}
+	return append(record, last)
+}
+
+// appendAmounts adds the bucket amounts, leaving empty buckets blank.
+func appendAmounts(record []any, amounts []float64, total float64) []any {
+	for _, a := range amounts {
+		if a == 0 {
+			record = append(record, nil)
+			continue
+		}
+		record = append(record, a)
+	}
+	return append(record, total)
+}
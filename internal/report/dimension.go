@@ -0,0 +1,48 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseDimensions reads a dimension filter such as "CC:JKT,CC:SBY,PRJ:ALPHA"
+// into dimension code -> accepted values. Values of the same dimension are
+// alternatives; different dimensions must all match.
+func parseDimensions(s string) (map[string][]string, error) {
+	filter := map[string][]string{}
+	if s == "" {
+		return filter, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		dim, value, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || dim == "" || value == "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "dimensions must be a comma-separated list of DIMENSION:VALUE pairs")
+		}
+		filter[dim] = append(filter[dim], value)
+	}
+	return filter, nil
+}
+
+// dimensionClause returns the SQL conditions restricting the journal line
+// alias to lines tagged with the filter's values.
+func dimensionClause(alias string, filter map[string][]string) (string, []any) {
+	dims := make([]string, 0, len(filter))
+	for dim := range filter {
+		dims = append(dims, dim)
+	}
+	sort.Strings(dims)
+
+	var clause string
+	var args []any
+	for _, dim := range dims {
+		clause += ` AND EXISTS (
+			SELECT 1 FROM journal_line_dimensions ldf
+			WHERE ldf.detail_id = ` + alias + `.id
+			AND ldf.dimension_code = ?
+			AND ldf.value_code IN ?)`
+		args = append(args, dim, filter[dim])
+	}
+	return clause, args
+}
@@ -4,26 +4,44 @@ import "time"
 
 // LedgerQuery is the request DTO for General Ledger.
 // CoaCode is required. StartDate and EndDate are optional.
+// Dimensions filters lines by dimension values ("CC:JKT,PRJ:ALPHA") and
+// GroupBy subtotals the transactions by one dimension code.
 type LedgerQuery struct {
-	CoaCode   string `query:"coaCode" validate:"required"`
-	StartDate string `query:"startDate"`
-	EndDate   string `query:"endDate"`
+	CoaCode    string `query:"coaCode" validate:"required"`
+	StartDate  string `query:"startDate"`
+	EndDate    string `query:"endDate"`
+	Dimensions string `query:"dimensions"`
+	GroupBy    string `query:"groupBy"`
 }
 
-// PeriodQuery is the request DTO for periodic reports.
+// PeriodQuery is the request DTO for periodic reports. Dimensions and GroupBy
+// work as in LedgerQuery; GroupBy splits each account row by dimension value.
 type PeriodQuery struct {
-	StartDate string `query:"startDate"`
-	EndDate   string `query:"endDate"`
+	StartDate  string `query:"startDate"`
+	EndDate    string `query:"endDate"`
+	Dimensions string `query:"dimensions"`
+	GroupBy    string `query:"groupBy"`
 }
 
 // TransactionRow represents a single line in the general ledger.
 type TransactionRow struct {
-	Date        time.Time `json:"date"`
-	Reference   string    `json:"reference"`
-	Description string    `json:"description"`
-	Debit       float64   `json:"debit"`
-	Credit      float64   `json:"credit"`
-	Balance     float64   `json:"balance"` // calculated running balance
+	DetailID    string            `json:"-"                    gorm:"column:detail_id"`
+	Date        time.Time         `json:"date"`
+	Reference   string            `json:"reference"`
+	Description string            `json:"description"`
+	Debit       float64           `json:"debit"`
+	Credit      float64           `json:"credit"`
+	Balance     float64           `json:"balance"` // calculated running balance
+	Dimensions  map[string]string `json:"dimensions,omitempty" gorm:"-"`
+}
+
+// LedgerGroup subtotals the period's transactions for one value of the
+// GroupBy dimension. An empty DimensionValue collects untagged lines.
+type LedgerGroup struct {
+	DimensionValue string  `json:"dimensionValue"`
+	Debit          float64 `json:"debit"`
+	Credit         float64 `json:"credit"`
+	Net            float64 `json:"net"`
 }
 
 // LedgerResponse is the response body for General Ledger.
@@ -33,16 +51,19 @@ type LedgerResponse struct {
 	OpeningBalance float64          `json:"openingBalance"`
 	Transactions   []TransactionRow `json:"transactions"`
 	ClosingBalance float64          `json:"closingBalance"`
+	Groups         []LedgerGroup    `json:"groups,omitempty"`
 }
 
 // AccountBalanceRow represents a summarized account balance for a period.
+// DimensionValue is only set when the report is grouped by a dimension.
 type AccountBalanceRow struct {
-	CoaCode string  `json:"coaCode" gorm:"column:coa_code"`
-	CoaName string  `json:"coaName" gorm:"column:coa_name"`
-	Type    string  `json:"-"       gorm:"column:type"`
-	Debit   float64 `json:"debit,omitempty"   gorm:"column:sum_debit"`
-	Credit  float64 `json:"credit,omitempty"  gorm:"column:sum_credit"`
-	Balance float64 `json:"balance,omitempty"` // Net balance for PnL/BalanceSheet
+	CoaCode        string  `json:"coaCode" gorm:"column:coa_code"`
+	CoaName        string  `json:"coaName" gorm:"column:coa_name"`
+	DimensionValue string  `json:"dimensionValue,omitempty" gorm:"column:dimension_value"`
+	Type           string  `json:"-"       gorm:"column:type"`
+	Debit          float64 `json:"debit,omitempty"   gorm:"column:sum_debit"`
+	Credit         float64 `json:"credit,omitempty"  gorm:"column:sum_credit"`
+	Balance        float64 `json:"balance,omitempty"` // Net balance for PnL/BalanceSheet
 }
 
 // TrialBalanceResponse is the response body for Trial Balance.
@@ -74,6 +95,64 @@ type BalanceSheetResponse struct {
 	IsBalanced      bool                `json:"isBalanced"`
 }
 
+// AgingQuery is the request DTO for the AR and AP aging reports. AsOf
+// defaults to today. Buckets lists the upper day boundaries of the overdue
+// buckets ("30,60,90" gives current, 1-30, 31-60, 61-90 and >90) and
+// defaults to AGING_BUCKETS. Format is json, csv or xlsx.
+type AgingQuery struct {
+	AsOf       string `query:"asOf"`
+	Buckets    string `query:"buckets"`
+	CustomerID string `query:"customerId"`
+	VendorID   string `query:"vendorId"`
+	Format     string `query:"format"`
+}
+
+// AgingDetailRow is one open invoice or bill. Amounts holds its outstanding
+// amount in the column of its bucket, aligned with the response's Buckets.
+type AgingDetailRow struct {
+	DocumentID  string    `json:"documentId"`
+	Number      string    `json:"number"`
+	PartyID     string    `json:"partyId"`
+	PartyCode   string    `json:"partyCode"`
+	PartyName   string    `json:"partyName"`
+	Date        time.Time `json:"date"`
+	DueDate     time.Time `json:"dueDate"`
+	DaysOverdue int       `json:"daysOverdue"`
+	Bucket      string    `json:"bucket"`
+	Amounts     []float64 `json:"amounts"`
+	Outstanding float64   `json:"outstanding"`
+}
+
+// AgingSummaryRow totals a customer's or vendor's open documents per bucket.
+type AgingSummaryRow struct {
+	PartyID   string    `json:"partyId"`
+	PartyCode string    `json:"partyCode"`
+	PartyName string    `json:"partyName"`
+	Amounts   []float64 `json:"amounts"`
+	Total     float64   `json:"total"`
+}
+
+// AgingDetailResponse is the response body for the aging detail reports.
+// Ledger is receivable or payable.
+type AgingDetailResponse struct {
+	Ledger  string           `json:"ledger"`
+	AsOf    time.Time        `json:"asOf"`
+	Buckets []string         `json:"buckets"`
+	Rows    []AgingDetailRow `json:"rows"`
+	Totals  []float64        `json:"totals"`
+	Total   float64          `json:"total"`
+}
+
+// AgingSummaryResponse is the response body for the aging summary reports.
+type AgingSummaryResponse struct {
+	Ledger  string            `json:"ledger"`
+	AsOf    time.Time         `json:"asOf"`
+	Buckets []string          `json:"buckets"`
+	Rows    []AgingSummaryRow `json:"rows"`
+	Totals  []float64         `json:"totals"`
+	Total   float64           `json:"total"`
+}
+
 // Swagger Responses
 
 type SwaggerLedgerResponse struct {
@@ -99,3 +178,15 @@ type SwaggerBalanceSheetResponse struct {
 	Message string               `json:"message"`
 	Data    BalanceSheetResponse `json:"data"`
 }
+
+type SwaggerAgingDetailResponse struct {
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Data    AgingDetailResponse `json:"data"`
+}
+
+type SwaggerAgingSummaryResponse struct {
+	Code    int                  `json:"code"`
+	Message string               `json:"message"`
+	Data    AgingSummaryResponse `json:"data"`
+}
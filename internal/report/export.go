@@ -0,0 +1,9 @@
+package report
+
+import "fiber.com/session-api/pkg/export"
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = export.CSV
+	FormatXLSX = export.XLSX
+)
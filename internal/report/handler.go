@@ -1,6 +1,9 @@
 package report
 
 import (
+	"fmt"
+	"strings"
+
 	"fiber.com/session-api/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -22,6 +25,8 @@ func NewHandler(service Service) *Handler {
 // @Param        coaCode   query     string  true  "COA Code"
 // @Param        startDate query     string  false "Start Date (YYYY-MM-DD)"
 // @Param        endDate   query     string  false "End Date (YYYY-MM-DD)"
+// @Param        dimensions query    string  false "Dimension filter (e.g. CC:JKT,PRJ:ALPHA)"
+// @Param        groupBy   query     string  false "Dimension code to subtotal by (e.g. CC)"
 // @Success      200  {object}  SwaggerLedgerResponse
 // @Failure      400  {object}  model.SwaggerErrorResponse
 // @Failure      401  {object}  model.SwaggerErrorResponse
@@ -52,6 +57,8 @@ func (h *Handler) GetLedger(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        startDate query     string  false "Start Date (YYYY-MM-DD)"
 // @Param        endDate   query     string  false "End Date (YYYY-MM-DD)"
+// @Param        dimensions query    string  false "Dimension filter (e.g. CC:JKT,PRJ:ALPHA)"
+// @Param        groupBy   query     string  false "Dimension code to split each account by (e.g. CC)"
 // @Success      200  {object}  SwaggerTrialBalanceResponse
 // @Failure      400  {object}  model.SwaggerErrorResponse
 // @Failure      401  {object}  model.SwaggerErrorResponse
@@ -78,6 +85,8 @@ func (h *Handler) GetTrialBalance(c *fiber.Ctx) error {
 // @Produce      json
 // @Param        startDate query     string  false "Start Date (YYYY-MM-DD)"
 // @Param        endDate   query     string  false "End Date (YYYY-MM-DD)"
+// @Param        dimensions query    string  false "Dimension filter (e.g. CC:JKT,PRJ:ALPHA)"
+// @Param        groupBy   query     string  false "Dimension code to split each account by (e.g. CC)"
 // @Success      200  {object}  SwaggerProfitLossResponse
 // @Failure      400  {object}  model.SwaggerErrorResponse
 // @Failure      401  {object}  model.SwaggerErrorResponse
@@ -121,3 +130,127 @@ func (h *Handler) GetBalanceSheet(c *fiber.Ctx) error {
 
 	return utils.SuccessResponse(c, fiber.StatusOK, "Balance Sheet fetched successfully", res)
 }
+
+// aging serves an aging report as JSON or, with format=csv or xlsx, as a
+// file download.
+func (h *Handler) aging(c *fiber.Ctx, ledger string, detail bool) error {
+	req := new(AgingQuery)
+	if err := c.QueryParser(req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+
+	format := strings.ToLower(req.Format)
+	if format != "" && format != FormatJSON {
+		data, err := h.service.ExportAging(ledger, detail, req, format)
+		if err != nil {
+			return err
+		}
+
+		view := "summary"
+		if detail {
+			view = "detail"
+		}
+		asOf := req.AsOf
+		if asOf == "" {
+			asOf = "today"
+		}
+		contentType := "text/csv"
+		if format == FormatXLSX {
+			contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		}
+		c.Set(fiber.HeaderContentType, contentType)
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s-aging-%s-%s.%s"`, ledger, view, asOf, format))
+
+		return c.Status(fiber.StatusOK).Send(data)
+	}
+
+	if detail {
+		res, err := h.service.GetAgingDetail(ledger, req)
+		if err != nil {
+			return err
+		}
+		return utils.SuccessResponse(c, fiber.StatusOK, "Aging detail fetched successfully", res)
+	}
+
+	res, err := h.service.GetAgingSummary(ledger, req)
+	if err != nil {
+		return err
+	}
+	return utils.SuccessResponse(c, fiber.StatusOK, "Aging summary fetched successfully", res)
+}
+
+// GetReceivableAging godoc
+// @Summary      Get AR Aging Summary
+// @Description  Open customer invoices as of a date, totalled per customer into buckets by days past due. Receipts dated after asOf are not deducted. Amounts and totals are aligned with buckets.
+// @Tags         Report
+// @Produce      json
+// @Produce      octet-stream
+// @Param        asOf       query  string  false "As-of date (YYYY-MM-DD), default today"
+// @Param        buckets    query  string  false "Overdue day boundaries, default from AGING_BUCKETS (e.g. 30,60,90)"
+// @Param        customerId query  string  false "Customer ID (UUID)"
+// @Param        format     query  string  false "Response format" Enums(json, csv, xlsx) default(json)
+// @Success      200  {object}  SwaggerAgingSummaryResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /report/ar-aging [get]
+func (h *Handler) GetReceivableAging(c *fiber.Ctx) error {
+	return h.aging(c, LedgerReceivable, false)
+}
+
+// GetReceivableAgingDetail godoc
+// @Summary      Get AR Aging Detail
+// @Description  Every open customer invoice as of a date with its days past due and bucket. Receipts dated after asOf are not deducted.
+// @Tags         Report
+// @Produce      json
+// @Produce      octet-stream
+// @Param        asOf       query  string  false "As-of date (YYYY-MM-DD), default today"
+// @Param        buckets    query  string  false "Overdue day boundaries, default from AGING_BUCKETS (e.g. 30,60,90)"
+// @Param        customerId query  string  false "Customer ID (UUID)"
+// @Param        format     query  string  false "Response format" Enums(json, csv, xlsx) default(json)
+// @Success      200  {object}  SwaggerAgingDetailResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /report/ar-aging/detail [get]
+func (h *Handler) GetReceivableAgingDetail(c *fiber.Ctx) error {
+	return h.aging(c, LedgerReceivable, true)
+}
+
+// GetPayableAging godoc
+// @Summary      Get AP Aging Summary
+// @Description  Open vendor bills as of a date, totalled per vendor into buckets by days past due. Payments dated after asOf are not deducted. Amounts and totals are aligned with buckets.
+// @Tags         Report
+// @Produce      json
+// @Produce      octet-stream
+// @Param        asOf     query  string  false "As-of date (YYYY-MM-DD), default today"
+// @Param        buckets  query  string  false "Overdue day boundaries, default from AGING_BUCKETS (e.g. 30,60,90)"
+// @Param        vendorId query  string  false "Vendor ID (UUID)"
+// @Param        format   query  string  false "Response format" Enums(json, csv, xlsx) default(json)
+// @Success      200  {object}  SwaggerAgingSummaryResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /report/ap-aging [get]
+func (h *Handler) GetPayableAging(c *fiber.Ctx) error {
+	return h.aging(c, LedgerPayable, false)
+}
+
+// GetPayableAgingDetail godoc
+// @Summary      Get AP Aging Detail
+// @Description  Every open vendor bill as of a date with its days past due and bucket. Payments dated after asOf are not deducted.
+// @Tags         Report
+// @Produce      json
+// @Produce      octet-stream
+// @Param        asOf     query  string  false "As-of date (YYYY-MM-DD), default today"
+// @Param        buckets  query  string  false "Overdue day boundaries, default from AGING_BUCKETS (e.g. 30,60,90)"
+// @Param        vendorId query  string  false "Vendor ID (UUID)"
+// @Param        format   query  string  false "Response format" Enums(json, csv, xlsx) default(json)
+// @Success      200  {object}  SwaggerAgingDetailResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /report/ap-aging/detail [get]
+func (h *Handler) GetPayableAgingDetail(c *fiber.Ctx) error {
+	return h.aging(c, LedgerPayable, true)
+}
@@ -5,9 +5,9 @@ import (
 )
 
 type Repository interface {
-	GetOpeningBalance(coaCode, startDate string) (float64, float64, error)
-	GetLedgerTransactions(coaCode, startDate, endDate string) ([]TransactionRow, error)
-	GetAccountBalances(startDate, endDate string) ([]AccountBalanceRow, error)
+	GetOpeningBalance(coaCode, startDate string, dims map[string][]string) (float64, float64, error)
+	GetLedgerTransactions(coaCode, startDate, endDate string, dims map[string][]string) ([]TransactionRow, error)
+	GetAccountBalances(startDate, endDate string, dims map[string][]string, groupBy string) ([]AccountBalanceRow, error)
 }
 
 type repository struct {
@@ -18,7 +18,7 @@ func NewRepository(db *gorm.DB) Repository {
 	return &repository{db: db}
 }
 
-func (r *repository) GetOpeningBalance(coaCode, startDate string) (float64, float64, error) {
+func (r *repository) GetOpeningBalance(coaCode, startDate string, dims map[string][]string) (float64, float64, error) {
 	if startDate == "" {
 		return 0, 0, nil
 	}
@@ -35,19 +35,27 @@ func (r *repository) GetOpeningBalance(coaCode, startDate string) (float64, floa
 		WHERE jd.coa_code = ? 
 		  AND je.status = 'posted' 
 		  AND je.deleted_at IS NULL
-		  AND je.date < ?
+		  AND jd.deleted_at IS NULL
+		  AND (je.date < ? OR (je.type = 'opening' AND je.date <= ?))
 	`
-	if err := r.db.Raw(query, coaCode, startDate).Scan(&res).Error; err != nil {
+	args := []any{coaCode, startDate, startDate}
+
+	dimClause, dimArgs := dimensionClause("jd", dims)
+	query += dimClause
+	args = append(args, dimArgs...)
+
+	if err := r.db.Raw(query, args...).Scan(&res).Error; err != nil {
 		return 0, 0, err
 	}
 	return res.Debit, res.Credit, nil
 }
 
-func (r *repository) GetLedgerTransactions(coaCode, startDate, endDate string) ([]TransactionRow, error) {
+func (r *repository) GetLedgerTransactions(coaCode, startDate, endDate string, dims map[string][]string) ([]TransactionRow, error) {
 	var rows []TransactionRow
 
 	query := `
 		SELECT 
+			jd.id AS detail_id,
 			je.date, 
 			je.reference, 
 			jd.description, 
@@ -58,54 +66,114 @@ func (r *repository) GetLedgerTransactions(coaCode, startDate, endDate string) (
 		WHERE jd.coa_code = ? 
 		  AND je.status = 'posted' 
 		  AND je.deleted_at IS NULL
+		  AND jd.deleted_at IS NULL
 	`
 	var args []interface{}
 	args = append(args, coaCode)
 
+	dimClause, dimArgs := dimensionClause("jd", dims)
+	query += dimClause
+	args = append(args, dimArgs...)
+
 	if startDate != "" {
-		query += " AND je.date >= ?"
-		args = append(args, startDate)
+		// An opening entry dated on the start date is already part of the
+		// opening balance, so it must not be listed again as a movement.
+		query += " AND je.date >= ? AND NOT (je.type = 'opening' AND je.date <= ?)"
+		args = append(args, startDate, startDate)
 	}
 	if endDate != "" {
 		query += " AND je.date <= ?"
 		args = append(args, endDate)
 	}
-	query += " ORDER BY je.date ASC, je.created_at ASC"
+	query += " ORDER BY je.date ASC, (je.type = 'opening') DESC, je.created_at ASC"
 
 	if err := r.db.Raw(query, args...).Scan(&rows).Error; err != nil {
 		return nil, err
 	}
+	if len(rows) == 0 {
+		return rows, nil
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.DetailID
+	}
+	var tags []struct {
+		DetailID      string `gorm:"column:detail_id"`
+		DimensionCode string `gorm:"column:dimension_code"`
+		ValueCode     string `gorm:"column:value_code"`
+	}
+	if err := r.db.Raw(
+		`SELECT detail_id, dimension_code, value_code FROM journal_line_dimensions WHERE detail_id IN ?`,
+		ids,
+	).Scan(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	byDetail := make(map[string]map[string]string)
+	for _, t := range tags {
+		if byDetail[t.DetailID] == nil {
+			byDetail[t.DetailID] = make(map[string]string)
+		}
+		byDetail[t.DetailID][t.DimensionCode] = t.ValueCode
+	}
+	for i := range rows {
+		rows[i].Dimensions = byDetail[rows[i].DetailID]
+	}
 	return rows, nil
 }
 
-func (r *repository) GetAccountBalances(startDate, endDate string) ([]AccountBalanceRow, error) {
+// GetAccountBalances sums posted lines per account. With groupBy set, each
+// account is split into one row per value of that dimension.
+func (r *repository) GetAccountBalances(startDate, endDate string, dims map[string][]string, groupBy string) ([]AccountBalanceRow, error) {
 	var rows []AccountBalanceRow
 
-	onClause := "je.id = jd.journal_entry_id AND je.status = 'posted' AND je.deleted_at IS NULL"
+	lineWhere := "jd.deleted_at IS NULL AND je.status = 'posted' AND je.deleted_at IS NULL"
 	var args []any
 
 	if startDate != "" {
-		onClause += " AND je.date >= ?"
+		lineWhere += " AND je.date >= ?"
 		args = append(args, startDate)
 	}
 	if endDate != "" {
-		onClause += " AND je.date <= ?"
+		lineWhere += " AND je.date <= ?"
 		args = append(args, endDate)
 	}
 
+	dimClause, dimArgs := dimensionClause("jd", dims)
+	lineWhere += dimClause
+	args = append(args, dimArgs...)
+
+	valueColumn := "''"
+	groupJoin := ""
+	groupColumns := "c.code, c.name, c.type"
+	if groupBy != "" {
+		valueColumn = "COALESCE(ld.value_code, '')"
+		groupJoin = "LEFT JOIN journal_line_dimensions ld ON ld.detail_id = l.id AND ld.dimension_code = ?"
+		groupColumns += ", ld.value_code"
+		args = append(args, groupBy)
+	}
+
 	query := `
+		WITH lines AS (
+			SELECT jd.id, jd.coa_code, jd.debit, jd.credit
+			FROM journal_entry_details jd
+			JOIN journal_entries je ON je.id = jd.journal_entry_id
+			WHERE ` + lineWhere + `
+		)
 		SELECT 
 			c.code AS coa_code,
 			c.name AS coa_name,
 			c.type AS type,
-			COALESCE(SUM(jd.debit), 0) AS sum_debit,
-			COALESCE(SUM(jd.credit), 0) AS sum_credit
+			` + valueColumn + ` AS dimension_value,
+			COALESCE(SUM(l.debit), 0) AS sum_debit,
+			COALESCE(SUM(l.credit), 0) AS sum_credit
 		FROM chart_of_accounts c
-		LEFT JOIN journal_entry_details jd ON jd.coa_code = c.code AND jd.deleted_at IS NULL
-		LEFT JOIN journal_entries je ON ` + onClause + `
-		WHERE c.is_active = true
-		GROUP BY c.code, c.name, c.type
-		ORDER BY c.code ASC
+		LEFT JOIN lines l ON l.coa_code = c.code
+		` + groupJoin + `
+		WHERE c.deleted_at IS NULL
+		GROUP BY ` + groupColumns + `
+		ORDER BY c.code ASC, dimension_value ASC
 	`
 
 	if err := r.db.Raw(query, args...).Scan(&rows).Error; err != nil {
@@ -1,6 +1,7 @@
 package report
 
 import (
+	"fiber.com/session-api/internal/apikey"
 	"fiber.com/session-api/pkg/middleware"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,10 +9,14 @@ import (
 
 func RegisterRoutes(router fiber.Router, handler *Handler) {
 	reportRoutes := router.Group("/report")
-	reportRoutes.Use(middleware.AuthMiddleware())
+	reportRoutes.Use(middleware.AuthMiddleware(), middleware.RequireScope(apikey.ScopeReportRead))
 
 	reportRoutes.Get("/ledger", handler.GetLedger)
 	reportRoutes.Get("/trial-balance", handler.GetTrialBalance)
 	reportRoutes.Get("/profit-loss", handler.GetProfitLoss)
 	reportRoutes.Get("/balance-sheet", handler.GetBalanceSheet)
+	reportRoutes.Get("/ar-aging", handler.GetReceivableAging)
+	reportRoutes.Get("/ar-aging/detail", handler.GetReceivableAgingDetail)
+	reportRoutes.Get("/ap-aging", handler.GetPayableAging)
+	reportRoutes.Get("/ap-aging/detail", handler.GetPayableAgingDetail)
 }
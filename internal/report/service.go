@@ -1,8 +1,12 @@
 package report
 
 import (
+	"sort"
+
 	"fiber.com/session-api/internal/coa"
 	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/payable"
+	"fiber.com/session-api/internal/receivable"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -12,15 +16,20 @@ type Service interface {
 	GetTrialBalance(req *PeriodQuery) (*TrialBalanceResponse, error)
 	GetProfitLoss(req *PeriodQuery) (*ProfitLossResponse, error)
 	GetBalanceSheet(req *PeriodQuery) (*BalanceSheetResponse, error)
+	GetAgingDetail(ledger string, req *AgingQuery) (*AgingDetailResponse, error)
+	GetAgingSummary(ledger string, req *AgingQuery) (*AgingSummaryResponse, error)
+	ExportAging(ledger string, detail bool, req *AgingQuery, format string) ([]byte, error)
 }
 
 type service struct {
-	repo    Repository
-	coaRepo coa.Repository
+	repo           Repository
+	coaRepo        coa.Repository
+	receivableRepo receivable.Repository
+	payableRepo    payable.Repository
 }
 
-func NewService(repo Repository, coaRepo coa.Repository) Service {
-	return &service{repo: repo, coaRepo: coaRepo}
+func NewService(repo Repository, coaRepo coa.Repository, receivableRepo receivable.Repository, payableRepo payable.Repository) Service {
+	return &service{repo: repo, coaRepo: coaRepo, receivableRepo: receivableRepo, payableRepo: payableRepo}
 }
 
 func (s *service) GetLedger(req *LedgerQuery) (*LedgerResponse, error) {
@@ -32,7 +41,12 @@ func (s *service) GetLedger(req *LedgerQuery) (*LedgerResponse, error) {
 		return nil, fiber.NewError(fiber.StatusNotFound, "Account not found")
 	}
 
-	debit, credit, err := s.repo.GetOpeningBalance(req.CoaCode, req.StartDate)
+	dims, err := parseDimensions(req.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	debit, credit, err := s.repo.GetOpeningBalance(req.CoaCode, req.StartDate, dims)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -44,7 +58,7 @@ func (s *service) GetLedger(req *LedgerQuery) (*LedgerResponse, error) {
 		openingBalance = credit - debit
 	}
 
-	transactions, err := s.repo.GetLedgerTransactions(req.CoaCode, req.StartDate, req.EndDate)
+	transactions, err := s.repo.GetLedgerTransactions(req.CoaCode, req.StartDate, req.EndDate, dims)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -63,17 +77,50 @@ func (s *service) GetLedger(req *LedgerQuery) (*LedgerResponse, error) {
 		transactions[i].Balance = currentBalance
 	}
 
-	return &LedgerResponse{
+	res := &LedgerResponse{
 		CoaCode:        account.Code,
 		CoaName:        account.Name,
 		OpeningBalance: openingBalance,
 		Transactions:   transactions,
 		ClosingBalance: currentBalance,
-	}, nil
+	}
+
+	if req.GroupBy != "" {
+		res.Groups = []LedgerGroup{}
+		index := map[string]int{}
+		for _, t := range transactions {
+			value := t.Dimensions[req.GroupBy]
+			i, ok := index[value]
+			if !ok {
+				i = len(res.Groups)
+				index[value] = i
+				res.Groups = append(res.Groups, LedgerGroup{DimensionValue: value})
+			}
+			res.Groups[i].Debit += t.Debit
+			res.Groups[i].Credit += t.Credit
+		}
+		for i := range res.Groups {
+			if account.Type == domain.AccountTypeAsset || account.Type == domain.AccountTypeExpense {
+				res.Groups[i].Net = res.Groups[i].Debit - res.Groups[i].Credit
+			} else {
+				res.Groups[i].Net = res.Groups[i].Credit - res.Groups[i].Debit
+			}
+		}
+		sort.Slice(res.Groups, func(a, b int) bool {
+			return res.Groups[a].DimensionValue < res.Groups[b].DimensionValue
+		})
+	}
+
+	return res, nil
 }
 
 func (s *service) GetTrialBalance(req *PeriodQuery) (*TrialBalanceResponse, error) {
-	balances, err := s.repo.GetAccountBalances(req.StartDate, req.EndDate)
+	dims, err := parseDimensions(req.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.repo.GetAccountBalances(req.StartDate, req.EndDate, dims, req.GroupBy)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -101,7 +148,12 @@ func (s *service) GetTrialBalance(req *PeriodQuery) (*TrialBalanceResponse, erro
 }
 
 func (s *service) GetProfitLoss(req *PeriodQuery) (*ProfitLossResponse, error) {
-	balances, err := s.repo.GetAccountBalances(req.StartDate, req.EndDate)
+	dims, err := parseDimensions(req.Dimensions)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := s.repo.GetAccountBalances(req.StartDate, req.EndDate, dims, req.GroupBy)
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -116,9 +168,10 @@ func (s *service) GetProfitLoss(req *PeriodQuery) (*ProfitLossResponse, error) {
 			net := bal.Credit - bal.Debit
 			if net != 0 {
 				res.Revenues = append(res.Revenues, AccountBalanceRow{
-					CoaCode: bal.CoaCode,
-					CoaName: bal.CoaName,
-					Balance: net,
+					CoaCode:        bal.CoaCode,
+					CoaName:        bal.CoaName,
+					DimensionValue: bal.DimensionValue,
+					Balance:        net,
 				})
 				res.TotalRevenue += net
 			}
@@ -126,9 +179,10 @@ func (s *service) GetProfitLoss(req *PeriodQuery) (*ProfitLossResponse, error) {
 			net := bal.Debit - bal.Credit
 			if net != 0 {
 				res.Expenses = append(res.Expenses, AccountBalanceRow{
-					CoaCode: bal.CoaCode,
-					CoaName: bal.CoaName,
-					Balance: net,
+					CoaCode:        bal.CoaCode,
+					CoaName:        bal.CoaName,
+					DimensionValue: bal.DimensionValue,
+					Balance:        net,
 				})
 				res.TotalExpense += net
 			}
@@ -140,7 +194,9 @@ func (s *service) GetProfitLoss(req *PeriodQuery) (*ProfitLossResponse, error) {
 }
 
 func (s *service) GetBalanceSheet(req *PeriodQuery) (*BalanceSheetResponse, error) {
-	balances, err := s.repo.GetAccountBalances("", req.EndDate)
+	// The balance sheet always covers the whole ledger; dimension filters
+	// would leave it unbalanced.
+	balances, err := s.repo.GetAccountBalances("", req.EndDate, nil, "")
 	if err != nil {
 		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -0,0 +1,89 @@
+// Package subledger holds what the receivable and payable ledgers share: the
+// control account each one posts to, its reconciliation and the request
+// helpers both services use.
+package subledger
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/coa"
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Ledger is a subledger whose open items are summarised on one control
+// account of the general ledger.
+type Ledger struct {
+	// Name labels the ledger in messages, e.g. "AR".
+	Name string
+	// ControlType is the account type the control account must have.
+	ControlType domain.AccountType
+	// JournalTypes are the journals the subledger books. No other journal
+	// may post to the control account.
+	JournalTypes []domain.JournalType
+
+	controlCode func() string
+	// sign turns the control account's debit-minus-credit net into the
+	// ledger's balance: receivables are debit-normal, payables credit-normal.
+	sign float64
+}
+
+var (
+	Receivable = &Ledger{
+		Name:         "AR",
+		ControlType:  domain.AccountTypeAsset,
+		JournalTypes: []domain.JournalType{domain.JournalTypeSalesInvoice, domain.JournalTypeReceipt},
+		controlCode:  func() string { return config.AppConfig.ARControlCode },
+		sign:         1,
+	}
+	Payable = &Ledger{
+		Name:         "AP",
+		ControlType:  domain.AccountTypeLiability,
+		JournalTypes: []domain.JournalType{domain.JournalTypePurchaseBill, domain.JournalTypePayment},
+		controlCode:  func() string { return config.AppConfig.APControlCode },
+		sign:         -1,
+	}
+
+	// Ledgers lists every subledger.
+	Ledgers = []*Ledger{Receivable, Payable}
+)
+
+// ControlCode is the configured control account code.
+func (l *Ledger) ControlCode() string {
+	return l.controlCode()
+}
+
+// CheckControl returns the control account code once it is confirmed to be
+// an active postable account of the ledger's control type. A misconfigured
+// control account is a conflict, not a bad request.
+func (l *Ledger) CheckControl(tx *gorm.DB) (string, error) {
+	code := l.ControlCode()
+	if err := CheckAccount(tx, code, l.Name+" control account", l.ControlType); err != nil {
+		return "", fiber.NewError(fiber.StatusConflict, err.(*fiber.Error).Message)
+	}
+	return code, nil
+}
+
+// CheckAccount makes sure code is an active postable account of one of the
+// given types.
+func CheckAccount(tx *gorm.DB, code, label string, accountTypes ...domain.AccountType) error {
+	account, err := coa.NewRepository(tx).FindByCode(code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if account == nil || !slices.Contains(accountTypes, account.Type) || account.Kind != domain.AccountKindPostable || !account.IsActive {
+		names := make([]string, len(accountTypes))
+		for i, t := range accountTypes {
+			names[i] = string(t)
+		}
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"%s %s must be an active postable %s account", label, code, strings.Join(names, " or "),
+		))
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package subledger
+
+import (
+	"time"
+
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// OpenItem is an invoice or bill with an amount still owed.
+type OpenItem interface {
+	OutstandingAmount() float64
+}
+
+// Source is the repository side of a ledger's open items and its
+// reconciliation. Repositories get ControlBalance by embedding Control.
+type Source[T OpenItem] interface {
+	FindOpenItems(asOf time.Time, partyID string) ([]T, error)
+	ControlBalance(asOf time.Time) (balance, other float64, err error)
+}
+
+// Control reads the control account of Ledger through DB.
+type Control struct {
+	Ledger *Ledger
+	DB     *gorm.DB
+}
+
+// ControlBalance returns the ledger's balance on the control account from
+// posted journals up to asOf, and the part of it that came from journals
+// the subledger did not book.
+func (c Control) ControlBalance(asOf time.Time) (balance, other float64, err error) {
+	var row struct {
+		Balance float64
+		Other   float64
+	}
+	types := make([]string, len(c.Ledger.JournalTypes))
+	for i, t := range c.Ledger.JournalTypes {
+		types[i] = string(t)
+	}
+
+	err = c.DB.Raw(
+		`SELECT COALESCE(SUM(d.debit - d.credit), 0) AS balance,
+			COALESCE(SUM(d.debit - d.credit) FILTER (WHERE j.type NOT IN ?), 0) AS other
+		 FROM journal_entry_details d
+		 JOIN journal_entries j ON j.id = d.journal_entry_id
+		 WHERE d.coa_code = ?
+		 AND j.status = 'posted'
+		 AND j.deleted_at IS NULL
+		 AND d.deleted_at IS NULL
+		 AND j.date <= ?`,
+		types, c.Ledger.ControlCode(), asOf,
+	).Scan(&row).Error
+	return row.Balance * c.Ledger.sign, row.Other * c.Ledger.sign, err
+}
+
+// FindOpenItems lists the open items as of asOfParam (default today), for
+// every party or only the one whose id was given in partyField.
+func FindOpenItems[T OpenItem](src Source[T], asOfParam, partyField, partyID string) ([]T, error) {
+	asOf, err := ParseAsOf(asOfParam)
+	if err != nil {
+		return nil, err
+	}
+	if partyID != "" {
+		if _, err := ParseID(partyField, partyID); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := src.FindOpenItems(asOf, partyID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if items == nil {
+		items = []T{}
+	}
+	return items, nil
+}
+
+// Reconciliation compares a control account with the ledger's open items as
+// of a date. OtherEntries is the net of posted journals on the control
+// account that the subledger did not book, the usual cause of a difference.
+// Other journals are rejected on control accounts, so these are opening
+// balances or entries booked before that check existed.
+type Reconciliation struct {
+	AsOf           time.Time `json:"asOf"`
+	ControlCode    string    `json:"controlCode"`
+	ControlBalance float64   `json:"controlBalance"`
+	OpenItemsTotal float64   `json:"openItemsTotal"`
+	Difference     float64   `json:"difference"`
+	OtherEntries   float64   `json:"otherEntries"`
+	Reconciled     bool      `json:"reconciled"`
+}
+
+type SwaggerReconciliationResponse struct {
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Data    Reconciliation `json:"data"`
+}
+
+// Reconcile compares the control account balance of l with the total of
+// its open items on the same date, asOfParam or today.
+func Reconcile[T OpenItem](l *Ledger, src Source[T], asOfParam string) (*Reconciliation, error) {
+	asOf, err := ParseAsOf(asOfParam)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, other, err := src.ControlBalance(asOf)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	items, err := src.FindOpenItems(asOf, "")
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	var open float64
+	for _, it := range items {
+		open += it.OutstandingAmount()
+	}
+
+	res := &Reconciliation{
+		AsOf:           asOf,
+		ControlCode:    l.ControlCode(),
+		ControlBalance: utils.Round2(balance),
+		OpenItemsTotal: utils.Round2(open),
+		OtherEntries:   utils.Round2(other),
+	}
+	res.Difference = utils.Round2(res.ControlBalance - res.OpenItemsTotal)
+	res.Reconciled = res.Difference == 0
+	return res, nil
+}
@@ -0,0 +1,75 @@
+package subledger
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/pkg/model"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ParseDate reads a YYYY-MM-DD date from the named request field.
+func ParseDate(field, value string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fiber.NewError(fiber.StatusBadRequest, field+" must use the YYYY-MM-DD format")
+	}
+	return t, nil
+}
+
+// ParseAsOf reads an optional as-of date, defaulting to today.
+func ParseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return utils.DateOf(time.Now()), nil
+	}
+	return ParseDate("asOf", value)
+}
+
+// ParseID reads a UUID from the named request field.
+func ParseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "Invalid "+field)
+	}
+	return id, nil
+}
+
+// Paginate builds the pagination meta of a list response.
+func Paginate(page, limit int, total int64) *model.MetaPagination {
+	return &model.MetaPagination{
+		Page:      page,
+		Limit:     limit,
+		TotalPage: int(math.Ceil(float64(total) / float64(limit))),
+		TotalData: int(total),
+	}
+}
+
+// PathID reads the :id path parameter of a name record.
+func PathID(c *fiber.Ctx, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "Invalid "+name+" ID")
+	}
+	return id, nil
+}
+
+// PageLimit defaults the page to 1 and the limit to 10, capped at 100.
+func PageLimit(page, limit *int) {
+	if *page < 1 {
+		*page = 1
+	}
+	if *limit < 1 || *limit > 100 {
+		*limit = 10
+	}
+}
+
+// DocumentNumber follows the journal reference pattern, e.g.
+// SI-20260131-1A2B.
+func DocumentNumber(prefix string, date time.Time) string {
+	return fmt.Sprintf("%s-%s-%s", prefix, date.Format("20060102"), strings.ToUpper(uuid.New().String()[0:4]))
+}
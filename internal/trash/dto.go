@@ -0,0 +1,48 @@
+package trash
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/audit"
+)
+
+// Trash entities share their names with the audit trail's entity types.
+const (
+	EntityCOA     = audit.EntityCOA
+	EntityJournal = audit.EntityJournal
+	EntityUser    = audit.EntityUser
+)
+
+// TrashItem is one soft-deleted record. ID is the COA code or the UUID of
+// the journal entry or user.
+type TrashItem struct {
+	ID          string    `json:"id"          gorm:"column:id"`
+	Label       string    `json:"label"       gorm:"column:label"`
+	DeletedAt   time.Time `json:"deletedAt"   gorm:"column:deleted_at"`
+	PurgeableAt time.Time `json:"purgeableAt" gorm:"-"`
+}
+
+// PurgeResult reports a bulk purge of everything past the retention period.
+// Items still referenced by live data are skipped and listed.
+type PurgeResult struct {
+	Purged  map[string]int `json:"purged"`
+	Skipped []PurgeSkip    `json:"skipped,omitempty"`
+}
+
+type PurgeSkip struct {
+	Entity string `json:"entity"`
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+type SwaggerTrashListResponse struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    []TrashItem `json:"data"`
+}
+
+type SwaggerPurgeResponse struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    PurgeResult `json:"data"`
+}
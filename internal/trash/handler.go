@@ -0,0 +1,133 @@
+package trash
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/model"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// GetAll godoc
+// @Summary      List trashed records
+// @Description  Returns soft-deleted records of one entity (newest first) with the date each becomes purgeable (admin only)
+// @Tags         Trash
+// @Produce      json
+// @Param        entity  path   string  true  "Entity" Enums(coa, journal, user)
+// @Param        page    query  int     false "Page number"    minimum(1)
+// @Param        limit   query  int     false "Items per page" minimum(1) maximum(100)
+// @Param        search  query  string  false "Search by label"
+// @Success      200  {object}  SwaggerTrashListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /trash/{entity} [get]
+func (h *Handler) GetAll(c *fiber.Ctx) error {
+	var req model.PaginationRequest
+	if err := c.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.Limit < 1 || req.Limit > 100 {
+		req.Limit = 10
+	}
+
+	items, meta, err := h.service.GetAll(c.Params("entity"), &req)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get trashed records", items, meta)
+}
+
+// Restore godoc
+// @Summary      Restore a trashed record
+// @Description  Undeletes a record after checking that it no longer conflicts with live data (admin only)
+// @Tags         Trash
+// @Produce      json
+// @Param        entity  path  string  true  "Entity" Enums(coa, journal, user)
+// @Param        id      path  string  true  "COA code or UUID"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /trash/{entity}/{id}/restore [post]
+func (h *Handler) Restore(c *fiber.Ctx) error {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Restore(c.Params("entity"), c.Params("id"), audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Record restored successfully", nil)
+}
+
+// Purge godoc
+// @Summary      Purge a trashed record
+// @Description  Permanently deletes a record once its retention period has passed and nothing references it (admin only)
+// @Tags         Trash
+// @Produce      json
+// @Param        entity  path  string  true  "Entity" Enums(coa, journal, user)
+// @Param        id      path  string  true  "COA code or UUID"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /trash/{entity}/{id} [delete]
+func (h *Handler) Purge(c *fiber.Ctx) error {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Purge(c.Params("entity"), c.Params("id"), audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Record purged permanently", nil)
+}
+
+// PurgeExpired godoc
+// @Summary      Purge all expired trash
+// @Description  Permanently deletes every trashed record past the retention period; records still referenced are skipped (admin only)
+// @Tags         Trash
+// @Produce      json
+// @Success      200  {object}  SwaggerPurgeResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /trash/purge [post]
+func (h *Handler) PurgeExpired(c *fiber.Ctx) error {
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.service.PurgeExpired(audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Expired trash purged", result)
+}
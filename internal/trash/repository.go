@@ -0,0 +1,237 @@
+package trash
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/pkg/model"
+
+	"gorm.io/gorm"
+)
+
+// entitySpec maps a trash entity to its soft-deleted table.
+type entitySpec struct {
+	table string
+	id    string
+	label string
+}
+
+var entities = map[string]entitySpec{
+	EntityCOA:     {table: "chart_of_accounts", id: "code", label: "code || ' ' || name"},
+	EntityJournal: {table: "journal_entries", id: "id", label: "reference"},
+	EntityUser:    {table: "users", id: "id", label: "user_name || ' <' || email || '>'"},
+}
+
+type Repository interface {
+	FindDeleted(entity string, req *model.PaginationRequest) ([]TrashItem, int64, error)
+	FindExpired(entity string, cutoff time.Time) ([]TrashItem, error)
+	FindDeletedByID(entity, id string) (*TrashItem, error)
+	FindDeletedCOA(code string) (*domain.ChartOfAccount, error)
+	FindMissingJournalCodes(journalID string) ([]string, error)
+	PurgeBlocker(entity, id string) (string, error)
+	Restore(entity, id string) error
+	Purge(entity, id string) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) FindDeleted(entity string, req *model.PaginationRequest) ([]TrashItem, int64, error) {
+	spec := entities[entity]
+	var items []TrashItem
+	var total int64
+	offset := (req.Page - 1) * req.Limit
+	search := "%" + req.Search + "%"
+
+	where := ` WHERE deleted_at IS NOT NULL AND ` + spec.label + ` ILIKE ?`
+
+	if err := r.db.Raw(`SELECT COUNT(*) FROM `+spec.table+where, search).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	dataQuery := `SELECT ` + spec.id + `::text AS id, ` + spec.label + ` AS label, deleted_at
+		FROM ` + spec.table + where + `
+		ORDER BY deleted_at DESC
+		LIMIT ? OFFSET ?`
+
+	if err := r.db.Raw(dataQuery, search, req.Limit, offset).Scan(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+func (r *repository) FindExpired(entity string, cutoff time.Time) ([]TrashItem, error) {
+	spec := entities[entity]
+	var items []TrashItem
+	err := r.db.Raw(
+		`SELECT `+spec.id+`::text AS id, `+spec.label+` AS label, deleted_at
+		 FROM `+spec.table+`
+		 WHERE deleted_at IS NOT NULL AND deleted_at < ?
+		 ORDER BY deleted_at ASC`,
+		cutoff,
+	).Scan(&items).Error
+	return items, err
+}
+
+func (r *repository) FindDeletedByID(entity, id string) (*TrashItem, error) {
+	spec := entities[entity]
+	var item TrashItem
+	result := r.db.Raw(
+		`SELECT `+spec.id+`::text AS id, `+spec.label+` AS label, deleted_at
+		 FROM `+spec.table+`
+		 WHERE `+spec.id+` = ? AND deleted_at IS NOT NULL
+		 LIMIT 1`,
+		id,
+	).Scan(&item)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &item, nil
+}
+
+func (r *repository) FindDeletedCOA(code string) (*domain.ChartOfAccount, error) {
+	var coa domain.ChartOfAccount
+	result := r.db.Raw(
+		`SELECT code, name, type, parent_code, kind, is_active, created_at, updated_at
+		 FROM chart_of_accounts WHERE code = ? AND deleted_at IS NOT NULL LIMIT 1`,
+		code,
+	).Scan(&coa)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &coa, nil
+}
+
+// FindMissingJournalCodes returns the account codes used by a journal's lines
+// that no longer exist as live accounts.
+func (r *repository) FindMissingJournalCodes(journalID string) ([]string, error) {
+	var codes []string
+	err := r.db.Raw(
+		`SELECT DISTINCT jd.coa_code
+		 FROM journal_entry_details jd
+		 LEFT JOIN chart_of_accounts c ON c.code = jd.coa_code AND c.deleted_at IS NULL
+		 WHERE jd.journal_entry_id = ?
+		 AND jd.deleted_at IS NULL
+		 AND c.code IS NULL`,
+		journalID,
+	).Scan(&codes).Error
+	return codes, err
+}
+
+type purgeCheck struct {
+	reason string
+	query  string
+	args   []any
+}
+
+// PurgeBlocker explains why a trashed row cannot be removed for good, or
+// returns "" when nothing references it any more.
+func (r *repository) PurgeBlocker(entity, id string) (string, error) {
+	var checks []purgeCheck
+	switch entity {
+	case EntityCOA:
+		checks = []purgeCheck{
+			{"journal lines still reference this account",
+				`SELECT EXISTS (SELECT 1 FROM journal_entry_details WHERE coa_code = ? OR chained_coa_code = ?)`, []any{id, id}},
+			{"other accounts still name this account as their parent",
+				`SELECT EXISTS (SELECT 1 FROM chart_of_accounts WHERE parent_code = ?)`, []any{id}},
+			{"allocation rules still use this account",
+				`SELECT EXISTS (
+					SELECT 1 FROM allocation_rules WHERE source_coa_code = ? OR basis_coa_code = ?
+					UNION ALL
+					SELECT 1 FROM allocation_targets WHERE coa_code = ? OR basis_coa_code = ?
+				)`, []any{id, id, id, id}},
+			{"journal templates still book to this account",
+				`SELECT EXISTS (
+					SELECT 1 FROM journal_templates
+					WHERE lines @> jsonb_build_array(jsonb_build_object('coaCode', CAST(? AS text)))
+				)`, []any{id}},
+			{"subledger documents still book to this account",
+				`SELECT EXISTS (
+					SELECT 1 FROM sales_invoice_lines WHERE coa_code = ?
+					UNION ALL
+					SELECT 1 FROM receipts WHERE bank_coa_code = ?
+					UNION ALL
+					SELECT 1 FROM purchase_bill_lines WHERE coa_code = ?
+					UNION ALL
+					SELECT 1 FROM payments WHERE bank_coa_code = ?
+				)`, []any{id, id, id, id}},
+		}
+	case EntityJournal:
+		checks = []purgeCheck{
+			{"the entry is part of the journal hash chain",
+				`SELECT EXISTS (SELECT 1 FROM journal_entries WHERE id = ? AND chain_seq IS NOT NULL)`, []any{id}},
+		}
+	case EntityUser:
+		checks = []purgeCheck{
+			{"journal entries were created by this user",
+				`SELECT EXISTS (SELECT 1 FROM journal_entries WHERE created_by = ?)`, []any{id}},
+			{"API keys were created by this user",
+				`SELECT EXISTS (SELECT 1 FROM api_keys WHERE created_by = ?)`, []any{id}},
+		}
+	}
+
+	for _, c := range checks {
+		var blocked bool
+		if err := r.db.Raw(c.query, c.args...).Scan(&blocked).Error; err != nil {
+			return "", err
+		}
+		if blocked {
+			return c.reason, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *repository) Restore(entity, id string) error {
+	spec := entities[entity]
+	return r.db.Exec(
+		`UPDATE `+spec.table+` SET deleted_at = NULL, updated_at = NOW()
+		 WHERE `+spec.id+` = ? AND deleted_at IS NOT NULL`,
+		id,
+	).Error
+}
+
+// Purge permanently deletes a trashed row together with the rows it owns.
+func (r *repository) Purge(entity, id string) error {
+	switch entity {
+	case EntityJournal:
+		if err := r.db.Exec(
+			`DELETE FROM journal_line_dimensions
+			 WHERE detail_id IN (SELECT id FROM journal_entry_details WHERE journal_entry_id = ?)`,
+			id,
+		).Error; err != nil {
+			return err
+		}
+		if err := r.db.Exec(`DELETE FROM journal_entry_details WHERE journal_entry_id = ?`, id).Error; err != nil {
+			return err
+		}
+	case EntityCOA:
+		if err := r.db.Exec(`DELETE FROM account_dimensions WHERE coa_code = ?`, id).Error; err != nil {
+			return err
+		}
+	case EntityUser:
+		if err := r.db.Exec(`DELETE FROM recovery_codes WHERE user_id = ?`, id).Error; err != nil {
+			return err
+		}
+	}
+
+	spec := entities[entity]
+	return r.db.Exec(
+		`DELETE FROM `+spec.table+` WHERE `+spec.id+` = ? AND deleted_at IS NOT NULL`,
+		id,
+	).Error
+}
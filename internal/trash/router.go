@@ -0,0 +1,18 @@
+package trash
+
+import (
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	trashRoutes := router.Group("/trash")
+	trashRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole("admin"), middleware.Idempotency(db))
+
+	trashRoutes.Post("/purge", middleware.DBTransaction(db), handler.PurgeExpired)
+	trashRoutes.Get("/:entity", handler.GetAll)
+	trashRoutes.Post("/:entity/:id/restore", middleware.DBTransaction(db), handler.Restore)
+	trashRoutes.Delete("/:entity/:id", middleware.DBTransaction(db), handler.Purge)
+}
@@ -0,0 +1,232 @@
+package trash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/coa"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/event"
+	"fiber.com/session-api/pkg/model"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// purgeOrder purges journals before accounts and users, so a batch can clear
+// an account or user whose only remaining references were trashed journals.
+var purgeOrder = []string{EntityJournal, EntityCOA, EntityUser}
+
+type Service interface {
+	GetAll(entity string, req *model.PaginationRequest) ([]TrashItem, *model.MetaPagination, error)
+	Restore(entity, id string, actor audit.Actor, tx *gorm.DB) error
+	Purge(entity, id string, actor audit.Actor, tx *gorm.DB) error
+	PurgeExpired(actor audit.Actor, tx *gorm.DB) (*PurgeResult, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func retention() time.Duration {
+	return time.Duration(config.AppConfig.TrashRetentionDays) * 24 * time.Hour
+}
+
+// checkEntity validates the entity name and, for UUID-keyed entities, the ID.
+func checkEntity(entity, id string) error {
+	if _, ok := entities[entity]; !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "entity must be one of coa, journal or user")
+	}
+	if id != "" && entity != EntityCOA {
+		if _, err := uuid.Parse(id); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid ID")
+		}
+	}
+	return nil
+}
+
+func (s *service) GetAll(entity string, req *model.PaginationRequest) ([]TrashItem, *model.MetaPagination, error) {
+	if err := checkEntity(entity, ""); err != nil {
+		return nil, nil, err
+	}
+
+	items, total, err := s.repo.FindDeleted(entity, req)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	for i := range items {
+		items[i].PurgeableAt = items[i].DeletedAt.Add(retention())
+	}
+
+	meta := &model.MetaPagination{
+		Page:      req.Page,
+		Limit:     req.Limit,
+		TotalPage: int(math.Ceil(float64(total) / float64(req.Limit))),
+		TotalData: int(total),
+	}
+
+	return items, meta, nil
+}
+
+func (s *service) Restore(entity, id string, actor audit.Actor, tx *gorm.DB) error {
+	if err := checkEntity(entity, id); err != nil {
+		return err
+	}
+	txRepo := NewRepository(tx)
+
+	item, err := txRepo.FindDeletedByID(entity, id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if item == nil {
+		return fiber.NewError(fiber.StatusNotFound, "Deleted record not found")
+	}
+
+	switch entity {
+	case EntityCOA:
+		err = checkCOARestore(txRepo, coa.NewRepository(tx), id)
+	case EntityJournal:
+		err = checkJournalRestore(txRepo, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := txRepo.Restore(entity, id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := audit.Record(tx, actor, entity, id, audit.ActionRestore, nil, item); err != nil {
+		return err
+	}
+	if entity == EntityCOA {
+		return event.PublishCOAChange(tx, id, audit.ActionRestore, nil, item)
+	}
+	return nil
+}
+
+// checkCOARestore refuses to bring back an account whose parent is gone or
+// no longer fits the hierarchy rules.
+func checkCOARestore(txRepo Repository, coaRepo coa.Repository, code string) error {
+	account, err := txRepo.FindDeletedCOA(code)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if account.ParentCode == nil {
+		return nil
+	}
+
+	ancestors, err := coaRepo.FindAncestors(*account.ParentCode)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if len(ancestors) == 0 {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Parent COA %s is deleted or missing; restore it first", *account.ParentCode,
+		))
+	}
+	if parent := ancestors[0]; parent.Kind != domain.AccountKindHeader {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Parent COA %s is now a postable account and cannot have children", parent.Code,
+		))
+	}
+	if root := ancestors[len(ancestors)-1]; root.Type != account.Type {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"COA type %s no longer matches type %s of root account %s", account.Type, root.Type, root.Code,
+		))
+	}
+	return nil
+}
+
+func checkJournalRestore(txRepo Repository, id string) error {
+	missing, err := txRepo.FindMissingJournalCodes(id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if len(missing) > 0 {
+		return fiber.NewError(fiber.StatusConflict, fmt.Sprintf(
+			"Journal lines reference deleted accounts (%s); restore them first", strings.Join(missing, ", "),
+		))
+	}
+	return nil
+}
+
+func (s *service) Purge(entity, id string, actor audit.Actor, tx *gorm.DB) error {
+	if err := checkEntity(entity, id); err != nil {
+		return err
+	}
+	txRepo := NewRepository(tx)
+
+	item, err := txRepo.FindDeletedByID(entity, id)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if item == nil {
+		return fiber.NewError(fiber.StatusNotFound, "Deleted record not found")
+	}
+
+	if purgeableAt := item.DeletedAt.Add(retention()); time.Now().Before(purgeableAt) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf(
+			"Record is still within the %d-day retention period and can be purged after %s",
+			config.AppConfig.TrashRetentionDays, purgeableAt.Format(time.RFC3339),
+		))
+	}
+
+	return purgeItem(tx, txRepo, actor, entity, item)
+}
+
+func purgeItem(tx *gorm.DB, txRepo Repository, actor audit.Actor, entity string, item *TrashItem) error {
+	reason, err := txRepo.PurgeBlocker(entity, item.ID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if reason != "" {
+		return fiber.NewError(fiber.StatusConflict, "Record cannot be purged: "+reason)
+	}
+
+	if err := txRepo.Purge(entity, item.ID); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return audit.Record(tx, actor, entity, item.ID, audit.ActionPurge, item, nil)
+}
+
+// PurgeExpired permanently deletes every trashed record past the retention
+// period, skipping those that are still referenced.
+func (s *service) PurgeExpired(actor audit.Actor, tx *gorm.DB) (*PurgeResult, error) {
+	txRepo := NewRepository(tx)
+	cutoff := time.Now().Add(-retention())
+	result := &PurgeResult{Purged: map[string]int{}}
+
+	for _, entity := range purgeOrder {
+		items, err := txRepo.FindExpired(entity, cutoff)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		for i := range items {
+			err := purgeItem(tx, txRepo, actor, entity, &items[i])
+			var e *fiber.Error
+			if errors.As(err, &e) && e.Code == fiber.StatusConflict {
+				result.Skipped = append(result.Skipped, PurgeSkip{Entity: entity, ID: items[i].ID, Reason: e.Message})
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			result.Purged[entity]++
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// batchSize caps how many events are fanned out, and how many
+	// deliveries are sent, per pass.
+	batchSize = 100
+
+	// leaseDuration is how long a delivery is held while it is being sent.
+	// A dispatcher that dies mid-send leaves it to be retried after that.
+	leaseDuration = 2 * time.Minute
+)
+
+// Sign returns the X-Webhook-Signature value for body sent at t:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>" keyed by secret>".
+// Receivers should recompute it and reject stale timestamps.
+func Sign(secret string, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher moves outbox events to webhook endpoints: it queues one
+// delivery per subscribed endpoint, then sends due deliveries, retrying
+// failures with backoff until they run out of attempts and go dead.
+type Dispatcher struct {
+	repo   Repository
+	client *http.Client
+}
+
+func NewDispatcher(db *gorm.DB) *Dispatcher {
+	return &Dispatcher{
+		repo:   NewRepository(db),
+		client: &http.Client{Timeout: time.Duration(max(config.AppConfig.WebhookTimeoutSeconds, 1)) * time.Second},
+	}
+}
+
+// Start runs the dispatcher, polling every poll interval while there is
+// nothing to send. The returned stop function waits for it to finish.
+func (d *Dispatcher) Start(poll time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for {
+			busy := d.pass(ctx)
+			if busy {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(poll):
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// pass fans out new events and sends due deliveries once. It reports
+// whether a full batch was handled, i.e. more work is likely waiting.
+func (d *Dispatcher) pass(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	events, err := d.repo.FanOut(batchSize)
+	if err != nil {
+		log.Printf("webhook: fan out: %v", err)
+	}
+
+	tasks, err := d.repo.LeaseDeliveries(batchSize, time.Now().Add(leaseDuration))
+	if err != nil {
+		log.Printf("webhook: lease: %v", err)
+	}
+	for _, t := range tasks {
+		if ctx.Err() != nil {
+			// The lease lapses and another pass picks the rest up.
+			return false
+		}
+		d.deliver(ctx, t)
+	}
+
+	return events == batchSize || len(tasks) == batchSize
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, t deliveryTask) {
+	status, err := d.send(ctx, t)
+	if err == nil {
+		if err := d.repo.MarkDelivered(t.ID, status); err != nil {
+			log.Printf("webhook delivery %s: update status: %v", t.ID, err)
+		}
+		return
+	}
+	if ctx.Err() != nil {
+		// Shutting down: leave the delivery leased so it is retried later.
+		return
+	}
+
+	var next *time.Time
+	if t.Attempts < max(config.AppConfig.WebhookMaxAttempts, 1) {
+		at := time.Now().Add(utils.Backoff(t.Attempts))
+		next = &at
+	} else {
+		log.Printf("webhook delivery %s (%s) is dead after %d attempts: %v", t.ID, t.EventType, t.Attempts, err)
+	}
+	if err := d.repo.MarkFailed(t.ID, status, err.Error(), next); err != nil {
+		log.Printf("webhook delivery %s: update status: %v", t.ID, err)
+	}
+}
+
+// send POSTs the event to the endpoint. Any 2xx response counts as
+// delivered.
+func (d *Dispatcher) send(ctx context.Context, t deliveryTask) (int, error) {
+	body, err := json.Marshal(Envelope{
+		ID:         t.EventID,
+		Type:       t.EventType,
+		EntityType: t.EntityType,
+		EntityID:   t.EntityID,
+		CreatedAt:  t.CreatedAt,
+		Data:       t.Payload,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "session-api-webhooks/1")
+	req.Header.Set("X-Webhook-Event", t.EventType)
+	req.Header.Set("X-Webhook-Delivery", t.ID)
+	req.Header.Set("X-Webhook-Signature", Sign(t.Secret, time.Now(), body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
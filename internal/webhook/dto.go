@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EndpointRequest struct {
+	URL         string   `json:"url"         validate:"required,url"  example:"https://erp.example.com/hooks/ledger"`
+	Description string   `json:"description" validate:"max=255"       example:"ERP sync"`
+	EventTypes  []string `json:"eventTypes"  validate:"omitempty"     example:"journal.posted,coa.updated"`
+	IsActive    *bool    `json:"isActive"    validate:"omitempty"     example:"true"`
+}
+
+type EndpointResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	EventTypes  []string  `json:"eventTypes"`
+	IsActive    bool      `json:"isActive"`
+	CreatedBy   string    `json:"createdBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// CreatedEndpointResponse carries the signing secret. It is only returned
+// once.
+type CreatedEndpointResponse struct {
+	EndpointResponse
+	Secret string `json:"secret"`
+}
+
+type DeliveryQuery struct {
+	Page       int    `query:"page"`
+	Limit      int    `query:"limit"`
+	Status     string `query:"status"`
+	EndpointID string `query:"endpointId"`
+}
+
+type DeliveryResponse struct {
+	ID             string     `json:"id"`
+	EndpointID     string     `json:"endpointId"`
+	EndpointURL    string     `json:"endpointUrl"`
+	EventID        string     `json:"eventId"`
+	EventType      string     `json:"eventType"`
+	EntityType     string     `json:"entityType"`
+	EntityID       string     `json:"entityId"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  time.Time  `json:"nextAttemptAt"`
+	LastStatusCode int        `json:"lastStatusCode"`
+	LastError      string     `json:"lastError"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// Envelope is the JSON body POSTed to an endpoint.
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	EntityType string          `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// deliveryTask is a leased delivery with what is needed to send it.
+type deliveryTask struct {
+	ID         string          `gorm:"column:id"`
+	Attempts   int             `gorm:"column:attempts"`
+	URL        string          `gorm:"column:url"`
+	Secret     string          `gorm:"column:secret"`
+	EventID    string          `gorm:"column:event_id"`
+	EventType  string          `gorm:"column:event_type"`
+	EntityType string          `gorm:"column:entity_type"`
+	EntityID   string          `gorm:"column:entity_id"`
+	Payload    json.RawMessage `gorm:"column:payload"`
+	CreatedAt  time.Time       `gorm:"column:created_at"`
+}
+
+// Swagger Responses
+
+type SwaggerEndpointResponse struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Data    EndpointResponse `json:"data"`
+}
+
+type SwaggerCreatedEndpointResponse struct {
+	Code    int                     `json:"code"`
+	Message string                  `json:"message"`
+	Data    CreatedEndpointResponse `json:"data"`
+}
+
+type SwaggerEndpointListResponse struct {
+	Code    int                `json:"code"`
+	Message string             `json:"message"`
+	Data    []EndpointResponse `json:"data"`
+}
+
+type SwaggerDeliveryResponse struct {
+	Code    int              `json:"code"`
+	Message string           `json:"message"`
+	Data    DeliveryResponse `json:"data"`
+}
+
+type SwaggerDeliveryListResponse struct {
+	Code    int                `json:"code"`
+	Message string             `json:"message"`
+	Data    []DeliveryResponse `json:"data"`
+	Meta    any                `json:"meta,omitempty"`
+}
@@ -0,0 +1,242 @@
+package webhook
+
+import (
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func parseID(c *fiber.Ctx, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "Invalid "+name+" ID")
+	}
+	return id, nil
+}
+
+// GetAll godoc
+// @Summary      List webhook endpoints
+// @Description  Returns all registered webhook endpoints (without secrets). Admin only.
+// @Tags         Webhooks
+// @Produce      json
+// @Success      200  {object}  SwaggerEndpointListResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /webhooks [get]
+func (h *Handler) GetAll(c *fiber.Ctx) error {
+	endpoints, err := h.service.GetAll()
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get all webhook endpoints", endpoints)
+}
+
+// GetByID godoc
+// @Summary      Get a webhook endpoint
+// @Description  Returns a webhook endpoint (without its secret). Admin only.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path  string  true  "Endpoint ID (UUID)"
+// @Success      200  {object}  SwaggerEndpointResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /webhooks/{id} [get]
+func (h *Handler) GetByID(c *fiber.Ctx) error {
+	id, err := parseID(c, "endpoint")
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := h.service.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Success get webhook endpoint", endpoint)
+}
+
+// Create godoc
+// @Summary      Register a webhook endpoint
+// @Description  Registers a URL to receive domain events (journal.created, journal.posted, journal.reversed, coa.updated, period.closed). An empty eventTypes subscribes to all. Each request is signed in the X-Webhook-Signature header as "t=<unix>,v1=<hex HMAC-SHA256 of t.body>"; the secret is only returned in this response. Admin only.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        body body EndpointRequest true "Endpoint payload"
+// @Success      201  {object}  SwaggerCreatedEndpointResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /webhooks [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req EndpointRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := h.service.Create(&req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusCreated, "Webhook endpoint created. Store the secret now, it will not be shown again", endpoint)
+}
+
+// Update godoc
+// @Summary      Update a webhook endpoint
+// @Description  Changes the URL, description, subscribed event types or active flag of an endpoint. The secret is kept. Admin only.
+// @Tags         Webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id   path  string           true  "Endpoint ID (UUID)"
+// @Param        body body  EndpointRequest  true  "Endpoint payload"
+// @Success      200  {object}  SwaggerEndpointResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /webhooks/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := parseID(c, "endpoint")
+	if err != nil {
+		return err
+	}
+
+	var req EndpointRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	endpoint, err := h.service.Update(id, &req, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhook endpoint updated successfully", endpoint)
+}
+
+// Delete godoc
+// @Summary      Delete a webhook endpoint
+// @Description  Removes an endpoint; its pending deliveries are no longer sent. Admin only.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path  string  true  "Endpoint ID (UUID)"
+// @Success      200  {object}  model.SwaggerEmptyResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /webhooks/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := parseID(c, "endpoint")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Delete(id, audit.ActorFromCtx(c), tx); err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse[any](c, fiber.StatusOK, "Webhook endpoint deleted successfully", nil)
+}
+
+// GetDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Returns deliveries newest first. Use status=dead for the dead-letter list of deliveries that ran out of attempts. Admin only.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        page        query  int     false  "Page number"    minimum(1)
+// @Param        limit       query  int     false  "Items per page" minimum(1) maximum(100)
+// @Param        status      query  string  false  "Status (pending, delivered, dead)"
+// @Param        endpointId  query  string  false  "Endpoint ID (UUID)"
+// @Success      200  {object}  SwaggerDeliveryListResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /webhooks/deliveries [get]
+func (h *Handler) GetDeliveries(c *fiber.Ctx) error {
+	var query DeliveryQuery
+	if err := c.QueryParser(&query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.Limit < 1 || query.Limit > 100 {
+		query.Limit = 10
+	}
+
+	deliveries, meta, err := h.service.GetDeliveries(&query)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponsePaginate(c, fiber.StatusOK, "Success get webhook deliveries", deliveries, meta)
+}
+
+// Redeliver godoc
+// @Summary      Redeliver a webhook delivery
+// @Description  Queues a dead or delivered delivery again with a fresh set of attempts. The event keeps its ID so receivers can drop duplicates. Admin only.
+// @Tags         Webhooks
+// @Produce      json
+// @Param        id   path  string  true  "Delivery ID (UUID)"
+// @Success      200  {object}  SwaggerDeliveryResponse
+// @Failure      400  {object}  model.SwaggerErrorResponse
+// @Failure      401  {object}  model.SwaggerErrorResponse
+// @Failure      403  {object}  model.SwaggerErrorResponse
+// @Failure      404  {object}  model.SwaggerErrorResponse
+// @Failure      409  {object}  model.SwaggerErrorResponse
+// @Security     CookieAuth
+// @Router       /webhooks/deliveries/{id}/redeliver [post]
+func (h *Handler) Redeliver(c *fiber.Ctx) error {
+	id, err := parseID(c, "delivery")
+	if err != nil {
+		return err
+	}
+
+	tx, err := middleware.TxFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	delivery, err := h.service.Redeliver(id, audit.ActorFromCtx(c), tx)
+	if err != nil {
+		return err
+	}
+
+	return utils.SuccessResponse(c, fiber.StatusOK, "Webhook delivery queued", delivery)
+}
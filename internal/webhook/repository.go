@@ -0,0 +1,241 @@
+package webhook
+
+import (
+	"time"
+
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	FindAll() ([]domain.WebhookEndpoint, error)
+	FindByID(id uuid.UUID) (*domain.WebhookEndpoint, error)
+	Create(e *domain.WebhookEndpoint) error
+	Update(e *domain.WebhookEndpoint) error
+	Delete(id uuid.UUID) error
+	FindDeliveries(query *DeliveryQuery) ([]DeliveryResponse, int64, error)
+	FindDelivery(id uuid.UUID) (*DeliveryResponse, error)
+	Redeliver(id uuid.UUID) (bool, error)
+	FanOut(limit int) (int64, error)
+	LeaseDeliveries(limit int, until time.Time) ([]deliveryTask, error)
+	MarkDelivered(id string, statusCode int) error
+	MarkFailed(id string, statusCode int, message string, next *time.Time) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) FindAll() ([]domain.WebhookEndpoint, error) {
+	var endpoints []domain.WebhookEndpoint
+	err := r.db.Raw(
+		`SELECT id, url, COALESCE(description, '') AS description, event_types, is_active, created_by, created_at, updated_at
+		 FROM webhook_endpoints
+		 WHERE deleted_at IS NULL
+		 ORDER BY created_at DESC`,
+	).Scan(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *repository) FindByID(id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	var e domain.WebhookEndpoint
+	result := r.db.Raw(
+		`SELECT id, url, COALESCE(description, '') AS description, secret, event_types, is_active, created_by, created_at, updated_at
+		 FROM webhook_endpoints
+		 WHERE id = ? AND deleted_at IS NULL
+		 LIMIT 1`,
+		id,
+	).Scan(&e)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+func (r *repository) Create(e *domain.WebhookEndpoint) error {
+	return r.db.Exec(
+		`INSERT INTO webhook_endpoints (id, url, description, secret, event_types, is_active, created_by, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, NOW(), NOW())`,
+		e.ID, e.URL, e.Description, e.Secret, e.EventTypes, e.IsActive, e.CreatedBy,
+	).Error
+}
+
+func (r *repository) Update(e *domain.WebhookEndpoint) error {
+	return r.db.Exec(
+		`UPDATE webhook_endpoints
+		 SET url = ?, description = ?, event_types = ?, is_active = ?, updated_at = NOW()
+		 WHERE id = ? AND deleted_at IS NULL`,
+		e.URL, e.Description, e.EventTypes, e.IsActive, e.ID,
+	).Error
+}
+
+func (r *repository) Delete(id uuid.UUID) error {
+	return r.db.Exec(
+		`UPDATE webhook_endpoints SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL`,
+		id,
+	).Error
+}
+
+const deliveryColumns = `d.id, d.endpoint_id, e.url AS endpoint_url, d.event_id, o.type AS event_type,
+	o.entity_type, o.entity_id, d.status, d.attempts, d.next_attempt_at, d.last_status_code,
+	COALESCE(d.last_error, '') AS last_error, d.delivered_at, d.created_at, d.updated_at`
+
+// FindDeliveries lists deliveries newest first, e.g. the dead-letter list
+// with status=dead.
+func (r *repository) FindDeliveries(query *DeliveryQuery) ([]DeliveryResponse, int64, error) {
+	where := "1 = 1"
+	var args []interface{}
+	if query.Status != "" {
+		where += " AND d.status = ?"
+		args = append(args, query.Status)
+	}
+	if query.EndpointID != "" {
+		where += " AND d.endpoint_id = ?"
+		args = append(args, query.EndpointID)
+	}
+
+	var total int64
+	if err := r.db.Raw(`SELECT COUNT(*) FROM webhook_deliveries d WHERE `+where, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []DeliveryResponse
+	err := r.db.Raw(
+		`SELECT `+deliveryColumns+`
+		 FROM webhook_deliveries d
+		 JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		 JOIN outbox_events o ON o.id = d.event_id
+		 WHERE `+where+`
+		 ORDER BY d.created_at DESC
+		 LIMIT ? OFFSET ?`,
+		append(args, query.Limit, (query.Page-1)*query.Limit)...,
+	).Scan(&rows).Error
+	return rows, total, err
+}
+
+func (r *repository) FindDelivery(id uuid.UUID) (*DeliveryResponse, error) {
+	var d DeliveryResponse
+	result := r.db.Raw(
+		`SELECT `+deliveryColumns+`
+		 FROM webhook_deliveries d
+		 JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		 JOIN outbox_events o ON o.id = d.event_id
+		 WHERE d.id = ?
+		 LIMIT 1`,
+		id,
+	).Scan(&d)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &d, nil
+}
+
+// Redeliver queues a finished delivery again with a fresh set of attempts.
+// It reports false when the delivery is still pending.
+func (r *repository) Redeliver(id uuid.UUID) (bool, error) {
+	result := r.db.Exec(
+		`UPDATE webhook_deliveries
+		 SET status = 'pending', attempts = 0, next_attempt_at = NOW(), delivered_at = NULL, updated_at = NOW()
+		 WHERE id = ? AND status <> 'pending'`,
+		id,
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FanOut takes up to limit undispatched events in order, queues a delivery
+// for every active endpoint subscribed to each, and marks the events
+// dispatched, all in one statement. It returns the number of events taken.
+func (r *repository) FanOut(limit int) (int64, error) {
+	result := r.db.Exec(
+		`WITH batch AS (
+			SELECT id, type FROM outbox_events
+			WHERE dispatched_at IS NULL
+			ORDER BY seq
+			FOR UPDATE SKIP LOCKED
+			LIMIT ?
+		 ), queued AS (
+			INSERT INTO webhook_deliveries (id, endpoint_id, event_id, status, attempts, next_attempt_at, last_status_code, created_at, updated_at)
+			SELECT gen_random_uuid(), e.id, b.id, 'pending', 0, NOW(), 0, NOW(), NOW()
+			FROM batch b
+			JOIN webhook_endpoints e ON e.deleted_at IS NULL AND e.is_active
+				AND (e.event_types IS NULL OR jsonb_array_length(e.event_types) = 0 OR e.event_types @> jsonb_build_array(b.type))
+			ON CONFLICT DO NOTHING
+		 )
+		 UPDATE outbox_events SET dispatched_at = NOW() WHERE id IN (SELECT id FROM batch)`,
+		limit,
+	)
+	return result.RowsAffected, result.Error
+}
+
+// LeaseDeliveries claims up to limit due deliveries to active endpoints by
+// pushing their next attempt to until, so a dispatcher that dies mid-send
+// leaves them to be retried. SKIP LOCKED lets several instances share the
+// work.
+func (r *repository) LeaseDeliveries(limit int, until time.Time) ([]deliveryTask, error) {
+	var tasks []deliveryTask
+	err := r.db.Raw(
+		`UPDATE webhook_deliveries d
+		 SET attempts = d.attempts + 1, next_attempt_at = ?, updated_at = NOW()
+		 FROM webhook_endpoints e, outbox_events o
+		 WHERE d.id IN (
+			SELECT wd.id FROM webhook_deliveries wd
+			JOIN webhook_endpoints we ON we.id = wd.endpoint_id
+			WHERE wd.status = 'pending' AND wd.next_attempt_at <= NOW()
+			AND we.deleted_at IS NULL AND we.is_active
+			ORDER BY wd.next_attempt_at
+			FOR UPDATE OF wd SKIP LOCKED
+			LIMIT ?
+		 )
+		 AND e.id = d.endpoint_id
+		 AND o.id = d.event_id
+		 RETURNING d.id, d.attempts, e.url, e.secret, o.id AS event_id, o.type AS event_type,
+			o.entity_type, o.entity_id, o.payload, o.created_at`,
+		until, limit,
+	).Scan(&tasks).Error
+	return tasks, err
+}
+
+func (r *repository) MarkDelivered(id string, statusCode int) error {
+	return r.db.Exec(
+		`UPDATE webhook_deliveries
+		 SET status = 'delivered', last_status_code = ?, last_error = '', delivered_at = NOW(), updated_at = NOW()
+		 WHERE id = ?`,
+		statusCode, id,
+	).Error
+}
+
+// MarkFailed records a failed attempt and schedules the next one at next,
+// or moves the delivery to the dead-letter list when next is nil.
+func (r *repository) MarkFailed(id string, statusCode int, message string, next *time.Time) error {
+	if next == nil {
+		return r.db.Exec(
+			`UPDATE webhook_deliveries
+			 SET status = 'dead', last_status_code = ?, last_error = ?, updated_at = NOW()
+			 WHERE id = ?`,
+			statusCode, message, id,
+		).Error
+	}
+	return r.db.Exec(
+		`UPDATE webhook_deliveries
+		 SET last_status_code = ?, last_error = ?, next_attempt_at = ?, updated_at = NOW()
+		 WHERE id = ?`,
+		statusCode, message, *next, id,
+	).Error
+}
@@ -0,0 +1,21 @@
+package webhook
+
+import (
+	"fiber.com/session-api/pkg/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router fiber.Router, handler *Handler, db *gorm.DB) {
+	webhookRoutes := router.Group("/webhooks")
+	webhookRoutes.Use(middleware.AuthMiddleware(), middleware.RequireRole("admin"), middleware.Idempotency(db))
+
+	webhookRoutes.Get("/", handler.GetAll)
+	webhookRoutes.Post("/", middleware.DBTransaction(db), handler.Create)
+	webhookRoutes.Get("/deliveries", handler.GetDeliveries)
+	webhookRoutes.Post("/deliveries/:id/redeliver", middleware.DBTransaction(db), handler.Redeliver)
+	webhookRoutes.Get("/:id", handler.GetByID)
+	webhookRoutes.Put("/:id", middleware.DBTransaction(db), handler.Update)
+	webhookRoutes.Delete("/:id", middleware.DBTransaction(db), handler.Delete)
+}
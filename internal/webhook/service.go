@@ -0,0 +1,280 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+
+	"fiber.com/session-api/internal/audit"
+	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/event"
+	"fiber.com/session-api/pkg/model"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Secrets look like "whsec_<hex>". Endpoints use them to verify the
+// X-Webhook-Signature header.
+const secretPrefix = "whsec_"
+
+type Service interface {
+	GetAll() ([]EndpointResponse, error)
+	GetByID(id uuid.UUID) (*EndpointResponse, error)
+	Create(req *EndpointRequest, actor audit.Actor, tx *gorm.DB) (*CreatedEndpointResponse, error)
+	Update(id uuid.UUID, req *EndpointRequest, actor audit.Actor, tx *gorm.DB) (*EndpointResponse, error)
+	Delete(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error
+	GetDeliveries(query *DeliveryQuery) ([]DeliveryResponse, *model.MetaPagination, error)
+	Redeliver(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*DeliveryResponse, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func toResponse(e *domain.WebhookEndpoint) *EndpointResponse {
+	eventTypes := []string{}
+	if len(e.EventTypes) > 0 {
+		_ = json.Unmarshal(e.EventTypes, &eventTypes)
+	}
+	return &EndpointResponse{
+		ID:          e.ID.String(),
+		URL:         e.URL,
+		Description: e.Description,
+		EventTypes:  eventTypes,
+		IsActive:    e.IsActive,
+		CreatedBy:   e.CreatedBy,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+// checkRequest validates an endpoint payload and returns its subscribed
+// event types encoded for storage.
+func checkRequest(req *EndpointRequest) ([]byte, error) {
+	u, err := url.Parse(strings.TrimSpace(req.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "url must be an absolute http or https URL")
+	}
+	if len(req.Description) > 255 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "description must be at most 255 characters")
+	}
+
+	eventTypes := []string{}
+	for _, t := range req.EventTypes {
+		if !slices.Contains(event.Types, t) {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Unknown event type: "+t)
+		}
+		if !slices.Contains(eventTypes, t) {
+			eventTypes = append(eventTypes, t)
+		}
+	}
+	return json.Marshal(eventTypes)
+}
+
+func findEndpoint(repo Repository, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	e, err := repo.FindByID(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if e == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Webhook endpoint not found")
+	}
+	return e, nil
+}
+
+func (s *service) GetAll() ([]EndpointResponse, error) {
+	endpoints, err := s.repo.FindAll()
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	res := make([]EndpointResponse, len(endpoints))
+	for i, e := range endpoints {
+		res[i] = *toResponse(&e)
+	}
+	return res, nil
+}
+
+func (s *service) GetByID(id uuid.UUID) (*EndpointResponse, error) {
+	e, err := findEndpoint(s.repo, id)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(e), nil
+}
+
+func (s *service) Create(req *EndpointRequest, actor audit.Actor, tx *gorm.DB) (*CreatedEndpointResponse, error) {
+	txRepo := NewRepository(tx)
+
+	eventTypes, err := checkRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to generate webhook secret")
+	}
+
+	e := &domain.WebhookEndpoint{
+		ID:          uuid.New(),
+		URL:         strings.TrimSpace(req.URL),
+		Description: req.Description,
+		Secret:      secretPrefix + secret,
+		EventTypes:  eventTypes,
+		IsActive:    req.IsActive == nil || *req.IsActive,
+		CreatedBy:   actor.ID,
+	}
+	if err := txRepo.Create(e); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	created, err := findEndpoint(txRepo, e.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toResponse(created)
+	if err := audit.Record(tx, actor, audit.EntityWebhook, resp.ID, audit.ActionCreate, nil, resp); err != nil {
+		return nil, err
+	}
+
+	return &CreatedEndpointResponse{EndpointResponse: *resp, Secret: e.Secret}, nil
+}
+
+func (s *service) Update(id uuid.UUID, req *EndpointRequest, actor audit.Actor, tx *gorm.DB) (*EndpointResponse, error) {
+	txRepo := NewRepository(tx)
+
+	existing, err := findEndpoint(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+	before := toResponse(existing)
+
+	eventTypes, err := checkRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.URL = strings.TrimSpace(req.URL)
+	existing.Description = req.Description
+	existing.EventTypes = eventTypes
+	if req.IsActive != nil {
+		existing.IsActive = *req.IsActive
+	}
+	if err := txRepo.Update(existing); err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	updated, err := findEndpoint(txRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	after := toResponse(updated)
+	if err := audit.Record(tx, actor, audit.EntityWebhook, id.String(), audit.ActionUpdate, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// Delete removes an endpoint. Its pending deliveries are no longer sent.
+func (s *service) Delete(id uuid.UUID, actor audit.Actor, tx *gorm.DB) error {
+	txRepo := NewRepository(tx)
+
+	existing, err := findEndpoint(txRepo, id)
+	if err != nil {
+		return err
+	}
+	if err := txRepo.Delete(id); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return audit.Record(tx, actor, audit.EntityWebhook, id.String(), audit.ActionDelete, toResponse(existing), nil)
+}
+
+func (s *service) GetDeliveries(query *DeliveryQuery) ([]DeliveryResponse, *model.MetaPagination, error) {
+	switch domain.WebhookDeliveryStatus(query.Status) {
+	case "", domain.WebhookDeliveryPending, domain.WebhookDeliveryDelivered, domain.WebhookDeliveryDead:
+	default:
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "status must be one of pending, delivered or dead")
+	}
+	if query.EndpointID != "" {
+		if _, err := uuid.Parse(query.EndpointID); err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Invalid endpoint ID")
+		}
+	}
+
+	rows, total, err := s.repo.FindDeliveries(query)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if rows == nil {
+		rows = []DeliveryResponse{}
+	}
+
+	meta := &model.MetaPagination{
+		Page:      query.Page,
+		Limit:     query.Limit,
+		TotalPage: int(math.Ceil(float64(total) / float64(query.Limit))),
+		TotalData: int(total),
+	}
+	return rows, meta, nil
+}
+
+// Redeliver queues a dead or delivered delivery again. The endpoint gets
+// the same event ID, so receivers can drop duplicates.
+func (s *service) Redeliver(id uuid.UUID, actor audit.Actor, tx *gorm.DB) (*DeliveryResponse, error) {
+	txRepo := NewRepository(tx)
+
+	before, err := txRepo.FindDelivery(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if before == nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Webhook delivery not found")
+	}
+
+	endpoint, err := txRepo.FindByID(uuid.MustParse(before.EndpointID))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if endpoint == nil || !endpoint.IsActive {
+		return nil, fiber.NewError(fiber.StatusConflict, "Webhook endpoint is deleted or inactive")
+	}
+
+	ok, err := txRepo.Redeliver(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if !ok {
+		return nil, fiber.NewError(fiber.StatusConflict, "Webhook delivery is already pending")
+	}
+
+	after, err := txRepo.FindDelivery(id)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if err := audit.Record(tx, actor, audit.EntityWebhook, before.EndpointID, audit.ActionRedeliver, before, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -3,15 +3,30 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"fiber.com/session-api/config"
 	_ "fiber.com/session-api/docs"
+	"fiber.com/session-api/internal/allocation"
+	"fiber.com/session-api/internal/apikey"
+	"fiber.com/session-api/internal/audit"
 	"fiber.com/session-api/internal/auth"
 	"fiber.com/session-api/internal/coa"
+	"fiber.com/session-api/internal/dimension"
 	"fiber.com/session-api/internal/domain"
+	"fiber.com/session-api/internal/event"
+	"fiber.com/session-api/internal/job"
 	"fiber.com/session-api/internal/journal"
+	"fiber.com/session-api/internal/opening"
+	"fiber.com/session-api/internal/payable"
+	"fiber.com/session-api/internal/receivable"
+	"fiber.com/session-api/internal/recurring"
 	"fiber.com/session-api/internal/report"
+	"fiber.com/session-api/internal/trash"
+	"fiber.com/session-api/internal/webhook"
 	"fiber.com/session-api/pkg/middleware"
+	"fiber.com/session-api/pkg/scheduler"
 	"fiber.com/session-api/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -38,6 +53,10 @@ import (
 // @securityDefinitions.apikey CookieAuth
 // @in cookie
 // @name auth_token
+
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
 func main() {
 	config.Load()
 
@@ -46,13 +65,52 @@ func main() {
 
 	if err := db.AutoMigrate(
 		&domain.User{},
+		&domain.RecoveryCode{},
+		&domain.APIKey{},
+		&domain.AuditLog{},
 		&domain.ChartOfAccount{},
 		&domain.JournalEntry{},
 		&domain.JournalEntryDetail{},
+		&domain.Dimension{},
+		&domain.DimensionValue{},
+		&domain.AccountDimension{},
+		&domain.JournalLineDimension{},
+		&domain.AllocationRule{},
+		&domain.AllocationTarget{},
+		&domain.AllocationRun{},
+		&domain.JournalTemplate{},
+		&domain.JournalTemplateRun{},
+		&domain.IdempotencyKey{},
+		&domain.Job{},
+		&domain.OutboxEvent{},
+		&domain.WebhookEndpoint{},
+		&domain.WebhookDelivery{},
+		&domain.Customer{},
+		&domain.SalesInvoice{},
+		&domain.SalesInvoiceLine{},
+		&domain.Receipt{},
+		&domain.ReceiptAllocation{},
+		&domain.Vendor{},
+		&domain.PurchaseBill{},
+		&domain.PurchaseBillLine{},
+		&domain.Payment{},
+		&domain.PaymentAllocation{},
 	); err != nil {
 		log.Fatalf("Auto-migrate failed: %v", err)
 	}
 
+	if err := audit.Migrate(db); err != nil {
+		log.Fatalf("Audit migration failed: %v", err)
+	}
+
+	if err := coa.Migrate(db); err != nil {
+		log.Fatalf("COA migration failed: %v", err)
+	}
+
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(db, os.Args[1:]))
+	}
+
 	app := fiber.New(fiber.Config{
 		ErrorHandler: middleware.ErrorHandler,
 	})
@@ -60,7 +118,7 @@ func main() {
 	app.Use(recover.New())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:3000,http://localhost:5173,http://localhost:8080",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-API-Key, Idempotency-Key, Last-Event-ID",
 		AllowMethods:     "GET, POST, PUT, DELETE, OPTIONS",
 		AllowCredentials: true,
 	}))
@@ -72,34 +130,138 @@ func main() {
 
 	api := app.Group("/api/v1")
 
+	// Audit routes
+	auditRepo := audit.NewRepository(db)
+	auditService := audit.NewService(auditRepo)
+	auditHandler := audit.NewHandler(auditService)
+	audit.RegisterRoutes(api, auditHandler)
+
 	// Auth routes
 	authRepo := auth.NewRepository(db)
 	authService := auth.NewService(authRepo)
-	authHandler := auth.NewHandler(authService)
-	auth.RegisterRoutes(api, authHandler)
+	oidcService := auth.NewOIDCService()
+	authHandler := auth.NewHandler(authService, oidcService)
+	auth.RegisterRoutes(api, authHandler, db)
+
+	// API key routes
+	apiKeyRepo := apikey.NewRepository(db)
+	apiKeyService := apikey.NewService(apiKeyRepo)
+	apiKeyHandler := apikey.NewHandler(apiKeyService)
+	apikey.RegisterRoutes(api, apiKeyHandler, db)
+	middleware.SetAPIKeyVerifier(apiKeyService.Verify)
 
 	// COA routes
 	coaRepo := coa.NewRepository(db)
-	coaService := coa.NewService(coaRepo)
+	coaService := coa.NewService(coaRepo, auditService)
 	coaHandler := coa.NewHandler(coaService)
-	coa.RegisterRoutes(api, coaHandler)
+	coa.RegisterRoutes(api, coaHandler, db)
+
+	// Dimension routes
+	dimensionRepo := dimension.NewRepository(db)
+	dimensionService := dimension.NewService(dimensionRepo)
+	dimensionHandler := dimension.NewHandler(dimensionService)
+	dimension.RegisterRoutes(api, dimensionHandler, db)
 
 	// Journal routes
 	journalRepo := journal.NewRepository(db)
-	journalService := journal.NewService(journalRepo)
-	journalHandler := journal.NewHandler(journalService)
+	journalService := journal.NewService(journalRepo, auditService)
+	journalHandler := journal.NewHandler(journalService, db)
 	journal.RegisterRoutes(api, journalHandler, db)
 
+	// Allocation routes
+	allocationRepo := allocation.NewRepository(db)
+	allocationService := allocation.NewService(allocationRepo)
+	allocationHandler := allocation.NewHandler(allocationService)
+	allocation.RegisterRoutes(api, allocationHandler, db)
+
+	// Recurring journal routes
+	recurringRepo := recurring.NewRepository(db)
+	recurringService := recurring.NewService(recurringRepo, journalService)
+	recurringHandler := recurring.NewHandler(recurringService)
+	recurring.RegisterRoutes(api, recurringHandler, db)
+
+	// Opening balance routes
+	openingRepo := opening.NewRepository(db)
+	openingService := opening.NewService(openingRepo)
+	openingHandler := opening.NewHandler(openingService)
+	opening.RegisterRoutes(api, openingHandler, db)
+
+	// Accounts receivable routes
+	receivableRepo := receivable.NewRepository(db)
+	receivableService := receivable.NewService(receivableRepo, journalService)
+	receivableHandler := receivable.NewHandler(receivableService)
+	receivable.RegisterRoutes(api, receivableHandler, db)
+
+	// Accounts payable routes
+	payableRepo := payable.NewRepository(db)
+	payableService := payable.NewService(payableRepo, journalService)
+	payableHandler := payable.NewHandler(payableService)
+	payable.RegisterRoutes(api, payableHandler, db)
+
 	// Report routes
 	reportRepo := report.NewRepository(db)
-	reportService := report.NewService(reportRepo, coaRepo)
+	reportService := report.NewService(reportRepo, coaRepo, receivableRepo, payableRepo)
 	reportHandler := report.NewHandler(reportService)
 	report.RegisterRoutes(api, reportHandler)
 
+	// Trash routes
+	trashRepo := trash.NewRepository(db)
+	trashService := trash.NewService(trashRepo)
+	trashHandler := trash.NewHandler(trashService)
+	trash.RegisterRoutes(api, trashHandler, db)
+
+	// Webhook routes
+	webhookRepo := webhook.NewRepository(db)
+	webhookService := webhook.NewService(webhookRepo)
+	webhookHandler := webhook.NewHandler(webhookService)
+	webhook.RegisterRoutes(api, webhookHandler, db)
+
+	// Event stream routes
+	eventHub := event.NewHub(db)
+	stopEventHub := eventHub.Start()
+	defer stopEventHub()
+	eventHandler := event.NewHandler(eventHub)
+	event.RegisterRoutes(api, eventHandler)
+
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(utils.SuccessResponse[any](c, fiber.StatusOK, "Hello Accounting COA managenment from Fiber", nil))
 	})
 
+	// Background jobs
+	jobWorker := job.NewWorker(db)
+	jobWorker.Register(journal.JobTypeBulk, journal.BulkJobRunner(journalService, db))
+	jobWorker.Register(recurring.JobTypeRunDue, recurring.RunDueJobRunner(recurringService, db))
+	stopWorkers := jobWorker.Start(config.AppConfig.JobWorkers, time.Duration(max(config.AppConfig.JobPollSeconds, 1))*time.Second)
+	defer stopWorkers()
+
+	jobRepo := job.NewRepository(db)
+	jobService := job.NewService(jobRepo)
+	jobHandler := job.NewHandler(jobService)
+	job.RegisterRoutes(api, jobHandler)
+
+	// Webhook delivery
+	dispatcher := webhook.NewDispatcher(db)
+	stopDispatcher := dispatcher.Start(time.Duration(max(config.AppConfig.WebhookPollSeconds, 1)) * time.Second)
+	defer stopDispatcher()
+
+	if config.AppConfig.SchedulerEnabled {
+		interval := time.Duration(max(config.AppConfig.SchedulerIntervalMinutes, 1)) * time.Minute
+		stopScheduler := scheduler.Start(interval,
+			scheduler.Job{Name: "recurring-journals", Run: func(now time.Time) error {
+				_, err := recurringService.RunDue(db, now)
+				return err
+			}},
+			scheduler.Job{Name: "auto-reversals", Run: func(now time.Time) error {
+				_, err := journalService.RunReversals(db, now)
+				return err
+			}},
+			scheduler.Job{Name: "idempotency-keys", Run: func(now time.Time) error {
+				return middleware.PurgeIdempotencyKeys(db, now)
+			}},
+		)
+		defer stopScheduler()
+	}
+
 	addr := fmt.Sprintf(":%s", config.AppConfig.Port)
 	log.Printf("Server starting on http://localhost%s", addr)
 	log.Printf("Swagger UI: http://localhost%s/swagger/index.html", addr)
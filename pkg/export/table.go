@@ -0,0 +1,82 @@
+// Package export writes tabular data as CSV or XLSX downloads.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	CSV  = "csv"
+	XLSX = "xlsx"
+)
+
+// Table writes records, header row first, as CSV or XLSX; sheet names the
+// XLSX worksheet. Cells are strings, ints, float64 amounts or nil for
+// blanks; amounts stay numeric in XLSX so the sheet can be summed.
+func Table(sheet string, records [][]any, format string) ([]byte, error) {
+	switch format {
+	case CSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		for _, record := range records {
+			row := make([]string, len(record))
+			for i, cell := range record {
+				row[i] = csvCell(cell)
+			}
+			if err := w.Write(row); err != nil {
+				return nil, err
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+
+	case XLSX:
+		f := excelize.NewFile()
+		defer f.Close()
+
+		if err := f.SetSheetName("Sheet1", sheet); err != nil {
+			return nil, err
+		}
+		for i, record := range records {
+			cell, err := excelize.CoordinatesToCellName(1, i+1)
+			if err != nil {
+				return nil, err
+			}
+			if err := f.SetSheetRow(sheet, cell, &record); err != nil {
+				return nil, err
+			}
+		}
+
+		buf, err := f.WriteToBuffer()
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func csvCell(cell any) string {
+	switch v := cell.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
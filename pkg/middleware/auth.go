@@ -3,13 +3,55 @@ package middleware
 import (
 	"strings"
 
+	"fiber.com/session-api/config"
 	"fiber.com/session-api/pkg/utils"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func AuthMiddleware() fiber.Handler {
+const (
+	AuthTypeUser   = "user"
+	AuthTypeAPIKey = "api_key"
+
+	APIKeyHeader = "X-API-Key"
+	RoleService  = "service"
+)
+
+// APIKeyIdentity is the principal behind a verified API key.
+type APIKeyIdentity struct {
+	KeyID       string
+	ServiceName string
+	Scopes      []string
+}
+
+// APIKeyVerifier resolves a raw API key. It returns nil when the key is
+// unknown, revoked or expired.
+type APIKeyVerifier func(rawKey string) (*APIKeyIdentity, error)
+
+var apiKeyVerifier APIKeyVerifier
+
+// SetAPIKeyVerifier enables the X-API-Key header in AuthMiddleware.
+func SetAPIKeyVerifier(verifier APIKeyVerifier) {
+	apiKeyVerifier = verifier
+}
+
+type AuthConfig struct {
+	// AllowMFAEnrollment lets sessions of MFA-enforced roles that have not
+	// enrolled TOTP yet through, so they can reach the enrollment endpoints.
+	AllowMFAEnrollment bool
+}
+
+func AuthMiddleware(cfg ...AuthConfig) fiber.Handler {
+	var authCfg AuthConfig
+	if len(cfg) > 0 {
+		authCfg = cfg[0]
+	}
+
 	return func(c *fiber.Ctx) error {
+		if rawKey := c.Get(APIKeyHeader); rawKey != "" {
+			return authenticateAPIKey(c, rawKey)
+		}
+
 		tokenStr := ""
 
 		tokenStr = c.Cookies("auth_token")
@@ -30,11 +72,41 @@ func AuthMiddleware() fiber.Handler {
 			return fiber.NewError(fiber.StatusUnauthorized, "Invalid or expired token")
 		}
 
+		if !claims.MFAVerified && !authCfg.AllowMFAEnrollment && config.AppConfig.MFARequiredFor(claims.Role) {
+			return fiber.NewError(fiber.StatusForbidden, "Two-factor authentication enrollment is required for this role")
+		}
+
+		c.Locals("authType", AuthTypeUser)
 		c.Locals("userId", claims.UserID)
 		c.Locals("userName", claims.UserName)
 		c.Locals("email", claims.Email)
 		c.Locals("role", claims.Role)
+		c.Locals("mfaVerified", claims.MFAVerified)
 
 		return c.Next()
 	}
 }
+
+func authenticateAPIKey(c *fiber.Ctx, rawKey string) error {
+	if apiKeyVerifier == nil {
+		return fiber.NewError(fiber.StatusUnauthorized, "API key authentication is not enabled")
+	}
+
+	identity, err := apiKeyVerifier(rawKey)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if identity == nil {
+		return fiber.NewError(fiber.StatusUnauthorized, "Invalid, revoked or expired API key")
+	}
+
+	c.Locals("authType", AuthTypeAPIKey)
+	c.Locals("userId", identity.KeyID)
+	c.Locals("userName", identity.ServiceName)
+	c.Locals("email", "")
+	c.Locals("role", RoleService)
+	c.Locals("mfaVerified", false)
+	c.Locals("scopes", identity.Scopes)
+
+	return c.Next()
+}
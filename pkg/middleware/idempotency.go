@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"fiber.com/session-api/config"
+	"fiber.com/session-api/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	IdempotencyHeader = "Idempotency-Key"
+	// IdempotentReplayHeader is set on responses replayed from a stored key.
+	IdempotentReplayHeader = "Idempotent-Replayed"
+)
+
+// Idempotency makes mutating requests that carry an Idempotency-Key header
+// safe to retry. The first request claims the key for its caller; once it
+// succeeds its response is stored, and a retry with the same key and the
+// same method, URL and body gets that response back without running again.
+// Reusing a key for a different request is rejected with 409. Failed
+// requests release the key so they can be retried. It must run after
+// AuthMiddleware and before DBTransaction, so only committed work is stored.
+func Idempotency(db *gorm.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyHeader)
+		if key == "" {
+			return c.Next()
+		}
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+		if len(key) > 255 {
+			return fiber.NewError(fiber.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+		}
+
+		authType, _ := c.Locals("authType").(string)
+		userID, _ := c.Locals("userId").(string)
+		scope := authType + ":" + userID
+
+		sum := sha256.New()
+		sum.Write([]byte(c.Method() + "\n" + c.OriginalURL() + "\n"))
+		sum.Write(c.Body())
+		hash := hex.EncodeToString(sum.Sum(nil))
+
+		ttl := time.Duration(config.AppConfig.IdempotencyTTLHours) * time.Hour
+		claimed, err := claimIdempotencyKey(db, scope, key, hash, ttl)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		if !claimed {
+			return replayIdempotencyKey(c, db, scope, key, hash)
+		}
+
+		err = c.Next()
+		status := c.Response().StatusCode()
+		if err != nil || status >= fiber.StatusBadRequest {
+			if releaseErr := db.Exec(
+				`DELETE FROM idempotency_keys WHERE scope = ? AND key = ?`,
+				scope, key,
+			).Error; releaseErr != nil {
+				log.Printf("idempotency: release %q: %v", key, releaseErr)
+			}
+			return err
+		}
+
+		if storeErr := db.Exec(
+			`UPDATE idempotency_keys SET status_code = ?, content_type = ?, response = ?
+			 WHERE scope = ? AND key = ?`,
+			status, string(c.Response().Header.ContentType()), c.Response().Body(), scope, key,
+		).Error; storeErr != nil {
+			log.Printf("idempotency: store %q: %v", key, storeErr)
+		}
+		return nil
+	}
+}
+
+// claimIdempotencyKey reserves the key for this request. An expired key is
+// dropped first so it can be reused. It reports false when the key is
+// already held.
+func claimIdempotencyKey(db *gorm.DB, scope, key, hash string, ttl time.Duration) (bool, error) {
+	if err := db.Exec(
+		`DELETE FROM idempotency_keys WHERE scope = ? AND key = ? AND expires_at < NOW()`,
+		scope, key,
+	).Error; err != nil {
+		return false, err
+	}
+
+	result := db.Exec(
+		`INSERT INTO idempotency_keys (scope, key, request_hash, status_code, created_at, expires_at)
+		 VALUES (?, ?, ?, 0, NOW(), ?)
+		 ON CONFLICT (scope, key) DO NOTHING`,
+		scope, key, hash, time.Now().Add(ttl),
+	)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func replayIdempotencyKey(c *fiber.Ctx, db *gorm.DB, scope, key, hash string) error {
+	var stored domain.IdempotencyKey
+	result := db.Raw(
+		`SELECT scope, key, request_hash, status_code, content_type, response, created_at, expires_at
+		 FROM idempotency_keys
+		 WHERE scope = ? AND key = ?`,
+		scope, key,
+	).Scan(&stored)
+	if result.Error != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, result.Error.Error())
+	}
+
+	if result.RowsAffected == 0 || stored.StatusCode == 0 {
+		return fiber.NewError(fiber.StatusConflict, "A request with this Idempotency-Key is still in progress")
+	}
+	if stored.RequestHash != hash {
+		return fiber.NewError(fiber.StatusConflict, "Idempotency-Key was already used for a different request")
+	}
+
+	c.Set(IdempotentReplayHeader, "true")
+	if stored.ContentType != "" {
+		c.Set(fiber.HeaderContentType, stored.ContentType)
+	}
+	return c.Status(stored.StatusCode).Send(stored.Response)
+}
+
+// PurgeIdempotencyKeys deletes the keys whose TTL has passed.
+func PurgeIdempotencyKeys(db *gorm.DB, now time.Time) error {
+	return db.Exec(`DELETE FROM idempotency_keys WHERE expires_at < ?`, now).Error
+}
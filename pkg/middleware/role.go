@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireRole only lets through requests whose authenticated role is one of
+// the given roles. It must run after AuthMiddleware.
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		if !slices.Contains(roles, role) {
+			return fiber.NewError(fiber.StatusForbidden, "You do not have permission to access this resource")
+		}
+		return c.Next()
+	}
+}
+
+// RequireScope restricts API-key requests to keys holding the given scope.
+// Interactive user sessions are not scoped and always pass.
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Locals("authType") != AuthTypeAPIKey {
+			return c.Next()
+		}
+
+		scopes, _ := c.Locals("scopes").([]string)
+		if !slices.Contains(scopes, scope) {
+			return fiber.NewError(fiber.StatusForbidden, "API key is missing the required scope: "+scope)
+		}
+		return c.Next()
+	}
+}
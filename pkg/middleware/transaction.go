@@ -33,3 +33,12 @@ func DBTransaction(db *gorm.DB) fiber.Handler {
 		return nil
 	}
 }
+
+// TxFromCtx returns the transaction opened by DBTransaction for this request.
+func TxFromCtx(c *fiber.Ctx) (*gorm.DB, error) {
+	tx, ok := c.Locals("tx").(*gorm.DB)
+	if !ok || tx == nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Database transaction not available")
+	}
+	return tx, nil
+}
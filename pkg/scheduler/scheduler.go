@@ -0,0 +1,45 @@
+// Package scheduler runs periodic background jobs inside the API process.
+package scheduler
+
+import (
+	"log"
+	"time"
+)
+
+// Job is one periodic task. Run receives the tick time and must be safe to
+// repeat: a job that fails is simply tried again on the next tick.
+type Job struct {
+	Name string
+	Run  func(now time.Time) error
+}
+
+// Start runs every job once right away and then on each interval, one after
+// the other, until the returned stop function is called.
+func Start(interval time.Duration, jobs ...Job) (stop func()) {
+	done := make(chan struct{})
+
+	tick := func(now time.Time) {
+		for _, job := range jobs {
+			if err := job.Run(now); err != nil {
+				log.Printf("scheduler: %s failed: %v", job.Name, err)
+			}
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		tick(time.Now())
+		for {
+			select {
+			case now := <-ticker.C:
+				tick(now)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -0,0 +1,8 @@
+package utils
+
+import "math"
+
+// Round2 rounds an amount to cents.
+func Round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
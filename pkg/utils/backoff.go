@@ -0,0 +1,10 @@
+package utils
+
+import "time"
+
+// Backoff is the delay before retry attempt n of a failed job or delivery:
+// 30s, 1m, 2m, ... up to 1h.
+func Backoff(attempt int) time.Duration {
+	delay := 30 * time.Second << min(attempt-1, 7)
+	return min(delay, time.Hour)
+}
@@ -0,0 +1,9 @@
+package utils
+
+import "time"
+
+// DateOf truncates t to its calendar date at midnight UTC.
+func DateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
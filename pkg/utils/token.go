@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"time"
 
 	"fiber.com/session-api/config"
@@ -13,17 +14,45 @@ type JWTClaims struct {
 	UserName string `json:"userName"`
 	Email    string `json:"email"`
 	Role     string `json:"role"`
+	// MFAVerified is true when the session was opened with a second factor.
+	MFAVerified bool `json:"mfa,omitempty"`
+	// Purpose is empty for session tokens. Restricted tokens such as the
+	// "mfa pending" token set it and are rejected by ValidateToken.
+	Purpose string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID, userName, email, role string) (string, error) {
+const purposeMFAPending = "mfa_pending"
+
+var ErrTokenPurpose = errors.New("token is not valid for this purpose")
+
+func GenerateToken(userID, userName, email, role string, mfaVerified bool) (string, error) {
 	expiresAt := time.Now().Add(time.Duration(config.AppConfig.JWTExpiresHour) * time.Hour)
 
 	claims := JWTClaims{
-		UserID:   userID,
-		UserName: userName,
-		Email:    email,
-		Role:     role,
+		UserID:      userID,
+		UserName:    userName,
+		Email:       email,
+		Role:        role,
+		MFAVerified: mfaVerified,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(config.AppConfig.JWTSecret))
+}
+
+// GenerateMFAPendingToken issues a short-lived token proving the password step
+// succeeded. It can only be exchanged for a session token with a TOTP code.
+func GenerateMFAPendingToken(userID string) (string, error) {
+	expiresAt := time.Now().Add(time.Duration(config.AppConfig.MFAPendingMinutes) * time.Minute)
+
+	claims := JWTClaims{
+		UserID:  userID,
+		Purpose: purposeMFAPending,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -35,6 +64,28 @@ func GenerateToken(userID, userName, email, role string) (string, error) {
 }
 
 func ValidateToken(tokenStr string) (*JWTClaims, error) {
+	claims, err := parseToken(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, ErrTokenPurpose
+	}
+	return claims, nil
+}
+
+func ValidateMFAPendingToken(tokenStr string) (*JWTClaims, error) {
+	claims, err := parseToken(tokenStr)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != purposeMFAPending {
+		return nil, ErrTokenPurpose
+	}
+	return claims, nil
+}
+
+func parseToken(tokenStr string) (*JWTClaims, error) {
 	claims := &JWTClaims{}
 	token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {